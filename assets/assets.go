@@ -0,0 +1,9 @@
+// Package assets embeds the bundled artwork into the binaries
+package assets
+
+import "embed"
+
+// ASCII holds the bundled ASCII art, as ascii/<name>.txt
+//
+//go:embed ascii/*.txt
+var ASCII embed.FS
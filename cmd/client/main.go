@@ -2,10 +2,21 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/Nomadcxx/sysc-walls/internal/animations"
+	"github.com/Nomadcxx/sysc-walls/internal/art"
+	"github.com/Nomadcxx/sysc-walls/internal/away"
 	"github.com/Nomadcxx/sysc-walls/internal/config"
+	"github.com/Nomadcxx/sysc-walls/internal/record"
+	"github.com/Nomadcxx/sysc-walls/internal/theme"
 )
 
 func main() {
@@ -34,6 +45,16 @@ func main() {
 		handleStopCommand()
 	case "status":
 		handleStatusCommand()
+	case "render":
+		handleRenderCommand(os.Args[2:])
+	case "away":
+		handleAwayCommand(os.Args[2:])
+	case "art":
+		handleArtCommand(os.Args[2:])
+	case "theme":
+		handleThemeCommand(os.Args[2:])
+	case "effects":
+		handleEffectsCommand(os.Args[2:])
 	case "help", "--help", "-h":
 		printUsage()
 	default:
@@ -52,6 +73,11 @@ func printUsage() {
 	fmt.Println("  stop               Stop the daemon")
 	fmt.Println("  test [effect] [theme] Test screensaver immediately")
 	fmt.Println("  status             Check daemon status")
+	fmt.Println("  render [flags] <file> Render an effect to .cast, .gif or .png")
+	fmt.Println("  away <message> [--until T] Show a message on the screensaver")
+	fmt.Println("  art <list|add|preview|remove> Manage ASCII art in ~/.config/sysc-walls/ascii")
+	fmt.Println("  theme <list|import|check> Manage themes in ~/.config/sysc-walls/themes")
+	fmt.Println("  effects [--json]   List effects with what they support")
 	fmt.Println("  help               Show this help message")
 
 	fmt.Println("\nSet commands:")
@@ -65,6 +91,31 @@ func printUsage() {
 	fmt.Println("  sysc-walls run matrix dracula")
 	fmt.Println("  sysc-walls run fire nord")
 	fmt.Println("  sysc-walls run  # uses current config")
+
+	fmt.Println("\nRender commands:")
+	fmt.Println("  sysc-walls render --effect fire --size 80x24 --frames 200 fire.cast")
+	fmt.Println("  sysc-walls render --effect beams --theme nord --frames 60 beams.gif")
+
+	fmt.Println("\nAway commands:")
+	fmt.Println("  sysc-walls away \"In a meeting - ping on chat\" --until 15:00")
+	fmt.Println("  sysc-walls away BRB --until 10m")
+	fmt.Println("  sysc-walls away --clear")
+
+	fmt.Println("\nArt commands:")
+	fmt.Println("  sysc-walls art list")
+	fmt.Println("  sysc-walls art add ~/logo.txt [name]")
+	fmt.Println("  sysc-walls art preview logo")
+	fmt.Println("  sysc-walls art remove logo")
+
+	fmt.Println("\nTheme commands:")
+	fmt.Println("  sysc-walls theme list")
+	fmt.Println("  sysc-walls theme import ~/.config/kitty/current-theme.conf [name]")
+	fmt.Println("  sysc-walls theme import ~/.cache/wal/colors.json wal")
+	fmt.Println("  sysc-walls theme check ocean")
+
+	fmt.Println("\nEffects commands:")
+	fmt.Println("  sysc-walls effects")
+	fmt.Println("  sysc-walls effects --json")
 }
 
 func handleSetCommand(key, value string) {
@@ -180,3 +231,315 @@ func handleStatusCommand() {
 	fmt.Println("\nSystemd service status:")
 	fmt.Println("Use: systemctl status sysc-walls.service")
 }
+
+func handleRenderCommand(args []string) {
+	cfg := config.NewConfig()
+
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	effect := flags.String("effect", cfg.GetAnimationEffect(), "Animation effect to render")
+	theme := flags.String("theme", cfg.GetAnimationTheme(), "Color theme")
+	file := flags.String("file", "", "Text file for text-based effects")
+	size := flags.String("size", "80x24", "Terminal size in columns x rows")
+	fps := flags.Int("fps", 20, "Frames per second (1-120)")
+	frames := flags.Int("frames", 100, "Number of frames to render")
+	seed := flags.Int64("seed", 1, "Random seed, 0 for a different run each time")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sysc-walls render [flags] <out.cast|out.gif|out.png>\n\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	output := flags.Arg(0)
+
+	format, err := record.FormatFromPath(output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := record.Options{
+		Effect: *effect,
+		Theme:  *theme,
+		FPS:    *fps,
+		Frames: *frames,
+		Seed:   *seed,
+	}
+	if _, err := fmt.Sscanf(strings.ToLower(*size), "%dx%d", &opts.Width, &opts.Height); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid size %q (want e.g. 80x24)\n", *size)
+		os.Exit(1)
+	}
+	if *file != "" {
+		text, err := os.ReadFile(*file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", *file, err)
+			os.Exit(1)
+		}
+		opts.Text = string(text)
+	}
+
+	out, err := os.Create(output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	w := bufio.NewWriter(out)
+	err = record.Write(w, format, opts)
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(output)
+		fmt.Fprintf(os.Stderr, "Error rendering %s: %v\n", output, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Rendered %d frames of %s (%s) at %dx%d to %s\n", opts.Frames, opts.Effect, opts.Theme, opts.Width, opts.Height, output)
+}
+
+func handleAwayCommand(args []string) {
+	flags := flag.NewFlagSet("away", flag.ExitOnError)
+	until := flags.String("until", "", "When the message ends: HH:MM, YYYY-MM-DD HH:MM or a duration such as 45m")
+	clearMessage := flags.Bool("clear", false, "Remove the away message")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sysc-walls away <message> [--until T]\n       sysc-walls away --clear\n\n")
+		flags.PrintDefaults()
+	}
+
+	// Flags may come before or after the message
+	var words []string
+	for {
+		flags.Parse(args)
+		if flags.NArg() == 0 {
+			break
+		}
+		words = append(words, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+	path := away.Path()
+
+	if *clearMessage {
+		if err := away.Clear(path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Away message cleared")
+		return
+	}
+	if len(words) == 0 {
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	message := away.Message{Text: strings.Join(words, " ")}
+	if *until != "" {
+		t, err := away.ParseUntil(*until, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		message.Until = t
+	}
+	if err := away.Save(path, message); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if message.Until.IsZero() {
+		fmt.Printf("Away message set: %s\n", message.Text)
+	} else {
+		fmt.Printf("Away message set until %s: %s\n", message.Until.Format("Mon 15:04"), message.Text)
+	}
+}
+
+func handleArtCommand(args []string) {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, "Usage: sysc-walls art list\n")
+		fmt.Fprintf(os.Stderr, "       sysc-walls art add <file> [name]\n")
+		fmt.Fprintf(os.Stderr, "       sysc-walls art preview <name|file>\n")
+		fmt.Fprintf(os.Stderr, "       sysc-walls art remove <name>\n")
+		os.Exit(1)
+	}
+	if len(args) == 0 {
+		usage()
+	}
+	library := art.NewLibrary()
+
+	switch args[0] {
+	case "list":
+		entries, err := library.List()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, entry := range entries {
+			if entry.Bundled {
+				fmt.Printf("  %-20s (bundled)\n", entry.Name)
+			} else {
+				fmt.Printf("  %-20s %s\n", entry.Name, entry.Path)
+			}
+		}
+	case "add":
+		if len(args) < 2 || len(args) > 3 {
+			usage()
+		}
+		var name string
+		if len(args) == 3 {
+			name = args[2]
+		}
+		path, err := library.Add(args[1], name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Added %s\n", path)
+		fmt.Printf("Use it with: animation.file = %s\n", path)
+	case "preview":
+		if len(args) != 2 {
+			usage()
+		}
+		text, err := library.Read(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(text)
+	case "remove":
+		if len(args) != 2 {
+			usage()
+		}
+		if err := library.Remove(args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Removed %s\n", args[1])
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown art command: %s\n", args[0])
+		usage()
+	}
+}
+
+func handleThemeCommand(args []string) {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, "Usage: sysc-walls theme list\n")
+		fmt.Fprintf(os.Stderr, "       sysc-walls theme import <kitty.conf|base16.yaml|colors.json> [name]\n")
+		fmt.Fprintf(os.Stderr, "       sysc-walls theme check <name|file>\n")
+		os.Exit(1)
+	}
+	if len(args) == 0 {
+		usage()
+	}
+	printMissing := func(t *theme.Theme) {
+		if missing := t.Missing(); len(missing) > 0 {
+			fmt.Printf("Missing roles, using their defaults: %s\n", strings.Join(missing, ", "))
+		}
+	}
+
+	switch args[0] {
+	case "list":
+		files, err := theme.Files()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, path := range files {
+			fmt.Printf("  %-20s %s\n", strings.TrimSuffix(filepath.Base(path), theme.Extension), path)
+		}
+		for _, name := range config.AvailableThemes {
+			fmt.Printf("  %-20s (built in)\n", name)
+		}
+		fmt.Printf("  %-20s (the desktop's colors)\n", theme.Auto)
+	case "import":
+		if len(args) < 2 || len(args) > 3 {
+			usage()
+		}
+		var name string
+		if len(args) == 3 {
+			name = args[2]
+		}
+		t, err := theme.Import(args[1], name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		path, err := theme.Install(t)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Imported %s\n", path)
+		printMissing(t)
+		fmt.Printf("Use it with: sysc-walls set theme %s\n", t.Name)
+	case "check":
+		if len(args) != 2 {
+			usage()
+		}
+		var (
+			t   *theme.Theme
+			err error
+		)
+		if strings.ContainsRune(args[1], os.PathSeparator) || strings.HasSuffix(args[1], theme.Extension) {
+			t, err = theme.LoadFile(args[1])
+		} else {
+			t, err = theme.Find(args[1])
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s: %d colors\n", t.Name, len(t.Palette))
+		if t.Path == "" {
+			fmt.Println("Built-in theme, using the default roles")
+			return
+		}
+		printMissing(t)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown theme command: %s\n", args[0])
+		usage()
+	}
+}
+
+func handleEffectsCommand(args []string) {
+	flags := flag.NewFlagSet("effects", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "Print the effect registry as JSON")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sysc-walls effects [--json]\n\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 0 {
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	effects := animations.Effects()
+	if *asJSON {
+		out := json.NewEncoder(os.Stdout)
+		out.SetIndent("", "  ")
+		if err := out.Encode(effects); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	yesNo := map[bool]string{true: "yes", false: "no"}
+	fmt.Printf("  %-12s %-5s %-9s %-9s %s\n", "EFFECT", "TEXT", "DATETIME", "MIN SIZE", "PARAMETERS")
+	for _, e := range effects {
+		names := make([]string, len(e.Params))
+		for i, p := range e.Params {
+			names[i] = p.Name
+		}
+		size := fmt.Sprintf("%dx%d", e.MinWidth, e.MinHeight)
+		fmt.Printf("  %-12s %-5s %-9s %-9s %s\n", e.Name, yesNo[e.Text], yesNo[e.Datetime], size, strings.Join(names, ", "))
+	}
+	if err := animations.CheckRegistry(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
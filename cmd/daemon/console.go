@@ -0,0 +1,63 @@
+// console.go - Virtual console mode for machines without a display server
+package main
+
+import (
+	"log"
+
+	"github.com/Nomadcxx/sysc-walls/internal/vt"
+	"github.com/Nomadcxx/sysc-walls/pkg/idle"
+)
+
+// startConsoleMode opens the console for VT switching when the screensaver
+// runs on a virtual console instead of in a terminal window
+func (d *Daemon) startConsoleMode() {
+	displayServer := idle.DisplayServer()
+	if !d.config.UseConsole(displayServer) {
+		return
+	}
+
+	console, err := vt.Open(d.config.GetConsoleVT())
+	if err != nil {
+		log.Printf("Console mode unavailable: %v", err)
+		return
+	}
+
+	if d.debug {
+		log.Printf("Console mode: display server %s, drawing on VT %d (0 = first free)", displayServer, d.config.GetConsoleVT())
+	}
+	d.console = console
+}
+
+// launchOnConsole switches to the screensaver VT and runs the display on it
+func (d *Daemon) launchOnConsole() {
+	binary, args, err := d.config.GetConsoleCommandWith(d.launchOptions())
+	if err != nil {
+		log.Printf("ERROR: Invalid screensaver configuration: %v", err)
+		return
+	}
+
+	tty, err := d.console.Switch()
+	if err != nil {
+		log.Printf("Failed to switch to screensaver VT: %v", err)
+		return
+	}
+
+	if d.debug {
+		log.Printf("Launching screensaver on %s: %s %v", tty.Name(), binary, args)
+	}
+
+	if err := d.systemD.LaunchOnConsole(binary, args, tty, tty.Name()); err != nil {
+		log.Printf("Failed to launch screensaver: %v", err)
+		d.restoreConsole()
+	}
+}
+
+// restoreConsole switches back to the VT that was active before the screensaver
+func (d *Daemon) restoreConsole() {
+	if d.console == nil {
+		return
+	}
+	if err := d.console.Restore(); err != nil {
+		log.Printf("Failed to switch back from screensaver VT: %v", err)
+	}
+}
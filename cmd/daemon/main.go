@@ -11,16 +11,25 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/Nomadcxx/sysc-walls/internal/animations"
+	"github.com/Nomadcxx/sysc-walls/internal/away"
 	"github.com/Nomadcxx/sysc-walls/internal/compositor"
 	"github.com/Nomadcxx/sysc-walls/internal/config"
+	"github.com/Nomadcxx/sysc-walls/internal/logind"
+	"github.com/Nomadcxx/sysc-walls/internal/powerstate"
+	"github.com/Nomadcxx/sysc-walls/internal/schedule"
 	"github.com/Nomadcxx/sysc-walls/internal/systemd"
+	"github.com/Nomadcxx/sysc-walls/internal/theme"
 	"github.com/Nomadcxx/sysc-walls/internal/version"
+	"github.com/Nomadcxx/sysc-walls/internal/vt"
 	"github.com/Nomadcxx/sysc-walls/pkg/daemonize"
 	"github.com/Nomadcxx/sysc-walls/pkg/idle"
+	"github.com/Nomadcxx/sysc-walls/pkg/power"
 )
 
 // RAMA theme colors matching installer
@@ -43,19 +52,55 @@ type Daemon struct {
 	systemD   *systemd.SystemD
 	idleDet   *idle.IdleDetector
 	debug     bool
+
+	// Output power management
+	powerTimer *time.Timer
+	powerCtl   power.Controller
+	outputsOff bool
+	powerMu    sync.Mutex
+
+	// Battery and power-profile policy
+	powerState    *powerstate.Monitor
+	policy        config.PowerPolicy
+	policyChanges <-chan powerstate.State
+
+	// Time-of-day schedule
+	schedule      *schedule.Schedule
+	scheduled     schedule.Override
+	scheduleTimer *time.Timer
+	scheduleC     <-chan time.Time
+
+	// Focused-window rules
+	compositor compositor.Compositor
+	appRule    config.AppRule
+	idleSince  time.Time
+
+	// logind session
+	session         *logind.Session
+	sessionEvents   <-chan logind.Event
+	sessionInactive bool
+	idleHint        bool
+
+	// Virtual console mode, nil when running under a display server
+	console *vt.Console
 }
 
 // NewDaemon creates a new daemon instance
 func NewDaemon(cfg *config.Config) *Daemon {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Power timer starts stopped and is armed once the screensaver is up
+	powerTimer := time.NewTimer(cfg.GetPowerTimeout())
+	powerTimer.Stop()
+
 	return &Daemon{
-		config:    cfg,
-		idleTimer: time.NewTimer(cfg.GetIdleTimeout()),
-		ctx:       ctx,
-		cancel:    cancel,
-		systemD:   systemd.NewSystemD(cfg),
-		idleDet:   idle.NewIdleDetector(cfg),
+		config:     cfg,
+		idleTimer:  time.NewTimer(cfg.GetIdleTimeout()),
+		ctx:        ctx,
+		cancel:     cancel,
+		systemD:    systemd.NewSystemD(cfg),
+		idleDet:    idle.NewIdleDetector(cfg),
+		powerTimer: powerTimer,
 	}
 }
 
@@ -103,6 +148,10 @@ func main() {
 	if err := config.CheckSyscGoVersion(); err != nil {
 		log.Fatalf("sysc-Go version incompatibility: %v", err)
 	}
+	// Effects missing on either side only fail once chosen
+	if err := animations.CheckRegistry(); err != nil {
+		log.Printf("%v", err)
+	}
 
 	// Initialize config manager
 	cfg := config.NewConfig()
@@ -193,6 +242,18 @@ func (d *Daemon) Run() {
 	// Start activity monitoring via xinput if available
 	d.startActivityMonitoring()
 
+	// Without Wayland or X the screensaver gets a VT of its own
+	d.startConsoleMode()
+
+	// Follow AC/battery changes if any [policy.*] section is configured
+	d.startPolicyMonitoring()
+
+	// Apply [schedule.*] rules and follow their boundaries
+	d.startScheduleMonitoring()
+
+	// Pause on VT switch and follow lock-session and sleep via logind
+	d.startSessionMonitoring()
+
 	// Start main event loop
 	d.eventLoop()
 }
@@ -240,6 +301,17 @@ func (d *Daemon) eventLoop() {
 				log.Println("Timer triggered idle (fallback)")
 			}
 			d.onIdle()
+		case <-d.powerTimer.C:
+			if d.debug {
+				log.Println("Power timeout reached, turning outputs off")
+			}
+			d.onPowerTimeout()
+		case state := <-d.policyChanges:
+			d.applyPolicy(state, true)
+		case <-d.scheduleC:
+			d.applySchedule(true)
+		case event := <-d.sessionEvents:
+			d.onSessionEvent(event)
 		}
 	}
 }
@@ -251,25 +323,156 @@ func (d *Daemon) onActivity() {
 		log.Println("User activity detected")
 	}
 
+	// Coming back to a running screensaver ends the away message
+	if d.systemD.IsRunning() || d.areOutputsOff() {
+		if err := away.Clear(away.Path()); err != nil {
+			log.Printf("Failed to clear away message: %v", err)
+		}
+	}
+
+	d.idleSince = time.Time{}
 	d.resetIdleTimer()
+	d.powerTimer.Stop()
+	d.restoreOutputPower()
 	d.StopScreensaver()
+	d.setIdleHint(false)
 	log.Println("onActivity completed")
 }
 
 // onIdle handles idle timeout (launch screensaver)
 func (d *Daemon) onIdle() {
+	// Our session is switched away, the active one handles its own idling
+	if d.sessionInactive {
+		return
+	}
+
+	// Outputs are already dark, don't bring the screensaver back
+	if d.areOutputsOff() {
+		d.resetIdleTimer()
+		return
+	}
+
+	// Focused-window rules can inhibit or delay the screensaver
+	if !d.systemD.IsRunning() {
+		if wait := d.holdOffForWindow(); wait > 0 {
+			if d.debug {
+				log.Printf("Screensaver held off for %v by app rule", wait)
+			}
+			d.idleTimer.Reset(wait)
+			return
+		}
+	}
+
+	d.activateScreensaver()
+	d.resetIdleTimer()
+}
+
+// activateScreensaver launches the screensaver, or goes straight to
+// powering outputs off when the active policy or schedule disables the animation
+func (d *Daemon) activateScreensaver() {
+	d.setIdleHint(true)
+
+	if d.skipScreensaver() {
+		if d.debug {
+			log.Println("System idle, policy or schedule skips screensaver - turning outputs off")
+		}
+		d.turnOutputsOff()
+		return
+	}
+
 	if d.debug {
 		log.Println("System idle, launching screensaver")
 	}
 
 	d.LaunchScreensaver()
-	d.resetIdleTimer()
+
+	// A schedule with power = on keeps outputs lit for as long as it is active
+	if d.config.IsPowerEnabled() && d.scheduled.Power != schedule.PowerOn && d.systemD.IsRunning() {
+		d.powerTimer.Reset(d.config.GetPowerTimeout())
+	}
+}
+
+// onPowerTimeout turns outputs off after the screensaver has run for power.timeout
+func (d *Daemon) onPowerTimeout() {
+	if !d.systemD.IsRunning() {
+		return
+	}
+
+	d.turnOutputsOff()
+}
+
+// turnOutputsOff stops or pauses displays and powers all outputs off
+func (d *Daemon) turnOutputsOff() {
+	if d.powerCtl == nil {
+		ctl, err := power.NewController(d.config.GetPowerMethod())
+		if err != nil {
+			log.Printf("Output power control unavailable: %v", err)
+			return
+		}
+		d.powerCtl = ctl
+		if d.debug {
+			log.Printf("Using power backend: %s", ctl.Name())
+		}
+	}
+
+	// Leave the screensaver running if the outputs stay on
+	if err := d.powerCtl.SetPower(false); err != nil {
+		log.Printf("Failed to turn outputs off: %v", err)
+		return
+	}
+
+	d.powerMu.Lock()
+	d.outputsOff = true
+	d.powerMu.Unlock()
+
+	// Nothing should render while the screens are dark
+	if d.config.GetPowerDisplay() == "pause" {
+		if err := d.systemD.PauseScreensaver(); err != nil {
+			log.Printf("Failed to pause screensaver: %v", err)
+		}
+	} else {
+		d.StopScreensaver()
+	}
+}
+
+// restoreOutputPower turns outputs back on if the daemon turned them off
+func (d *Daemon) restoreOutputPower() {
+	d.powerMu.Lock()
+	defer d.powerMu.Unlock()
+
+	if !d.outputsOff || d.powerCtl == nil {
+		return
+	}
+
+	if err := d.powerCtl.SetPower(true); err != nil {
+		log.Printf("Failed to turn outputs on: %v", err)
+		return
+	}
+	d.outputsOff = false
+
+	// Paused displays carry on, and can exit when the screensaver stops
+	if d.config.GetPowerDisplay() == "pause" {
+		if err := d.systemD.ResumeScreensaver(); err != nil {
+			log.Printf("Failed to resume screensaver: %v", err)
+		}
+	}
+
+	if d.debug {
+		log.Println("Outputs powered back on")
+	}
+}
+
+// areOutputsOff reports whether the daemon has powered outputs off
+func (d *Daemon) areOutputsOff() bool {
+	d.powerMu.Lock()
+	defer d.powerMu.Unlock()
+	return d.outputsOff
 }
 
 // resetIdleTimer resets the idle timeout timer
 func (d *Daemon) resetIdleTimer() {
 	d.idleTimer.Stop()
-	d.idleTimer.Reset(d.config.GetIdleTimeout())
+	d.idleTimer.Reset(d.idleTimeout())
 }
 
 // LaunchScreensaver starts the screensaver on all monitors
@@ -282,8 +485,13 @@ func (d *Daemon) LaunchScreensaver() {
 		return
 	}
 
+	if d.console != nil {
+		d.launchOnConsole()
+		return
+	}
+
 	// Get validated screensaver command
-	terminal, args, err := d.config.GetScreensaverCommand()
+	terminal, args, err := d.config.GetScreensaverCommandWith(d.launchOptions())
 	if err != nil {
 		log.Printf("ERROR: Invalid screensaver configuration: %v", err)
 		return
@@ -425,6 +633,9 @@ func (d *Daemon) StopScreensaver() {
 		}
 	}
 
+	// Back to the VT the user was on
+	d.restoreConsole()
+
 	if d.debug {
 		log.Println("StopScreensaver finished")
 	}
@@ -434,11 +645,23 @@ func (d *Daemon) StopScreensaver() {
 func (d *Daemon) Shutdown() {
 	d.cancel()
 
+	// Never leave the outputs dark on exit
+	d.restoreOutputPower()
+
 	// Stop screensaver
 	d.StopScreensaver()
 
-	// Stop timer
+	// Stop timers
 	d.idleTimer.Stop()
+	d.powerTimer.Stop()
+
+	if d.session != nil {
+		d.setIdleHint(false)
+		d.session.Close()
+	}
+	if d.console != nil {
+		d.console.Close()
+	}
 }
 
 // setupLogging sets up logging to a file for daemonized processes
@@ -558,6 +781,20 @@ func showTestMode(daemon *Daemon, debugMode bool, sigChan chan os.Signal) {
 	fmt.Println(colorAccent.Render("✓ Stopped"))
 }
 
+// demoEffects returns the effects the demo shows, text-based effects first,
+// then non-text effects
+func demoEffects() []string {
+	var text, other []string
+	for _, e := range animations.Effects() {
+		if e.Text {
+			text = append(text, e.Name)
+		} else {
+			other = append(other, e.Name)
+		}
+	}
+	return append(text, other...)
+}
+
 // showDemoMode cycles through all effects for recording showcase
 func showDemoMode(daemon *Daemon, debugMode bool, sigChan chan os.Signal) {
 	// Show ASCII art header
@@ -570,24 +807,7 @@ func showDemoMode(daemon *Daemon, debugMode bool, sigChan chan os.Signal) {
 
 	daemon.debug = debugMode
 
-	// Define demo effect order (text-based effects first, then non-text effects)
-	// Note: decrypt, pour, and print are not yet implemented in sysc-Go
-	demoEffects := []string{
-		// Text-based effects
-		"fire-text",
-		"matrix-art",
-		"beam-text",
-		"rain-art",
-		"ring-text",
-		"blackhole",
-		// Non-text effects
-		"matrix",
-		"rain",
-		"fire",
-		"fireworks",
-		"beams",
-		"aquarium",
-	}
+	demoEffects := demoEffects()
 
 	effectDuration := 15 * time.Second
 	theme := daemon.config.GetAnimationTheme()
@@ -728,7 +948,7 @@ func showUsage() {
 	fmt.Println()
 
 	fmt.Println(colorSecondary.Render("Available Themes:"))
-	fmt.Println("  " + colorMuted.Render(strings.Join(config.AvailableThemes, ", ")))
+	fmt.Println("  " + colorMuted.Render(strings.Join(theme.Names(), ", ")))
 	fmt.Println()
 
 	fmt.Println(colorMuted.Render("Config: ~/.config/sysc-walls/daemon.conf"))
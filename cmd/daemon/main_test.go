@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/config"
+)
+
+// fakePower records power changes and fails them while err is set
+type fakePower struct {
+	err   error
+	calls []bool
+}
+
+func (p *fakePower) SetPower(on bool) error {
+	p.calls = append(p.calls, on)
+	return p.err
+}
+
+func (p *fakePower) Name() string {
+	return "fake"
+}
+
+// isStopped reports whether a process is stopped, giving a signal sent to
+// it up to a second to land when it isn't yet as expected
+func isStopped(t *testing.T, pid int, expect bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The state follows the command name, which is in parentheses
+		fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+		stopped := fields[0] == "T"
+		if stopped == expect || time.Now().After(deadline) {
+			return stopped
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestTurnOutputsOff tests that displays are paused only once the outputs
+// are really off, and carry on when they come back
+func TestTurnOutputsOff(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "power.conf")
+	if err := os.WriteFile(configPath, []byte("[power]\ndisplay = pause\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.NewConfig()
+	if err := cfg.LoadFromFile(configPath); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDaemon(cfg)
+	defer d.systemD.StopScreensaver()
+	if err := d.systemD.LaunchScreensaver("sleep", []string{"60"}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	pids, err := d.systemD.GetPIDs()
+	if err != nil || len(pids) != 1 {
+		t.Fatalf("GetPIDs() = %v, %v", pids, err)
+	}
+	pid := pids[0]
+
+	// A failing backend leaves the display running
+	backend := &fakePower{err: errors.New("no outputs")}
+	d.powerCtl = backend
+	d.turnOutputsOff()
+	if d.areOutputsOff() {
+		t.Error("outputs marked off after SetPower failed")
+	}
+	if isStopped(t, pid, true) {
+		t.Error("display paused although the outputs stayed on")
+	}
+
+	backend.err = nil
+	d.turnOutputsOff()
+	if !d.areOutputsOff() {
+		t.Error("outputs not marked off")
+	}
+	if !isStopped(t, pid, true) {
+		t.Error("display not paused after the outputs went off")
+	}
+
+	d.restoreOutputPower()
+	if d.areOutputsOff() {
+		t.Error("outputs still marked off after restoring")
+	}
+	if isStopped(t, pid, false) {
+		t.Error("display still paused after the outputs came back")
+	}
+	if want := []bool{false, false, true}; !slices.Equal(backend.calls, want) {
+		t.Errorf("SetPower calls = %v, want %v", backend.calls, want)
+	}
+}
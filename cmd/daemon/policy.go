@@ -0,0 +1,88 @@
+// policy.go - Battery and power-profile aware screensaver policy
+package main
+
+import (
+	"log"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/config"
+	"github.com/Nomadcxx/sysc-walls/internal/powerstate"
+)
+
+// startPolicyMonitoring applies the current power policy and watches for
+// AC/battery or profile changes
+func (d *Daemon) startPolicyMonitoring() {
+	if !d.config.HasPowerPolicies() {
+		return
+	}
+
+	d.powerState = powerstate.NewMonitor(d.config.UsePolicyProfiles())
+	d.applyPolicy(d.powerState.Current(), false)
+	d.powerState.Start(d.ctx)
+	d.policyChanges = d.powerState.Changes()
+}
+
+// applyPolicy resolves the policy for a power state and applies it.
+// When live is set, a running screensaver is relaunched with the new policy.
+func (d *Daemon) applyPolicy(state powerstate.State, live bool) {
+	names := state.PolicyNames(d.config.GetPolicyLowBattery())
+	policy := d.config.ResolvePowerPolicy(names)
+
+	if d.debug {
+		log.Printf("Power state: battery=%v capacity=%d profile=%q -> policies %v", state.OnBattery, state.Capacity, state.Profile, names)
+	}
+
+	if reflect.DeepEqual(policy, d.policy) {
+		return
+	}
+	d.policy = policy
+
+	if err := d.idleDet.SetTimeout(d.idleTimeout()); err != nil {
+		log.Printf("Failed to update idle timeout: %v", err)
+	}
+	d.resetIdleTimer()
+
+	if !live || !d.systemD.IsRunning() {
+		return
+	}
+
+	if d.debug {
+		log.Println("Power policy changed, relaunching screensaver")
+	}
+	d.powerTimer.Stop()
+	d.StopScreensaver()
+	d.activateScreensaver()
+}
+
+// idleTimeout returns the idle timeout with the active policy and schedule
+// applied. Power policies win over schedules.
+func (d *Daemon) idleTimeout() time.Duration {
+	if d.policy.Timeout > 0 {
+		return d.policy.Timeout
+	}
+	if d.scheduled.Timeout > 0 {
+		return d.scheduled.Timeout
+	}
+	return d.config.GetIdleTimeout()
+}
+
+// launchOptions returns the per-launch command overrides from the active
+// policy, schedule and focused-window rule
+func (d *Daemon) launchOptions() config.LaunchOptions {
+	opts := config.LaunchOptions{
+		Effect:   d.scheduled.Effect,
+		Theme:    d.scheduled.Theme,
+		FPS:      d.policy.FPS,
+		Datetime: d.scheduled.Datetime,
+	}
+	if len(d.policy.Effects) > 0 {
+		opts.Effect = d.policy.Effects[rand.Intn(len(d.policy.Effects))]
+	}
+	// A matching focused-window rule is the most specific override
+	if d.appRule.Effect != "" {
+		opts.Effect = d.appRule.Effect
+	}
+	return opts
+}
@@ -0,0 +1,68 @@
+// rules.go - Focused-window rules evaluated when idle triggers
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/compositor"
+	"github.com/Nomadcxx/sysc-walls/internal/config"
+)
+
+// holdOffForWindow matches [rules] against the focused window and returns
+// how long to hold off the screensaver, or 0 to launch it now
+func (d *Daemon) holdOffForWindow() time.Duration {
+	d.appRule = config.AppRule{}
+	if !d.config.HasAppRules() {
+		return 0
+	}
+
+	if d.idleSince.IsZero() {
+		d.idleSince = time.Now()
+	}
+
+	if d.compositor == nil {
+		comp, err := compositor.DetectCompositor()
+		if err != nil {
+			if d.debug {
+				log.Printf("App rules unavailable: %v", err)
+			}
+			return 0
+		}
+		d.compositor = comp
+	}
+
+	window, err := d.compositor.FocusedWindow()
+	if err != nil {
+		log.Printf("Failed to query focused window: %v", err)
+		return 0
+	}
+	if window == nil {
+		return 0
+	}
+
+	rule, ok := d.config.MatchAppRule(window.AppID, window.Fullscreen)
+	if !ok {
+		return 0
+	}
+	d.appRule = rule
+
+	if d.debug {
+		log.Printf("Focused window %q (fullscreen=%v) matches rule %q", window.AppID, window.Fullscreen, rule.Pattern)
+	}
+
+	// Inhibited windows are checked again after another idle period
+	if rule.Inhibit {
+		return d.idleTimeout()
+	}
+
+	// Idle fired one timeout after the last input, so wait out the rest
+	if rule.TimeoutScale > 1 {
+		extra := time.Duration(float64(d.idleTimeout()) * (rule.TimeoutScale - 1))
+		if wait := time.Until(d.idleSince.Add(extra)); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
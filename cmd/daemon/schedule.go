@@ -0,0 +1,77 @@
+// schedule.go - Time-of-day schedule handling
+package main
+
+import (
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/schedule"
+)
+
+// startScheduleMonitoring applies the active schedule rules and arms a
+// timer for the next rule boundary
+func (d *Daemon) startScheduleMonitoring() {
+	rules := d.config.GetScheduleRules()
+	if len(rules) == 0 {
+		return
+	}
+
+	d.schedule = schedule.New(rules)
+	d.applySchedule(false)
+}
+
+// applySchedule re-evaluates the schedule and re-arms the boundary timer.
+// When live is set, a running screensaver is relaunched with the new overrides.
+func (d *Daemon) applySchedule(live bool) {
+	override, names := d.schedule.Current()
+	d.armScheduleTimer()
+
+	if d.debug {
+		log.Printf("Schedule: active rules %v", names)
+	}
+
+	if reflect.DeepEqual(override, d.scheduled) {
+		return
+	}
+	d.scheduled = override
+
+	if err := d.idleDet.SetTimeout(d.idleTimeout()); err != nil {
+		log.Printf("Failed to update idle timeout: %v", err)
+	}
+	d.resetIdleTimer()
+
+	if !live || !d.systemD.IsRunning() {
+		return
+	}
+
+	if d.debug {
+		log.Println("Schedule changed, relaunching screensaver")
+	}
+	d.powerTimer.Stop()
+	d.StopScreensaver()
+	d.activateScreensaver()
+}
+
+// armScheduleTimer sets the schedule timer to fire at the next rule boundary
+func (d *Daemon) armScheduleTimer() {
+	next, ok := d.schedule.NextBoundary()
+	if !ok {
+		return
+	}
+
+	// Land just past the boundary so the new rule is already active
+	wait := time.Until(next) + time.Second
+	if d.scheduleTimer == nil {
+		d.scheduleTimer = time.NewTimer(wait)
+		d.scheduleC = d.scheduleTimer.C
+		return
+	}
+	d.scheduleTimer.Reset(wait)
+}
+
+// skipScreensaver reports whether the animation should be skipped and
+// outputs powered off straight away
+func (d *Daemon) skipScreensaver() bool {
+	return d.policy.NoScreensaver || d.scheduled.Power == schedule.PowerOff
+}
@@ -0,0 +1,98 @@
+// session.go - logind session integration
+package main
+
+import (
+	"log"
+
+	"github.com/Nomadcxx/sysc-walls/internal/logind"
+)
+
+// startSessionMonitoring connects to logind and follows the user's session.
+// The daemon keeps working without it, e.g. on systems without systemd.
+func (d *Daemon) startSessionMonitoring() {
+	if !d.config.IsLogindEnabled() {
+		return
+	}
+
+	session, err := logind.Connect()
+	if err != nil {
+		log.Printf("logind integration unavailable: %v", err)
+		return
+	}
+	if err := session.Start(d.ctx); err != nil {
+		log.Printf("logind integration unavailable: %v", err)
+		session.Close()
+		return
+	}
+	if err := session.TakeSleepLock(); err != nil {
+		log.Printf("Screensaver may still be running at suspend: %v", err)
+	}
+
+	if active, err := session.IsActive(); err == nil && !active {
+		d.sessionInactive = true
+		d.idleTimer.Stop()
+	}
+
+	if d.debug {
+		log.Printf("Following logind session %s", session.Path())
+	}
+	d.session = session
+	d.sessionEvents = session.Events()
+}
+
+// onSessionEvent reacts to logind session and sleep signals
+func (d *Daemon) onSessionEvent(event logind.Event) {
+	if d.debug {
+		log.Printf("logind session event: %v", event)
+	}
+
+	switch event {
+	case logind.Lock:
+		// loginctl lock-session brings the screensaver up right away
+		if !d.sessionInactive && !d.systemD.IsRunning() {
+			d.activateScreensaver()
+		}
+	case logind.Unlock:
+		d.onActivity()
+	case logind.Inactive:
+		// In console mode our own switch to the screensaver VT deactivates the session
+		if d.console != nil && d.systemD.IsRunning() {
+			return
+		}
+		// Another session owns the seat, nothing should run until we are back
+		d.sessionInactive = true
+		d.idleTimer.Stop()
+		d.powerTimer.Stop()
+		d.StopScreensaver()
+	case logind.Active:
+		d.sessionInactive = false
+		d.onActivity()
+	case logind.Sleep:
+		d.idleTimer.Stop()
+		d.powerTimer.Stop()
+		d.StopScreensaver()
+		d.session.ReleaseSleepLock()
+	case logind.Resume:
+		// Timers run on the monotonic clock, which stops while asleep, so
+		// the schedule boundary timer is late by the time spent suspended
+		if d.schedule != nil {
+			d.applySchedule(false)
+		}
+		d.onActivity()
+		if err := d.session.TakeSleepLock(); err != nil {
+			log.Printf("Failed to re-take sleep inhibitor: %v", err)
+		}
+	}
+}
+
+// setIdleHint reports the idle state to logind when it changes
+func (d *Daemon) setIdleHint(idle bool) {
+	if d.session == nil || d.idleHint == idle {
+		return
+	}
+	if err := d.session.SetIdleHint(idle); err != nil {
+		log.Printf("Failed to update logind idle hint: %v", err)
+		return
+	}
+	d.idleHint = idle
+}
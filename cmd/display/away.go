@@ -0,0 +1,120 @@
+// away.go - The away message set with 'sysc-walls away'
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/away"
+	"github.com/Nomadcxx/sysc-walls/internal/clock"
+	"github.com/Nomadcxx/sysc-walls/internal/figlet"
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+	"github.com/mattn/go-runewidth"
+)
+
+// Away message styles
+const (
+	awayCenter = "center" // Large in the middle of the screen
+	awayBanner = "banner" // One line across the top
+)
+
+// maxBlockRunes is the longest message drawn in the block font; longer
+// ones are drawn as plain text
+const maxBlockRunes = 16
+
+// awayLines lays out a message for a screen width. In the center style
+// short messages are drawn in font and long ones are wrapped.
+func awayLines(m away.Message, style string, font *figlet.Font, width int, now time.Time) []string {
+	caption := untilCaption(m, now)
+	if style == awayBanner {
+		line := m.Text
+		if caption != "" {
+			line += " - " + caption
+		}
+		return []string{runewidth.Truncate(line, width-2, "...")}
+	}
+
+	var lines []string
+	if len([]rune(m.Text)) <= maxBlockRunes {
+		lines = clock.DrawText(font, m.Text)
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " ")
+		}
+		if clock.GetMaxLineWidth(lines) > width-4 {
+			lines = nil
+		}
+	}
+	if lines == nil {
+		lines = wrapText(m.Text, width-8)
+	}
+	if caption != "" {
+		lines = append(lines, "", caption)
+	}
+	return lines
+}
+
+// untilCaption says when the message ends: "until 15:00", with the weekday
+// once it is more than a day away
+func untilCaption(m away.Message, now time.Time) string {
+	if m.Until.IsZero() {
+		return ""
+	}
+	until := m.Until.Local()
+	if until.Sub(now) >= 24*time.Hour || until.Day() != now.Day() {
+		return "until " + until.Format("Mon 15:04")
+	}
+	return "until " + until.Format("15:04")
+}
+
+// wrapText breaks text into lines of at most width cells at spaces.
+// Words wider than a line are cut.
+func wrapText(text string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		for runewidth.StringWidth(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			head := runewidth.Truncate(word, width, "")
+			if head == "" {
+				// A wide character on a one-cell line
+				head = string([]rune(word)[:1])
+			}
+			lines = append(lines, head)
+			word = word[len(head):]
+		}
+		switch {
+		case line == "":
+			line = word
+		case runewidth.StringWidth(line)+1+runewidth.StringWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// overlayAway draws the away message onto the animation's cell grid, in
+// bright white over a dimmed band like the clock
+func overlayAway(grid *render.Grid, m away.Message, style string, font *figlet.Font) {
+	lines := awayLines(m, style, font, grid.Width, time.Now())
+
+	startLine := 1
+	if style != awayBanner {
+		startLine = (grid.Height - len(lines)) / 2
+	}
+	if startLine < 0 {
+		startLine = 0
+	}
+	composite(grid, centeredLayers(lines, grid.Width, startLine, "\x1b[38;2;255;255;255m", 0.35)...)
+}
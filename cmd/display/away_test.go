@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/away"
+	"github.com/Nomadcxx/sysc-walls/internal/figlet"
+)
+
+// TestAwayLines tests the center and banner layouts of away messages
+func TestAwayLines(t *testing.T) {
+	font := figlet.MustLoad("block")
+	now := time.Date(2026, 3, 4, 14, 0, 0, 0, time.Local)
+	until := time.Date(2026, 3, 4, 15, 0, 0, 0, time.Local)
+
+	// Short messages are drawn in the font, with the end time below
+	lines := awayLines(away.Message{Text: "BRB", Until: until}, awayCenter, font, 80, now)
+	big := font.Render("BRB")
+	if len(lines) != len(big)+2 || lines[len(lines)-1] != "until 15:00" {
+		t.Errorf("short message = %q", lines)
+	}
+
+	// Long ones are wrapped plain text
+	long := "In a meeting until three, ping me on chat if it is urgent"
+	lines = awayLines(away.Message{Text: long}, awayCenter, font, 40, now)
+	want := []string{"In a meeting until three, ping", "me on chat if it is urgent"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("long message = %q, want %q", lines, want)
+	}
+
+	// A short message too wide for the screen in the font is plain too
+	if lines := awayLines(away.Message{Text: "BACK SOON"}, awayCenter, font, 40, now); !reflect.DeepEqual(lines, []string{"BACK SOON"}) {
+		t.Errorf("narrow screen = %q", lines)
+	}
+
+	// Banners are one line, with the weekday for a later day
+	lines = awayLines(away.Message{Text: "Out", Until: until.AddDate(0, 0, 2)}, awayBanner, font, 80, now)
+	if !reflect.DeepEqual(lines, []string{"Out - until Fri 15:00"}) {
+		t.Errorf("banner = %q", lines)
+	}
+	if lines := awayLines(away.Message{Text: strings.Repeat("x", 100)}, awayBanner, font, 40, now); len(lines[0]) != 38 {
+		t.Errorf("banner is %d wide on a 40 column screen", len(lines[0]))
+	}
+}
+
+// TestWrapText tests word wrapping
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"a bb ccc", 4, []string{"a bb", "ccc"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"会議中です", 4, []string{"会議", "中で", "す"}},
+		{"  ", 10, nil},
+	}
+	for _, tt := range tests {
+		if got := wrapText(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("wrapText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
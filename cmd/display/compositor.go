@@ -0,0 +1,85 @@
+// compositor.go - Layered overlays on the animation's cell grid
+package main
+
+import (
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+)
+
+// Layer is a block of cells drawn over the animation at X, Y.
+// Spaces with no background are transparent, so the animation shows
+// through around the layer's text.
+type Layer struct {
+	Cells *render.Grid
+	X, Y  int
+
+	// Dim scales the brightness of the full rows the layer covers before it
+	// is drawn, from 0 (black) to 1 (unchanged). 0 leaves them untouched.
+	Dim float64
+}
+
+// composite draws layers onto base in order, clipping them to its bounds
+func composite(base *render.Grid, layers ...Layer) {
+	for _, layer := range layers {
+		if layer.Cells == nil {
+			continue
+		}
+
+		if layer.Dim > 0 && layer.Dim < 1 {
+			for y := layer.Y; y < layer.Y+layer.Cells.Height; y++ {
+				dimRow(base, y, layer.Dim)
+			}
+		}
+
+		for y := 0; y < layer.Cells.Height; y++ {
+			for x := 0; x < layer.Cells.Width; x++ {
+				cell := layer.Cells.At(x, y)
+				if transparent(cell) {
+					continue
+				}
+				base.Set(layer.X+x, layer.Y+y, cell)
+			}
+		}
+	}
+}
+
+// transparent reports whether a layer cell lets the cell below show through.
+// Continuation cells are drawn by the wide character to their left.
+func transparent(c render.Cell) bool {
+	return c.Continuation() || (c.Rune == ' ' && c.Style.Bg.IsDefault())
+}
+
+// dimRow scales the colors of one row of base by factor
+func dimRow(base *render.Grid, y int, factor float64) {
+	if y < 0 || y >= base.Height {
+		return
+	}
+	row := base.Cells[y*base.Width : (y+1)*base.Width]
+	for i := range row {
+		row[i].Style = dimStyle(row[i].Style, factor)
+	}
+}
+
+// dimStyle darkens truecolor colors by factor. Palette and default colors
+// can't be scaled, so they get the faint attribute instead.
+func dimStyle(s render.Style, factor float64) render.Style {
+	var scaled bool
+	s.Fg, scaled = dimColor(s.Fg, factor)
+	if !scaled {
+		s.Attrs |= render.AttrDim
+	}
+	s.Bg, _ = dimColor(s.Bg, factor)
+	return s
+}
+
+// dimColor scales a truecolor value and reports whether it could
+func dimColor(c render.Color, factor float64) (render.Color, bool) {
+	r, g, b, ok := c.RGB()
+	if !ok {
+		return c, false
+	}
+	return render.RGBColor(
+		uint8(float64(r)*factor),
+		uint8(float64(g)*factor),
+		uint8(float64(b)*factor),
+	), true
+}
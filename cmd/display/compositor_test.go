@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+)
+
+// TestComposite tests layer transparency, dimming and clipping
+func TestComposite(t *testing.T) {
+	base := render.NewGrid(6, 2)
+	base.Parse("\x1b[38;2;200;100;50mabcdef\x1b[0m\n\x1b[32mghijkl")
+
+	composite(base,
+		Layer{Cells: render.ParseGrid("X Y"), X: 1, Y: 0, Dim: 0.5},
+		Layer{Cells: render.ParseGrid("ZZZ"), X: 4, Y: 1}, // Clipped on the right
+	)
+
+	dimmed := render.Style{Fg: render.RGBColor(100, 50, 25)}
+	tests := []struct {
+		x, y int
+		want render.Cell
+	}{
+		{0, 0, render.Cell{Rune: 'a', Style: dimmed}},                                   // Dimmed, not covered
+		{1, 0, render.Cell{Rune: 'X'}},                                                  // Layer text
+		{2, 0, render.Cell{Rune: 'c', Style: dimmed}},                                   // Transparent space
+		{3, 0, render.Cell{Rune: 'Y'}},                                                  // Layer text
+		{5, 0, render.Cell{Rune: 'f', Style: dimmed}},                                   // Dimmed, not covered
+		{0, 1, render.Cell{Rune: 'g', Style: render.Style{Fg: render.IndexedColor(2)}}}, // Row not dimmed
+		{4, 1, render.Cell{Rune: 'Z'}},
+		{5, 1, render.Cell{Rune: 'Z'}},
+	}
+
+	for _, tt := range tests {
+		if got := base.At(tt.x, tt.y); got != tt.want {
+			t.Errorf("At(%d, %d) = %+v, want %+v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+// TestCompositeWide tests overlays that cut through wide characters
+func TestCompositeWide(t *testing.T) {
+	base := render.NewGrid(6, 1)
+	base.Parse("日本語")
+
+	// Covering the right half of 日 blanks its left half
+	composite(base, Layer{Cells: render.ParseGrid("x"), X: 1})
+	// A wide character straddling 本 and 語 blanks what is left of both
+	composite(base, Layer{Cells: render.ParseGrid("字"), X: 3})
+
+	want := []rune{' ', 'x', ' ', '字', 0, ' '}
+	for x, r := range want {
+		if got := base.At(x, 0).Rune; got != r {
+			t.Errorf("At(%d) = %q, want %q", x, got, r)
+		}
+	}
+}
+
+// TestDimStyle tests dimming of truecolor and palette colors
+func TestDimStyle(t *testing.T) {
+	got := dimStyle(render.Style{Fg: render.RGBColor(100, 200, 50), Bg: render.RGBColor(10, 20, 30)}, 0.5)
+	want := render.Style{Fg: render.RGBColor(50, 100, 25), Bg: render.RGBColor(5, 10, 15)}
+	if got != want {
+		t.Errorf("dimStyle(truecolor) = %+v, want %+v", got, want)
+	}
+
+	got = dimStyle(render.Style{Fg: render.IndexedColor(3)}, 0.5)
+	if got.Attrs&render.AttrDim == 0 || got.Fg != render.IndexedColor(3) {
+		t.Errorf("dimStyle(palette) = %+v, want faint attribute", got)
+	}
+}
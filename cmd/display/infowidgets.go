@@ -0,0 +1,262 @@
+// infowidgets.go - Host, battery, CPU, media, notification and status widgets
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/powerstate"
+	"github.com/godbus/dbus/v5"
+)
+
+// newWidget creates the widget a spec names
+func newWidget(spec widgetSpec) (Widget, error) {
+	switch spec.kind {
+	case "hostname":
+		return hostnameWidget{}, nil
+	case "battery":
+		return batteryWidget{root: powerstate.DefaultSysfsRoot}, nil
+	case "cpu":
+		return newCPUWidget("/proc"), nil
+	case "nowplaying":
+		return &nowPlayingWidget{}, nil
+	case "notifications":
+		return notificationsWidget{probes: notificationProbes}, nil
+	case "status":
+		if !filepath.IsAbs(spec.file) {
+			return nil, fmt.Errorf("status widget needs an absolute file, got %q", spec.file)
+		}
+		return statusWidget{path: spec.file}, nil
+	}
+	return nil, fmt.Errorf("unknown widget %q", spec.kind)
+}
+
+// hostnameWidget shows the machine's short host name
+type hostnameWidget struct{}
+
+func (hostnameWidget) Interval() time.Duration { return time.Hour }
+
+func (hostnameWidget) Render(time.Time) []string {
+	name, err := os.Hostname()
+	if err != nil {
+		return nil
+	}
+	name, _, _ = strings.Cut(name, ".")
+	return []string{name}
+}
+
+// batteryWidget shows the battery charge, hidden without a battery
+type batteryWidget struct {
+	root string // sysfs power_supply directory
+}
+
+func (batteryWidget) Interval() time.Duration { return 30 * time.Second }
+
+func (w batteryWidget) Render(time.Time) []string {
+	state := powerstate.Read(w.root)
+	if state.Capacity < 0 {
+		return nil
+	}
+	if state.OnBattery {
+		return []string{fmt.Sprintf("Battery %d%%", state.Capacity)}
+	}
+	return []string{fmt.Sprintf("Battery %d%% (AC)", state.Capacity)}
+}
+
+// cpuWidget shows CPU use since the last refresh and the load average
+type cpuWidget struct {
+	proc        string // procfs mount point
+	busy, total uint64 // /proc/stat counters at the last refresh
+}
+
+// newCPUWidget reads the CPU counters once, so CPU use shows from the first
+// refresh that comes after some time has passed
+func newCPUWidget(proc string) *cpuWidget {
+	w := &cpuWidget{proc: proc}
+	w.busy, w.total, _ = readCPUStat(proc)
+	return w
+}
+
+func (*cpuWidget) Interval() time.Duration { return 2 * time.Second }
+
+func (w *cpuWidget) Render(time.Time) []string {
+	var parts []string
+	if busy, total, ok := readCPUStat(w.proc); ok {
+		if total > w.total {
+			parts = append(parts, fmt.Sprintf("CPU %d%%", (busy-w.busy)*100/(total-w.total)))
+		}
+		w.busy, w.total = busy, total
+	}
+	if data, err := os.ReadFile(filepath.Join(w.proc, "loadavg")); err == nil {
+		if fields := strings.Fields(string(data)); len(fields) >= 3 {
+			parts = append(parts, "load "+strings.Join(fields[:3], " "))
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return []string{strings.Join(parts, "  ")}
+}
+
+// readCPUStat returns the busy and total jiffies of all CPUs. Idle and
+// iowait count as not busy.
+func readCPUStat(proc string) (busy, total uint64, ok bool) {
+	data, err := os.ReadFile(filepath.Join(proc, "stat"))
+	if err != nil {
+		return 0, 0, false
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0, false
+	}
+	for i, field := range fields[1:] {
+		// guest and guest_nice are already counted in user and nice
+		if i >= 8 {
+			break
+		}
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		total += n
+		if i != 3 && i != 4 {
+			busy += n
+		}
+	}
+	return busy, total, true
+}
+
+// MPRIS names of media players on the session bus
+const (
+	mprisPrefix = "org.mpris.MediaPlayer2."
+	mprisPath   = dbus.ObjectPath("/org/mpris/MediaPlayer2")
+	mprisPlayer = "org.mpris.MediaPlayer2.Player"
+)
+
+// nowPlayingWidget shows the track of the first playing MPRIS player
+type nowPlayingWidget struct {
+	conn *dbus.Conn
+}
+
+func (*nowPlayingWidget) Interval() time.Duration { return 5 * time.Second }
+
+func (w *nowPlayingWidget) Render(time.Time) []string {
+	if w.conn == nil {
+		conn, err := dbus.ConnectSessionBus()
+		if err != nil {
+			return nil
+		}
+		w.conn = conn
+	}
+
+	var names []string
+	if err := w.conn.BusObject().Call("org.freedesktop.DBus.ListNames", 0).Store(&names); err != nil {
+		// Connect again next time
+		w.conn.Close()
+		w.conn = nil
+		return nil
+	}
+	for _, name := range names {
+		if !strings.HasPrefix(name, mprisPrefix) {
+			continue
+		}
+		player := w.conn.Object(name, mprisPath)
+		status, err := player.GetProperty(mprisPlayer + ".PlaybackStatus")
+		if err != nil || status.Value() != "Playing" {
+			continue
+		}
+		metadata, err := player.GetProperty(mprisPlayer + ".Metadata")
+		if err != nil {
+			continue
+		}
+		if fields, ok := metadata.Value().(map[string]dbus.Variant); ok {
+			if line := trackLine(fields); line != "" {
+				return []string{line}
+			}
+		}
+	}
+	return nil
+}
+
+// trackLine formats MPRIS metadata as "Playing: Artist - Title"
+func trackLine(metadata map[string]dbus.Variant) string {
+	title, _ := metadata["xesam:title"].Value().(string)
+	if title == "" {
+		return ""
+	}
+	if artists, _ := metadata["xesam:artist"].Value().([]string); len(artists) > 0 {
+		title = strings.Join(artists, ", ") + " - " + title
+	}
+	return "Playing: " + title
+}
+
+// notificationProbes are commands that print the number of unread
+// notifications of common notification daemons
+var notificationProbes = [][]string{
+	{"swaync-client", "--count"},
+	{"dunstctl", "count", "waiting"},
+}
+
+// notificationsWidget shows the unread notification count, hidden at zero
+type notificationsWidget struct {
+	probes [][]string
+}
+
+func (notificationsWidget) Interval() time.Duration { return 10 * time.Second }
+
+func (w notificationsWidget) Render(time.Time) []string {
+	for _, probe := range w.probes {
+		output, err := exec.Command(probe[0], probe[1:]...).Output()
+		if err != nil {
+			continue
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+		if err != nil {
+			continue
+		}
+		switch {
+		case count <= 0:
+			return nil
+		case count == 1:
+			return []string{"1 notification"}
+		default:
+			return []string{fmt.Sprintf("%d notifications", count)}
+		}
+	}
+	return nil
+}
+
+// maxStatusLines is how many lines of a status file are shown
+const maxStatusLines = 3
+
+// statusWidget shows the first lines of a file, hidden while it's missing
+// or empty
+type statusWidget struct {
+	path string
+}
+
+func (statusWidget) Interval() time.Duration { return 5 * time.Second }
+
+func (w statusWidget) Render(time.Time) []string {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return nil
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if len(lines) == maxStatusLines {
+			break
+		}
+		lines = append(lines, strings.TrimRight(line, " \t\r"))
+	}
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	return lines
+}
@@ -2,235 +2,304 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"os/signal"
-	"path/filepath"
-	"regexp"
-	"strconv"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/Nomadcxx/sysc-walls/internal/animations"
+	"github.com/Nomadcxx/sysc-walls/internal/art"
+	"github.com/Nomadcxx/sysc-walls/internal/away"
 	"github.com/Nomadcxx/sysc-walls/internal/clock"
+	"github.com/Nomadcxx/sysc-walls/internal/figlet"
+	"github.com/Nomadcxx/sysc-walls/internal/imageart"
+	"github.com/Nomadcxx/sysc-walls/internal/record"
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+	"github.com/Nomadcxx/sysc-walls/internal/theme"
 	"github.com/Nomadcxx/sysc-walls/internal/version"
 	"github.com/Nomadcxx/sysc-walls/pkg/utils"
-
-	syscGo "github.com/Nomadcxx/sysc-Go/animations"
 )
 
-// loadTextContent loads text from a file with fallback to default SYSC.txt
-func loadTextContent(customPath string, debug bool) string {
-	// Try custom path first if provided
-	if customPath != "" {
-		if content, err := os.ReadFile(customPath); err == nil {
+// loadTextContent returns the art for text-based effects from an
+// animation.file source, falling back to the default art
+func loadTextContent(source *art.Source, debug bool) art.Piece {
+	if source != nil {
+		piece, err := source.Next()
+		if err == nil {
 			if debug {
-				fmt.Fprintf(os.Stderr, "Loaded text from: %s\n", customPath)
+				fmt.Fprintf(os.Stderr, "Loaded art from: %s\n", piece.From)
 			}
-			return strings.TrimSpace(string(content))
-		} else if debug {
-			fmt.Fprintf(os.Stderr, "Failed to load custom text file %s: %v\n", customPath, err)
+			return piece
 		}
+		fmt.Fprintf(os.Stderr, "Warning: %v, using the default art\n", err)
 	}
 
-	// Try default SYSC.txt from config directory (primary location)
-	homeDir := os.Getenv("HOME")
-	var defaultPaths []string
-
-	// Only add home-based paths if HOME is set and valid
-	if homeDir != "" && filepath.IsAbs(homeDir) {
-		defaultPaths = append(defaultPaths,
-			filepath.Join(homeDir, ".config", "sysc-walls", "ascii", "SYSC.txt"),
-			filepath.Join(homeDir, ".local", "share", "syscgo", "walls", "SYSC.txt"),
-			filepath.Join(homeDir, ".local", "share", "sysc-walls", "SYSC.txt"),
-		)
+	text, from := art.Default()
+	if debug {
+		fmt.Fprintf(os.Stderr, "Loaded art from: %s\n", from)
 	}
+	return art.Piece{Text: text, From: from}
+}
 
-	// System-wide paths (always available)
-	defaultPaths = append(defaultPaths,
-		"/usr/share/sysc-walls/ascii/SYSC.txt", // AUR package location
-		"/usr/share/syscgo/assets/SYSC.txt",
-		"/usr/share/sysc-walls/SYSC.txt",
-		"sysc-Go/assets/SYSC.txt", // For development
-	)
-
-	for _, path := range defaultPaths {
-		if content, err := os.ReadFile(path); err == nil {
-			if debug {
-				fmt.Fprintf(os.Stderr, "Loaded ASCII art from: %s\n", path)
+// rotateArt sends the next art from source every interval, for effects
+// that take new text while running. Commands may be slow, so the art is
+// fetched outside the frame loop.
+func rotateArt(source *art.Source, interval time.Duration, debug bool) <-chan art.Piece {
+	updates := make(chan art.Piece, 1)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			select {
+			case updates <- loadTextContent(source, debug):
+			default: // The last art hasn't been taken yet
 			}
-			return strings.TrimSpace(string(content))
 		}
-	}
+	}()
+	return updates
+}
+
+// defaultTextFont draws --text when no font is given or the font fails
+const defaultTextFont = "block"
 
-	// Final fallback - simple text
+// renderText draws text in a FIGlet font as the content of a text-based
+// effect
+func renderText(text, fontName string, debug bool) string {
+	font := loadTextFont(fontName)
 	if debug {
-		fmt.Fprintf(os.Stderr, "Warning: No ASCII art files found, using fallback text\n")
+		fmt.Fprintf(os.Stderr, "Drawing text %q in font %s\n", text, font.Name)
 	}
-	return "SYSC-WALLS"
-}
 
-// isTextBasedEffect checks if an effect uses text content
-// Now uses sysc-Go registry instead of hardcoded list
-func isTextBasedEffect(effect string) bool{
-	return syscGo.IsTextBasedEffect(effect)
+	lines := font.Render(text)
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
 }
 
-// dimANSIColors reduces the intensity of ANSI RGB colors by a factor
-// factor should be between 0.0 (black) and 1.0 (original)
-func dimANSIColors(text string, factor float64) string {
-	// Match ANSI RGB color codes: \x1b[38;2;R;G;Bm
-	re := regexp.MustCompile(`\x1b\[38;2;(\d+);(\d+);(\d+)m`)
-
-	return re.ReplaceAllStringFunc(text, func(match string) string {
-		// Extract RGB values
-		parts := re.FindStringSubmatch(match)
-		if len(parts) != 4 {
-			return match
-		}
+// loadTextFont loads the font text-based effects draw their text in,
+// falling back to the default font with a warning
+func loadTextFont(fontName string) *figlet.Font {
+	font, err := figlet.Load(fontName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using %s\n", err, defaultTextFont)
+		font = figlet.MustLoad(defaultTextFont)
+	}
+	return font
+}
 
-		r, _ := strconv.Atoi(parts[1])
-		g, _ := strconv.Atoi(parts[2])
-		b, _ := strconv.Atoi(parts[3])
+// fitOptions builds the art fit from its flags, leaving art as drawn with a
+// warning when they are invalid
+func fitOptions(mode, anchor string, padding int) animations.Fit {
+	fit := animations.Fit{Mode: mode, Anchor: anchor, Padding: padding}
+	if err := fit.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, leaving the art as drawn\n", err)
+		return animations.Fit{}
+	}
+	return fit
+}
 
-		// Dim the colors
-		r = int(float64(r) * factor)
-		g = int(float64(g) * factor)
-		b = int(float64(b) * factor)
+// paramFlags collects repeated --param name=value flags
+type paramFlags animations.Params
 
-		// Reconstruct ANSI code
-		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", r, g, b)
-	})
+func (f paramFlags) String() string {
+	var pairs []string
+	for _, name := range slices.Sorted(maps.Keys(f)) {
+		pairs = append(pairs, name+"="+f[name])
+	}
+	return strings.Join(pairs, " ")
 }
 
-// dimLineRegion dims a specific region of a line (from start to end column)
-func dimLineRegion(line string, startCol, endCol int, factor float64) string {
-	// Convert to runes to handle multi-byte characters and ANSI codes
-	runes := []rune(line)
-	if startCol < 0 || startCol >= len(runes) {
-		return line
-	}
-	if endCol > len(runes) {
-		endCol = len(runes)
+func (f paramFlags) Set(value string) error {
+	name, v, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("expected name=value")
 	}
+	f[strings.TrimSpace(name)] = strings.TrimSpace(v)
+	return nil
+}
 
-	// Extract the region, dim it, and reconstruct
-	before := string(runes[:startCol])
-	region := string(runes[startCol:endCol])
-	after := string(runes[endCol:])
-
-	return before + dimANSIColors(region, factor) + after
+// effectParams checks the --param flags against the effect's parameters,
+// leaving out those that don't fit with a warning
+func effectParams(effect string, flags paramFlags) animations.Params {
+	params := animations.Params{}
+	for name, value := range flags {
+		if err := (animations.Params{name: value}).Validate(effect); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, using the default\n", err)
+			continue
+		}
+		params[name] = value
+	}
+	return params
 }
 
-// overlayLine overlays overlay text onto base
-// For now, just returns overlay (base is already dimmed separately)
-func overlayLine(base, overlay string, width int) string {
-	// The overlay contains bright datetime text
-	// The base is already dimmed in the calling function
-	// Simply return the overlay which will show bright text on dimmed background
-	return overlay
+// isTextBasedEffect checks if an effect uses text content
+// Now uses the effect registry instead of hardcoded list
+func isTextBasedEffect(effect string) bool {
+	e, _ := animations.Lookup(effect)
+	return e.Text
 }
 
-// overlayDateTime overlays date-time on animation output
-func overlayDateTime(animOutput string, width, height int, isTextBased bool, position string) string {
-	// Get datetime lines
-	datetimeLines := clock.RenderDateTime()
+// fallbackEffect runs on screens too small for the chosen effect
+const fallbackEffect = "matrix"
 
-	// Split animation output into lines
-	animLines := strings.Split(animOutput, "\n")
+// newClock builds the datetime clock from its flags. Settings that fail
+// fall back to their defaults with a warning. The away timer starts now.
+func newClock(opts clock.Options, fontName, zones string) *clock.Clock {
+	opts.Start = time.Now()
+	font, err := figlet.Load(fontName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using %s\n", err, clock.DefaultFont)
+	}
+	opts.Font = font
+	if zones != "" {
+		for _, zone := range strings.Split(zones, ",") {
+			if zone = strings.TrimSpace(zone); zone != "" {
+				opts.Zones = append(opts.Zones, zone)
+			}
+		}
+	}
 
-	// Ensure we have enough lines
-	for len(animLines) < height {
-		animLines = append(animLines, strings.Repeat(" ", width))
+	c, err := clock.New(opts)
+	if err == nil {
+		return c
 	}
+	fmt.Fprintf(os.Stderr, "Warning: %v, using the default clock\n", err)
+	c, _ = clock.New(clock.Options{Font: font, Start: opts.Start})
+	return c
+}
+
+// overlayDateTime draws the date-time overlay onto the animation's cell grid
+func overlayDateTime(grid *render.Grid, dateClock *clock.Clock, isTextBased bool, position string) {
+	// Get datetime lines
+	datetimeLines := dateClock.Render(time.Now(), grid.Width, grid.Height)
+	height := grid.Height
 
 	if isTextBased {
-		// For text-based effects: append datetime below the animation
-		// Find the last non-empty line
-		lastNonEmpty := len(animLines) - 1
-		for lastNonEmpty >= 0 && strings.TrimSpace(animLines[lastNonEmpty]) == "" {
+		// For text-based effects: place datetime below the animation
+		// Find the last non-empty row
+		lastNonEmpty := height - 1
+		for lastNonEmpty >= 0 && blankRow(grid, lastNonEmpty) {
 			lastNonEmpty--
 		}
 
 		// Insert datetime starting a few lines below the text
 		startLine := lastNonEmpty + 3
-		if startLine >= len(animLines) {
-			startLine = len(animLines) - len(datetimeLines) - 1
+		if startLine >= height {
+			startLine = height - len(datetimeLines) - 1
 		}
 		if startLine < 0 {
 			startLine = 0
 		}
 
-		// Center and insert datetime lines
-		centeredDateTime := clock.CenterLines(datetimeLines, width)
-		for i, line := range centeredDateTime {
-			if startLine+i < len(animLines) {
-				animLines[startLine+i] = line
-			}
-		}
-	} else {
-		// For non-text effects: overlay with dimming at specified position
-		// Calculate starting position based on user preference
-		var startLine int
-		switch position {
-		case "top":
-			startLine = 2 // Small margin from top
-		case "center":
-			startLine = (height - len(datetimeLines)) / 2
-		case "bottom":
-			startLine = height - len(datetimeLines) - 2
-		default:
-			startLine = height - len(datetimeLines) - 2 // fallback to bottom
-		}
+		composite(grid, centeredLayers(datetimeLines, grid.Width, startLine, "", 0)...)
+		return
+	}
 
-		// Ensure we don't go out of bounds
-		if startLine < 0 {
-			startLine = 0
-		}
-		if startLine+len(datetimeLines) > len(animLines) {
-			startLine = len(animLines) - len(datetimeLines)
-			if startLine < 0 {
-				startLine = 0
-			}
-		}
+	// For non-text effects: overlay with dimming at specified position
+	// Calculate starting position based on user preference
+	var startLine int
+	switch position {
+	case "top":
+		startLine = 2 // Small margin from top
+	case "center":
+		startLine = (height - len(datetimeLines)) / 2
+	case "bottom":
+		startLine = height - len(datetimeLines) - 2
+	default:
+		startLine = height - len(datetimeLines) - 2 // fallback to bottom
+	}
 
-		// Get datetime lines with bright colors
-		centeredDateTime := clock.CenterLinesBright(datetimeLines, width)
+	// Ensure we don't go out of bounds
+	if startLine+len(datetimeLines) > height {
+		startLine = height - len(datetimeLines)
+	}
+	if startLine < 0 {
+		startLine = 0
+	}
 
-		// Dim the animation area behind datetime and overlay
-		for i, dtLine := range centeredDateTime {
-			lineIdx := startLine + i
-			if lineIdx >= len(animLines) {
-				break
-			}
+	// Bright white text over a dimmed band of the animation
+	composite(grid, centeredLayers(datetimeLines, grid.Width, startLine, "\x1b[38;2;255;255;255m", 0.35)...)
+}
 
-			// Dim the entire line where datetime will appear
-			animLines[lineIdx] = dimANSIColors(animLines[lineIdx], 0.35)
+// centeredLayers turns text lines into one layer per line, each centered
+// horizontally by its display width and drawn with the given SGR prefix
+func centeredLayers(lines []string, width, startLine int, style string, dim float64) []Layer {
+	layers := make([]Layer, 0, len(lines))
+	for i, line := range lines {
+		cells := render.ParseGrid(style + line)
+		layers = append(layers, Layer{
+			Cells: cells,
+			X:     (width - cells.Width) / 2,
+			Y:     startLine + i,
+			Dim:   dim,
+		})
+	}
+	return layers
+}
 
-			// Overlay datetime on top (character by character to preserve spacing)
-			animLines[lineIdx] = overlayLine(animLines[lineIdx], dtLine, width)
+// blankRow reports whether a grid row holds only spaces
+func blankRow(grid *render.Grid, y int) bool {
+	for x := 0; x < grid.Width; x++ {
+		if grid.At(x, y).Rune != ' ' {
+			return false
 		}
 	}
-
-	return strings.Join(animLines, "\n")
+	return true
 }
 
 func main() {
 	// Parse command line flags
 	var (
 		effect           = flag.String("effect", "matrix", "Animation effect to display")
-		theme            = flag.String("theme", "dracula", "Color theme for animation")
-		file             = flag.String("file", "", "Text file for text-based effects")
+		themeName        = flag.String("theme", "dracula", "Color theme for animation, or auto for the desktop's colors")
+		themeSource      = flag.String("theme-source", "", "Color scheme --theme auto follows: a pywal colors.json, kitty .conf or base16 .yaml (default ~/.cache/wal/colors.json, then the desktop portal)")
+		file             = flag.String("file", "", "Art for text-based effects: a text or image file, directory, glob or !command")
+		artInterval      = flag.Duration("art-interval", 0, "Show the next art from a directory, glob or command this often (0 to keep it)")
+		text             = flag.String("text", "", "Text for text-based effects, drawn in --font instead of --file")
+		textFont         = flag.String("font", defaultTextFont, "FIGlet font for --text: a bundled font name or .flf path")
+		textSource       = flag.String("text-source", "file", "Text of text-based effects: file (--file or --text) or clock")
+		imageMode        = flag.String("image-mode", imageart.DefaultOptions.Mode, "How image art is drawn: blocks (half blocks) or ramp (luminance characters)")
+		imageThreshold   = flag.Float64("image-threshold", imageart.DefaultOptions.Threshold, "Ink (opacity, or brightness for opaque images) from 0 to 1 an image pixel needs to be drawn")
+		imageDither      = flag.Bool("image-dither", false, "Dither image art")
+		imageInvert      = flag.Bool("image-invert", false, "Draw the dark parts of images, for dark art on a light background")
+		imageColors      = flag.Bool("image-colors", true, "Draw image art in the image's colors once it is in place")
+		fitMode          = flag.String("fit", animations.FitNone, "How text-based effects fit their art to the screen: "+strings.Join(animations.FitModes, ", "))
+		fitAnchor        = flag.String("fit-anchor", "center", "Part of the art kept when it is cropped: "+strings.Join(animations.FitAnchors, ", "))
+		fitPadding       = flag.Int("fit-padding", 0, "Cells kept clear around the art of text-based effects")
 		datetime         = flag.Bool("datetime", false, "Show date and time overlay")
 		datetimePosition = flag.String("datetime-position", "bottom", "Position of datetime overlay: top, center, bottom")
+		datetimeFont     = flag.String("datetime-font", clock.DefaultFont, "FIGlet font for the datetime clock")
+		datetimeFormat   = flag.String("datetime-format", "12h", "Clock format: 12h, 24h or a Go time layout")
+		datetimeSeconds  = flag.Bool("datetime-seconds", true, "Show seconds with the 12h and 24h formats")
+		datetimeLocale   = flag.String("datetime-locale", clock.DefaultLocale, "Locale of weekday and month names, or auto")
+		datetimeZones    = flag.String("datetime-zones", "", "Comma-separated time zones to show side by side, e.g. Tokyo=Asia/Tokyo,UTC")
+		datetimeStyle    = flag.String("datetime-style", string(clock.Digital), "Clock widget: "+strings.Join(clock.StyleNames(), ", "))
+		datetimeTarget   = flag.String("datetime-countdown", "", "Countdown target for --datetime-style countdown: HH:MM or YYYY-MM-DD HH:MM")
+		awayStyle        = flag.String("away-style", awayCenter, "How the away message is drawn: center or banner")
+		awayFont         = flag.String("away-font", defaultTextFont, "FIGlet font for short away messages")
 		showVersion      = flag.Bool("version", false, "Show version information")
 		showVersionV     = flag.Bool("v", false, "Show version information (shorthand)")
 		debug            = flag.Bool("debug", false, "Enable debug logging")
-		noClear      = flag.Bool("no-clear", false, "Don't clear the screen before animation")
-		fullScreen   = flag.Bool("fullscreen", false, "Run in fullscreen mode")
+		fps              = flag.Int("fps", 20, "Frames per second (1-120); effects move at most 20 times a second, times their speed")
+		colors           = flag.String("colors", "auto", "Color depth: auto, truecolor, 256, 16, mono")
+		recordPath       = flag.String("record", "", "Record what is drawn to an asciicast (.cast) file")
+		seed             = flag.Int64("seed", 0, "Seed for a repeatable run (0 for random)")
+		maxFrames        = flag.Int("frames", 0, "Exit after this many frames (0 to run until stopped)")
+		noClear          = flag.Bool("no-clear", false, "Don't clear the screen before animation")
+		fullScreen       = flag.Bool("fullscreen", false, "Run in fullscreen mode")
 	)
+	var widgets widgetFlags
+	flag.Var(&widgets, "widget", "Info widget, repeatable: kind,position=P,interval=D,file=F")
+	params := paramFlags{}
+	flag.Var(params, "param", "Effect parameter, repeatable: name=value, e.g. speed=1.5")
 	flag.Parse()
 
 	// Handle version flag
@@ -259,11 +328,18 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Retry %d: size=%dx%d\n", i+1, width, height)
 		}
 	}
-	
+
 	if *debug {
 		fmt.Fprintf(os.Stderr, "Final terminal size: %dx%d\n", width, height)
 	}
 
+	// Effects panic on screens smaller than they were written for
+	if e, ok := animations.Lookup(*effect); ok && !e.Fits(width, height) {
+		fmt.Fprintf(os.Stderr, "Warning: %s needs at least %dx%d, not %dx%d; using %s\n", e.Name, e.MinWidth, e.MinHeight, width, height, fallbackEffect)
+		*effect = fallbackEffect
+		clear(params)
+	}
+
 	// Setup terminal
 	if !*noClear {
 		utils.SetupTerminal()
@@ -272,17 +348,70 @@ func main() {
 
 	// Load text content for text-based effects
 	var textContent string
+	var liveText *clockText
+	var artUpdates <-chan art.Piece
+	var pic *picture // Image art, redrawn at each size
+	pictureOpts := imageOptions(*imageMode, *imageThreshold, *imageDither, *imageInvert)
 	if isTextBasedEffect(*effect) {
-		textContent = loadTextContent(*file, *debug)
+		if *textSource != "file" && *textSource != "clock" {
+			fmt.Fprintf(os.Stderr, "Warning: unknown --text-source %q, using file\n", *textSource)
+		}
+		if *textSource == "clock" {
+			// The current time, redrawn each minute in --font
+			liveText = newClockText(loadTextFont(*textFont), *datetimeFormat, *datetimeLocale)
+			textContent, _ = liveText.Next(time.Now())
+		} else if *text != "" {
+			textContent = renderText(*text, *textFont, *debug)
+		} else {
+			var source *art.Source
+			if *file != "" {
+				source = art.NewSource(*file)
+			}
+			textContent, pic = pieceText(loadTextContent(source, *debug), pictureOpts, *imageColors, width, height, *debug)
+			if source != nil && source.Rotates() && *artInterval > 0 {
+				artUpdates = rotateArt(source, *artInterval, *debug)
+			}
+		}
+	}
+
+	// Art is fitted to the screen by the effect, again on each resize.
+	// Image art is converted for the screen already, so it is left alone
+	// unless text may rotate in later.
+	fit := fitOptions(*fitMode, *fitAnchor, *fitPadding)
+	if pic != nil && artUpdates == nil {
+		fit = animations.Fit{}
+	}
+
+	// The auto theme takes the desktop's colors, and follows them while
+	// running
+	animOpts := animations.Options{Text: textContent, Seed: *seed, Fit: fit, Params: effectParams(*effect, params)}
+	var themeUpdates <-chan *theme.Theme
+	if *themeName == theme.Auto {
+		colors, err := theme.FindAuto(*themeSource)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, using rama until it can be read\n", err)
+			colors, _ = theme.Find("rama")
+		}
+		animOpts.Theme = colors
+		themeUpdates = theme.WatchAuto(context.Background(), *themeSource, theme.AutoInterval)
 	}
 
 	// Create animation based on effect
-	anim, err := animations.CreateAnimationWithText(*effect, width, height, *theme, textContent)
+	anim, err := animations.CreateAnimationWithOptions(*effect, width, height, *themeName, animOpts)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating animation: %v\n", err)
 		os.Exit(1)
 	}
 
+	// setText gives text-based effects new art, kept for when the effect
+	// is rebuilt in new colors
+	setText := func(text string) {
+		animOpts.Text = text
+		if textAnim, ok := anim.(animations.TextAnimation); ok {
+			textAnim.SetText(text)
+		}
+	}
+
 	// Setup signal handling for graceful shutdown and resize
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -293,56 +422,193 @@ func main() {
 
 	// Animation loop
 	frame := 0
-	ticker := time.NewTicker(50 * time.Millisecond) // 20 FPS
-	defer ticker.Stop()
+	if *fps < 1 || *fps > 120 {
+		fmt.Fprintf(os.Stderr, "Invalid --fps %d, using 20\n", *fps)
+		*fps = 20
+	}
 
-	// Screensaver runs infinitely
+	// Effects advance by elapsed time, so they run at the same speed at any fps
+	interval := time.Second / time.Duration(*fps)
+	timed := animations.NewTimedAnimation(anim, animOpts.Params.Step(), interval)
+	pacer := newFramePacer(*fps, time.Now())
+	frameTimer := time.NewTimer(0)
+	defer frameTimer.Stop()
+
+	// Screensaver runs infinitely unless a frame count is given
 	totalFrames := -1
+	if *maxFrames > 0 {
+		totalFrames = *maxFrames
+	}
 
 	// Store values for use in goroutine
 	showDateTime := *datetime
+	var dateClock *clock.Clock
+	if showDateTime {
+		dateClock = newClock(clock.Options{
+			Format:    *datetimeFormat,
+			NoSeconds: !*datetimeSeconds,
+			Locale:    *datetimeLocale,
+			Style:     clock.Style(*datetimeStyle),
+			Countdown: *datetimeTarget,
+		}, *datetimeFont, *datetimeZones)
+	}
+	board := newWidgetBoard(widgets, func(err error) {
+		fmt.Fprintf(os.Stderr, "Warning: %v, leaving it out\n", err)
+	})
+	board.Start(time.Now())
+
+	// The away message can be set and cleared while the display runs
+	awayWatcher := away.NewWatcher(away.Path())
+	if *awayStyle != awayCenter && *awayStyle != awayBanner {
+		fmt.Fprintf(os.Stderr, "Warning: unknown --away-style %q, using %s\n", *awayStyle, awayCenter)
+		*awayStyle = awayCenter
+	}
+	awayTextFont := loadTextFont(*awayFont)
 	effectName := *effect
 	isTextEffect := isTextBasedEffect(effectName)
 
 	if *debug {
-		fmt.Printf("Starting animation: %s with theme %s\n", *effect, *theme)
+		fmt.Printf("Starting animation: %s with theme %s\n", *effect, *themeName)
 		fmt.Printf("Terminal size: %dx%d\n", width, height)
-		fmt.Printf("Duration: infinite (screensaver mode)\n")
+		if totalFrames > 0 {
+			fmt.Printf("Duration: %d frames\n", totalFrames)
+		} else {
+			fmt.Printf("Duration: infinite (screensaver mode)\n")
+		}
+		if *seed != 0 {
+			fmt.Printf("Seed: %d\n", *seed)
+		}
 		fmt.Printf("DateTime overlay: %v\n", showDateTime)
 	}
 
+	// Optionally tee the terminal output into an asciicast recording
+	var output io.Writer = os.Stdout
+	var cast *record.Cast
+	if *recordPath != "" {
+		if format, err := record.FormatFromPath(*recordPath); err != nil || format != record.FormatCast {
+			fmt.Fprintf(os.Stderr, "--record writes asciicast (.cast) files; use 'sysc-walls render' for GIF and PNG\n")
+			os.Exit(1)
+		}
+		recording, err := os.Create(*recordPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating recording: %v\n", err)
+			os.Exit(1)
+		}
+		defer recording.Close()
+
+		cast, err = record.NewCast(recording, record.CastHeader{
+			Width:     width,
+			Height:    height,
+			Timestamp: time.Now().Unix(),
+			Title:     fmt.Sprintf("sysc-walls %s (%s)", *effect, *themeName),
+			Env:       map[string]string{"TERM": os.Getenv("TERM")},
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating recording: %v\n", err)
+			os.Exit(1)
+		}
+		cast.Write([]byte("\x1b[?25l\x1b[2J"))
+		output = io.MultiWriter(os.Stdout, cast)
+	}
+
+	// Only changed cells are written each frame
+	renderer := render.NewRenderer(output, width, height)
+
+	// Quantize colors for terminals without truecolor
+	colorMode := render.DetectColorMode()
+	if *colors != "auto" {
+		if mode, err := render.ParseColorMode(*colors); err == nil {
+			colorMode = mode
+		} else {
+			fmt.Fprintf(os.Stderr, "%v, detecting instead\n", err)
+		}
+	}
+	renderer.SetColorMode(colorMode)
+	if *debug {
+		fmt.Printf("Color mode: %s\n", colorMode)
+	}
+
 	// Animation goroutine
+	done := make(chan struct{})
 	go func() {
+		defer close(done)
 		for frame < totalFrames || totalFrames == -1 {
 			select {
-			case <-ticker.C:
-				// Update animation
-				anim.Update(frame)
+			case <-frameTimer.C:
+				// Swap in the new time when the minute changes
+				if liveText != nil {
+					if next, changed := liveText.Next(time.Now()); changed {
+						setText(next)
+					}
+				}
+
+				// Swap in the next art from a rotating source
+				select {
+				case piece := <-artUpdates:
+					var next string
+					next, pic = pieceText(piece, pictureOpts, *imageColors, width, height, *debug)
+					setText(next)
+				default:
+				}
+
+				// Rebuild the effect in the desktop's new colors
+				select {
+				case colors := <-themeUpdates:
+					animOpts.Theme = colors
+					if next, err := animations.CreateAnimationWithOptions(effectName, width, height, *themeName, animOpts); err == nil {
+						anim, timed = next, animations.NewTimedAnimation(next, animOpts.Params.Step(), interval)
+						if *debug {
+							fmt.Fprintf(os.Stderr, "Recolored with the desktop's colors: %s\n", strings.Join(colors.Palette, " "))
+						}
+					}
+				default:
+				}
+
+				// Update animation by the time since the last frame
+				timed.Advance(pacer.Begin(time.Now()))
 
 				// Render animation
 				if !*noClear && frame == 0 {
 					utils.ClearScreen()
 				}
 
-				// Get rendered output
-				output := anim.Render()
+				// Parse the frame into cells
+				grid := renderer.Grid()
+				grid.Parse(timed.Render())
+
+				// Image art in the image's colors
+				if pic != nil {
+					pic.Overlay(grid)
+				}
 
 				// Apply datetime overlay if enabled
 				if showDateTime {
-					output = overlayDateTime(output, width, height, isTextEffect, *datetimePosition)
+					overlayDateTime(grid, dateClock, isTextEffect, *datetimePosition)
 				}
 
-				// Print animation
-				fmt.Print(output)
+				// Draw the info widgets on top
+				if !board.Empty() {
+					overlayWidgets(grid, board)
+				}
 
-				// Move cursor to top
-				fmt.Print("\033[H")
+				// The away message goes over everything else
+				if message, ok := awayWatcher.Current(time.Now()); ok {
+					overlayAway(grid, message, *awayStyle, awayTextFont)
+				}
+
+				// Draw the changes since the last frame
+				if _, err := renderer.Flush(); err != nil && *debug {
+					fmt.Fprintf(os.Stderr, "Error writing frame: %v\n", err)
+				}
 
 				frame++
+
+				// Sleep until the next frame slot, skipping any this frame overran
+				frameTimer.Reset(pacer.Wait(time.Now()))
 			case <-c:
 				// Received interrupt or termination signal
 				if *debug {
-					fmt.Printf("Received interrupt, stopping after %d frames\n", frame)
+					fmt.Printf("Received interrupt, stopping after %d frames (%d skipped)\n", frame, pacer.skipped)
 				}
 				os.Exit(0)
 			case <-sigwinch:
@@ -358,13 +624,26 @@ func main() {
 							fmt.Printf("Terminal resized from %dx%d to %dx%d\n", width, height, newWidth, newHeight)
 						}
 						width, height = newWidth, newHeight
-						anim.Resize(width, height)
+						// Image art is converted again for the new size
+						// rather than stretched. Resizing rebuilds the
+						// effect with the text it was last given.
+						if pic != nil {
+							setText(pic.Text(width, height))
+						}
+						timed.Resize(width, height)
+						renderer.Resize(width, height)
+						if cast != nil {
+							cast.Resize(width, height)
+						}
 					}
 				}
 			}
 		}
 	}()
 
-	// Wait for interrupt or termination signal
-	<-c
+	// Wait for interrupt or termination signal, or the last frame
+	select {
+	case <-c:
+	case <-done:
+	}
 }
@@ -0,0 +1,42 @@
+// pacing.go - Frame pacing for the animation loop
+package main
+
+import "time"
+
+// framePacer schedules frames on a fixed grid of slots. A frame that
+// overruns its slot skips the slots it missed instead of queueing them, so
+// a slow frame never causes a burst of late frames afterwards.
+type framePacer struct {
+	interval time.Duration
+	next     time.Time // Start of the next frame slot
+	last     time.Time // Start of the previous frame
+	skipped  int       // Slots dropped because frames ran long
+}
+
+// newFramePacer creates a pacer for fps frames per second starting at now
+func newFramePacer(fps int, now time.Time) *framePacer {
+	return &framePacer{
+		interval: time.Second / time.Duration(fps),
+		next:     now,
+		last:     now,
+	}
+}
+
+// Begin marks the start of a frame and returns the time since the previous one
+func (p *framePacer) Begin(now time.Time) time.Duration {
+	elapsed := now.Sub(p.last)
+	p.last = now
+	return elapsed
+}
+
+// Wait returns how long to sleep before the next frame, called once the
+// current frame has been drawn
+func (p *framePacer) Wait(now time.Time) time.Duration {
+	p.next = p.next.Add(p.interval)
+	if late := now.Sub(p.next); late >= 0 {
+		missed := int(late/p.interval) + 1
+		p.skipped += missed
+		p.next = p.next.Add(time.Duration(missed) * p.interval)
+	}
+	return p.next.Sub(now)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFramePacer tests frame slots and skipping after slow frames
+func TestFramePacer(t *testing.T) {
+	start := time.Unix(0, 0)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	p := newFramePacer(20, start)
+
+	// Frame 0 renders in 10ms and sleeps until the 50ms slot
+	if elapsed := p.Begin(at(0)); elapsed != 0 {
+		t.Errorf("first Begin() = %v, want 0", elapsed)
+	}
+	if wait := p.Wait(at(10)); wait != 40*time.Millisecond {
+		t.Errorf("Wait() = %v, want 40ms", wait)
+	}
+
+	// Frame 1 takes 120ms: the 100ms and 150ms slots are skipped
+	if elapsed := p.Begin(at(50)); elapsed != 50*time.Millisecond {
+		t.Errorf("Begin() = %v, want 50ms", elapsed)
+	}
+	if wait := p.Wait(at(170)); wait != 30*time.Millisecond {
+		t.Errorf("Wait() after slow frame = %v, want 30ms", wait)
+	}
+	if p.skipped != 2 {
+		t.Errorf("skipped = %d, want 2", p.skipped)
+	}
+
+	// The elapsed time includes the stall so animations keep their speed
+	if elapsed := p.Begin(at(200)); elapsed != 150*time.Millisecond {
+		t.Errorf("Begin() after slow frame = %v, want 150ms", elapsed)
+	}
+
+	// A frame finishing exactly on the next slot skips it too
+	if wait := p.Wait(at(250)); wait != 50*time.Millisecond || p.skipped != 3 {
+		t.Errorf("Wait() on slot boundary = %v (skipped %d), want 50ms (3)", wait, p.skipped)
+	}
+}
@@ -0,0 +1,108 @@
+// picture.go - Images drawn as the art of text-based effects
+package main
+
+import (
+	"fmt"
+	"image"
+	"os"
+
+	"github.com/Nomadcxx/sysc-walls/internal/art"
+	"github.com/Nomadcxx/sysc-walls/internal/imageart"
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+)
+
+// Cells left free around an image on each side
+const (
+	pictureMarginX = 2
+	pictureMarginY = 1
+)
+
+// picture is an image shown as art. It is converted again for each screen
+// size rather than stretched, and the cells of the art the effect shows in
+// place can be drawn in the image's colors.
+type picture struct {
+	img    image.Image
+	opts   imageart.Options
+	colors bool // Draw the art in the image's colors
+
+	art  *imageart.Art
+	mask [][]rune // The art's characters, as the effect draws them
+	x, y int      // Top left of the art on screen, where the effects center it
+}
+
+// newPicture creates a picture of img
+func newPicture(img image.Image, opts imageart.Options, colors bool) *picture {
+	return &picture{img: img, opts: opts, colors: colors}
+}
+
+// Text converts the image for a screen size and returns it as effect text
+func (p *picture) Text(width, height int) string {
+	p.art = imageart.Convert(p.img, width-2*pictureMarginX, height-2*pictureMarginY, p.opts)
+	p.mask = make([][]rune, len(p.art.Lines))
+	for i, line := range p.art.Lines {
+		p.mask[i] = []rune(line)
+	}
+	p.x = (width - p.art.Width()) / 2
+	p.y = (height - len(p.art.Lines)) / 2
+	return p.art.Text()
+}
+
+// Overlay draws the cells that show the art's character in the image's
+// colors. Cells the effect is still animating keep their own look.
+func (p *picture) Overlay(grid *render.Grid) {
+	if !p.colors || p.art == nil {
+		return
+	}
+	for row, cells := range p.art.Colors {
+		for col, cell := range cells {
+			if cell.Rune == ' ' {
+				continue
+			}
+			x, y := p.x+col, p.y+row
+			if x < 0 || x >= grid.Width || y < 0 || y >= grid.Height {
+				continue
+			}
+			shown := grid.At(x, y)
+			if shown.Rune != p.mask[row][col] {
+				continue
+			}
+
+			style := shown.Style
+			style.Fg = render.RGBColor(cell.Fg.R, cell.Fg.G, cell.Fg.B)
+			if cell.Bg.A != 0 {
+				style.Bg = render.RGBColor(cell.Bg.R, cell.Bg.G, cell.Bg.B)
+			}
+			grid.Set(x, y, render.Cell{Rune: cell.Rune, Style: style})
+		}
+	}
+}
+
+// pieceText returns the effect text for a piece of art. Images become a
+// picture drawn at the screen size; one that converts to nothing is
+// replaced by the default art.
+func pieceText(piece art.Piece, opts imageart.Options, colors bool, width, height int, debug bool) (string, *picture) {
+	if piece.Image == nil {
+		return piece.Text, nil
+	}
+	pic := newPicture(piece.Image, opts, colors)
+	if text := pic.Text(width, height); text != "" {
+		return text, pic
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %s has nothing above the image threshold, using the default art\n", piece.From)
+	text, from := art.Default()
+	if debug {
+		fmt.Fprintf(os.Stderr, "Loaded art from: %s\n", from)
+	}
+	return text, nil
+}
+
+// imageOptions builds the conversion options from their flags, falling
+// back to the defaults with a warning
+func imageOptions(mode string, threshold float64, dither, invert bool) imageart.Options {
+	opts := imageart.Options{Mode: mode, Threshold: threshold, Dither: dither, Invert: invert}
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using %s at %g\n", err, imageart.DefaultOptions.Mode, imageart.DefaultOptions.Threshold)
+		opts.Mode, opts.Threshold = imageart.DefaultOptions.Mode, imageart.DefaultOptions.Threshold
+	}
+	return opts
+}
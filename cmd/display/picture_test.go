@@ -0,0 +1,48 @@
+package main
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/Nomadcxx/sysc-walls/internal/art"
+	"github.com/Nomadcxx/sysc-walls/internal/imageart"
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+)
+
+// TestPicture tests image art sizing and coloring
+func TestPicture(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff // Opaque white
+	}
+	pic := newPicture(img, imageart.DefaultOptions, true)
+
+	// Fitted inside the margins, keeping the 2:1 shape
+	text := pic.Text(20, 10)
+	if lines := strings.Split(text, "\n"); len(lines) != 4 || lines[0] != strings.Repeat("█", 16) {
+		t.Fatalf("text =\n%s", text)
+	}
+	if pic.x != 2 || pic.y != 3 {
+		t.Errorf("art at %d,%d, want 2,3", pic.x, pic.y)
+	}
+
+	// Only cells showing the art's character take its color
+	grid := render.NewGrid(20, 10)
+	red := render.Style{Fg: render.RGBColor(255, 0, 0)}
+	grid.Set(2, 3, render.Cell{Rune: '█', Style: red})
+	grid.Set(3, 3, render.Cell{Rune: 'x', Style: red})
+	pic.Overlay(grid)
+	if got := grid.At(2, 3).Style.Fg; got != render.RGBColor(255, 255, 255) {
+		t.Errorf("art cell color = %v", got)
+	}
+	if got := grid.At(3, 3); got.Rune != 'x' || got.Style != red {
+		t.Errorf("animating cell = %+v", got)
+	}
+
+	// A picture that converts to nothing falls back to the default art
+	dark := image.NewGray(image.Rect(0, 0, 4, 4))
+	if text, pic := pieceText(art.Piece{Image: dark, From: "dark.png"}, imageart.DefaultOptions, true, 20, 10, false); pic != nil || text == "" {
+		t.Errorf("blank image = %q, %v", text, pic)
+	}
+}
@@ -0,0 +1,42 @@
+// textsource.go - Live text for text-based effects
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/clock"
+	"github.com/Nomadcxx/sysc-walls/internal/figlet"
+)
+
+// clockText draws the current time as the text of a text-based effect.
+// The time is shown to the minute, so the text only changes once a minute.
+type clockText struct {
+	clock *clock.Clock
+	font  *figlet.Font
+	last  string // Time drawn last
+}
+
+// newClockText returns a clock text source in the given format and locale
+func newClockText(font *figlet.Font, format, locale string) *clockText {
+	c, err := clock.New(clock.Options{Font: font, Format: format, NoSeconds: true, Locale: locale})
+	if err != nil {
+		c, _ = clock.New(clock.Options{Font: font, NoSeconds: true})
+	}
+	return &clockText{clock: c, font: font}
+}
+
+// Next returns the text for now, and whether it changed since the last call
+func (s *clockText) Next(now time.Time) (string, bool) {
+	timeStr := strings.TrimSpace(s.clock.Time(now))
+	if timeStr == s.last {
+		return "", false
+	}
+	s.last = timeStr
+
+	lines := clock.DrawText(s.font, timeStr)
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n"), true
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/figlet"
+)
+
+// TestClockText tests that the clock text only changes with the minute
+func TestClockText(t *testing.T) {
+	source := newClockText(figlet.MustLoad("block"), "24h", "en")
+	at := func(h, m, s int) time.Time { return time.Date(2026, 1, 2, h, m, s, 0, time.Local) }
+
+	first, changed := source.Next(at(9, 41, 5))
+	if !changed || first == "" {
+		t.Fatalf("first Next() = %q, %t", first, changed)
+	}
+	want := strings.Join(figlet.MustLoad("block").Render("09:41"), "\n")
+	if strings.Count(first, "\n") != strings.Count(want, "\n") {
+		t.Errorf("clock text has %d lines, want %d", strings.Count(first, "\n")+1, strings.Count(want, "\n")+1)
+	}
+	for _, line := range strings.Split(first, "\n") {
+		if strings.HasSuffix(line, " ") {
+			t.Errorf("line %q has trailing spaces", line)
+		}
+	}
+
+	if _, changed := source.Next(at(9, 41, 59)); changed {
+		t.Error("text changed within the minute")
+	}
+	next, changed := source.Next(at(9, 42, 0))
+	if !changed || next == first {
+		t.Error("text did not change with the minute")
+	}
+}
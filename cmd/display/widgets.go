@@ -0,0 +1,237 @@
+// widgets.go - Info widgets drawn over the animation
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+	"github.com/mattn/go-runewidth"
+)
+
+// Widget is a small block of glanceable text, such as the battery level
+type Widget interface {
+	// Interval is how often the widget is rendered again
+	Interval() time.Duration
+	// Render returns the widget's lines, or none to hide it
+	Render(now time.Time) []string
+}
+
+// maxWidgetWidth is the widest a widget line is drawn, in cells
+const maxWidgetWidth = 60
+
+// widgetStyle is the SGR prefix widget text is drawn with
+const widgetStyle = "\x1b[38;2;255;255;255m"
+
+// anchor places a block of widget lines: -1, 0 or 1 for left, center or
+// right and top, middle or bottom
+type anchor struct{ h, v int }
+
+// placements are the positions a widget can take on the screen
+var placements = map[string]anchor{
+	"top-left":     {-1, -1},
+	"top":          {0, -1},
+	"top-right":    {1, -1},
+	"left":         {-1, 0},
+	"center":       {0, 0},
+	"right":        {1, 0},
+	"bottom-left":  {-1, 1},
+	"bottom":       {0, 1},
+	"bottom-right": {1, 1},
+}
+
+// widgetSlot is a widget at its position, with the lines it last rendered
+type widgetSlot struct {
+	widget   Widget
+	position string
+	interval time.Duration
+
+	mu    sync.Mutex
+	lines []string
+}
+
+// refresh renders the widget and keeps its lines for drawing
+func (s *widgetSlot) refresh(now time.Time) {
+	lines := s.widget.Render(now)
+	for i, line := range lines {
+		lines[i] = runewidth.Truncate(line, maxWidgetWidth, "...")
+	}
+
+	s.mu.Lock()
+	s.lines = lines
+	s.mu.Unlock()
+}
+
+// run refreshes the widget every interval. Widgets may read files or the
+// bus, so they refresh outside the frame loop.
+func (s *widgetSlot) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		s.refresh(now)
+	}
+}
+
+// widgetBoard holds the widgets drawn over the animation
+type widgetBoard struct {
+	slots []*widgetSlot
+}
+
+// Add places a widget. An interval of 0 uses the widget's own.
+func (b *widgetBoard) Add(widget Widget, position string, interval time.Duration) {
+	if interval <= 0 {
+		interval = widget.Interval()
+	}
+	b.slots = append(b.slots, &widgetSlot{widget: widget, position: position, interval: interval})
+}
+
+// Start renders every widget once, then keeps them refreshing
+func (b *widgetBoard) Start(now time.Time) {
+	for _, slot := range b.slots {
+		slot.refresh(now)
+		go slot.run()
+	}
+}
+
+// Empty reports whether the board has no widgets
+func (b *widgetBoard) Empty() bool {
+	return len(b.slots) == 0
+}
+
+// Layers returns the widgets' lines as layers on a width x height screen.
+// Widgets at the same position are stacked in the order they were added.
+func (b *widgetBoard) Layers(width, height int) []Layer {
+	var order []string
+	blocks := make(map[string][]string)
+	for _, slot := range b.slots {
+		slot.mu.Lock()
+		lines := slot.lines
+		slot.mu.Unlock()
+		if len(lines) == 0 {
+			continue
+		}
+		if _, ok := blocks[slot.position]; !ok {
+			order = append(order, slot.position)
+		}
+		blocks[slot.position] = append(blocks[slot.position], lines...)
+	}
+
+	var layers []Layer
+	for _, position := range order {
+		layers = append(layers, placeLines(blocks[position], placements[position], width, height)...)
+	}
+	return layers
+}
+
+// placeLines lays out a block of lines at an anchor, one layer per line.
+// Lines line up on the block's left, center or right edge, a margin in
+// from the screen edge.
+func placeLines(lines []string, at anchor, width, height int) []Layer {
+	const marginX, marginY = 2, 1
+
+	var startY int
+	switch at.v {
+	case -1:
+		startY = marginY
+	case 0:
+		startY = (height - len(lines)) / 2
+	default:
+		startY = height - len(lines) - marginY
+	}
+
+	layers := make([]Layer, 0, len(lines))
+	for i, line := range lines {
+		cells := render.ParseGrid(widgetStyle + line)
+		var x int
+		switch at.h {
+		case -1:
+			x = marginX
+		case 0:
+			x = (width - cells.Width) / 2
+		default:
+			x = width - cells.Width - marginX
+		}
+		layers = append(layers, Layer{Cells: cells, X: x, Y: startY + i})
+	}
+	return layers
+}
+
+// overlayWidgets draws the board's widgets onto the animation's cell grid
+func overlayWidgets(grid *render.Grid, board *widgetBoard) {
+	composite(grid, board.Layers(grid.Width, grid.Height)...)
+}
+
+// widgetFlags collects repeated --widget flags
+type widgetFlags []string
+
+func (f *widgetFlags) String() string {
+	return strings.Join(*f, " ")
+}
+
+func (f *widgetFlags) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// widgetSpec is one parsed --widget flag
+type widgetSpec struct {
+	kind     string
+	position string
+	interval time.Duration
+	file     string
+}
+
+// parseWidgetSpec parses "kind,position=P,interval=D,file=F". Everything
+// but the kind is optional; widgets go top-left by default.
+func parseWidgetSpec(value string) (widgetSpec, error) {
+	fields := strings.Split(value, ",")
+	spec := widgetSpec{kind: strings.TrimSpace(fields[0]), position: "top-left"}
+	if spec.kind == "" {
+		return spec, fmt.Errorf("widget %q has no kind", value)
+	}
+
+	for _, field := range fields[1:] {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return spec, fmt.Errorf("widget %q: %q is not key=value", value, field)
+		}
+		switch strings.TrimSpace(key) {
+		case "position":
+			if _, ok := placements[val]; !ok {
+				return spec, fmt.Errorf("widget %q: unknown position %q", value, val)
+			}
+			spec.position = val
+		case "interval":
+			interval, err := time.ParseDuration(val)
+			if err != nil || interval < time.Second {
+				return spec, fmt.Errorf("widget %q: interval must be 1s or more", value)
+			}
+			spec.interval = interval
+		case "file":
+			spec.file = val
+		default:
+			return spec, fmt.Errorf("widget %q: unknown setting %q", value, key)
+		}
+	}
+	return spec, nil
+}
+
+// newWidgetBoard builds the board from --widget flags. Widgets that fail
+// to parse are left out with a warning.
+func newWidgetBoard(flags []string, warn func(error)) *widgetBoard {
+	board := &widgetBoard{}
+	for _, value := range flags {
+		spec, err := parseWidgetSpec(value)
+		if err == nil {
+			var widget Widget
+			if widget, err = newWidget(spec); err == nil {
+				board.Add(widget, spec.position, spec.interval)
+				continue
+			}
+		}
+		warn(err)
+	}
+	return board
+}
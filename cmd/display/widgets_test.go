@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// lineWidget is a widget with fixed lines
+type lineWidget []string
+
+func (lineWidget) Interval() time.Duration { return time.Minute }
+
+func (w lineWidget) Render(time.Time) []string { return append([]string(nil), w...) }
+
+// TestWidgetLayout tests widget placement and stacking
+func TestWidgetLayout(t *testing.T) {
+	board := &widgetBoard{}
+	board.Add(lineWidget{"host"}, "top-left", 0)
+	board.Add(lineWidget{"Battery 80%"}, "bottom-right", 0)
+	board.Add(lineWidget{"one", "two"}, "top-left", 0)
+	board.Add(lineWidget{"mid"}, "center", 0)
+	board.Add(lineWidget(nil), "top", 0) // Hidden
+	board.Start(time.Now())
+
+	type pos struct{ x, y, w int }
+	var got []pos
+	for _, layer := range board.Layers(40, 10) {
+		got = append(got, pos{layer.X, layer.Y, layer.Cells.Width})
+	}
+	want := []pos{
+		{2, 1, 4}, {2, 2, 3}, {2, 3, 3}, // Stacked top-left
+		{27, 8, 11}, // Right edge two cells in
+		{18, 4, 3},  // Centered
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("layers = %v, want %v", got, want)
+	}
+}
+
+// TestParseWidgetSpec tests --widget values
+func TestParseWidgetSpec(t *testing.T) {
+	spec, err := parseWidgetSpec("status,position=bottom,interval=10s,file=/tmp/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (widgetSpec{"status", "bottom", 10 * time.Second, "/tmp/status"}); spec != want {
+		t.Errorf("spec = %+v, want %+v", spec, want)
+	}
+	if spec, _ := parseWidgetSpec("cpu"); spec.position != "top-left" || spec.interval != 0 {
+		t.Errorf("defaults = %+v", spec)
+	}
+
+	for _, bad := range []string{"", "cpu,position=middle", "cpu,interval=10ms", "cpu,colour=red", "cpu,top"} {
+		if _, err := parseWidgetSpec(bad); err == nil {
+			t.Errorf("parseWidgetSpec(%q) should fail", bad)
+		}
+	}
+
+	var warnings int
+	board := newWidgetBoard([]string{"hostname", "weather", "status"}, func(error) { warnings++ })
+	if len(board.slots) != 1 || warnings != 2 {
+		t.Errorf("%d widgets and %d warnings, want 1 and 2", len(board.slots), warnings)
+	}
+}
+
+// TestInfoWidgets tests the widgets against fake system files
+func TestInfoWidgets(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Battery
+	write("power/BAT0/type", "Battery")
+	write("power/BAT0/capacity", "64")
+	write("power/BAT0/status", "Discharging")
+	if got := (batteryWidget{filepath.Join(dir, "power")}).Render(time.Now()); !reflect.DeepEqual(got, []string{"Battery 64%"}) {
+		t.Errorf("battery = %q", got)
+	}
+	if got := (batteryWidget{filepath.Join(dir, "none")}).Render(time.Now()); got != nil {
+		t.Errorf("battery without a battery = %q", got)
+	}
+
+	// CPU: 100 of 400 jiffies busy since the first read
+	write("proc/stat", "cpu  100 0 100 800 0 0 0 0 0 0\ncpu0 1 2 3 4\n")
+	write("proc/loadavg", "0.52 0.48 0.40 1/234 5678\n")
+	cpu := newCPUWidget(filepath.Join(dir, "proc"))
+	write("proc/stat", "cpu  150 0 150 1100 0 0 0 0 0 0\n")
+	if got := cpu.Render(time.Now()); !reflect.DeepEqual(got, []string{"CPU 25%  load 0.52 0.48 0.40"}) {
+		t.Errorf("cpu = %q", got)
+	}
+
+	// Status file
+	status := statusWidget{filepath.Join(dir, "status")}
+	if got := status.Render(time.Now()); got != nil {
+		t.Errorf("missing status = %q", got)
+	}
+	write("status", "Deploying\nstep 2 of 5  \n\nline 4\n")
+	if got := status.Render(time.Now()); !reflect.DeepEqual(got, []string{"Deploying", "step 2 of 5", ""}) {
+		t.Errorf("status = %q", got)
+	}
+
+	// Notifications from the first daemon that answers
+	notes := notificationsWidget{probes: [][]string{{"no-such-daemon-ctl"}, {"echo", "3"}}}
+	if got := notes.Render(time.Now()); !reflect.DeepEqual(got, []string{"3 notifications"}) {
+		t.Errorf("notifications = %q", got)
+	}
+	if got := (notificationsWidget{probes: [][]string{{"echo", "0"}}}).Render(time.Now()); got != nil {
+		t.Errorf("no notifications = %q", got)
+	}
+
+	// Now playing
+	track := map[string]dbus.Variant{
+		"xesam:title":  dbus.MakeVariant("Teardrop"),
+		"xesam:artist": dbus.MakeVariant([]string{"Massive Attack"}),
+	}
+	if got := trackLine(track); got != "Playing: Massive Attack - Teardrop" {
+		t.Errorf("track = %q", got)
+	}
+	if got := trackLine(map[string]dbus.Variant{}); got != "" {
+		t.Errorf("empty metadata = %q", got)
+	}
+}
@@ -1,6 +1,8 @@
 // animations.go - Animation handling
 package animations
 
+import "github.com/Nomadcxx/sysc-walls/internal/theme"
+
 // Animation interface for all animations
 type Animation interface {
 	Update(frame int)
@@ -19,3 +21,25 @@ func CreateAnimationWithText(effect string, width, height int, theme string, tex
 	// Use optimized implementation with text support
 	return CreateOptimizedAnimationWithText(effect, width, height, theme, text)
 }
+
+// CreateAnimationWithSeed creates an animation that plays out the same way
+// every time for the same seed, for recordings and tests. A seed of 0 is
+// the same as CreateAnimationWithText.
+func CreateAnimationWithSeed(effect string, width, height int, theme string, text string, seed int64) (Animation, error) {
+	return CreateOptimizedAnimationWithSeed(effect, width, height, theme, text, seed)
+}
+
+// Options are the settings of an animation beyond its effect and theme
+type Options struct {
+	Text   string       // Art of text-based effects, "" for the default
+	Seed   int64        // Nonzero for a repeatable run
+	Fit    Fit          // How text-based effects fit their art to the screen
+	Theme  *theme.Theme // Colors to use instead of the named theme's
+	Params Params       // Parameters of the effect, checked against its schema
+}
+
+// CreateAnimationWithOptions creates an animation with text, a seed, an art
+// fit, colors other than the named theme's and effect parameters
+func CreateAnimationWithOptions(effect string, width, height int, theme string, opts Options) (Animation, error) {
+	return CreateOptimizedAnimationWithOptions(effect, width, height, theme, opts)
+}
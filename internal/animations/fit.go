@@ -0,0 +1,284 @@
+// fit.go - Fitting the art of text effects to the screen
+package animations
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Fit modes
+const (
+	FitNone    = "none"    // Art as drawn, cropped when it doesn't fit
+	FitFit     = "fit"     // Scaled up or down to the largest size that fits
+	FitFill    = "fill"    // Scaled to cover the screen, cropped at the anchor
+	FitInteger = "integer" // Scaled up by whole factors only, down like fit
+)
+
+// FitModes lists the fit modes
+var FitModes = []string{FitNone, FitFit, FitFill, FitInteger}
+
+// FitAnchors lists the parts of the art kept when it is cropped
+var FitAnchors = []string{
+	"center", "top", "bottom", "left", "right",
+	"top-left", "top-right", "bottom-left", "bottom-right",
+}
+
+// Fit is how a text effect fits its art to the screen. The zero Fit
+// leaves art that fits untouched.
+type Fit struct {
+	Mode    string // One of FitModes, "" for FitNone
+	Anchor  string // One of FitAnchors, "" for center
+	Padding int    // Cells kept clear on each side
+}
+
+// Validate checks the mode and anchor
+func (f Fit) Validate() error {
+	if f.Mode != "" && !contains(FitModes, f.Mode) {
+		return fmt.Errorf("unknown fit mode %q (use %s)", f.Mode, strings.Join(FitModes, ", "))
+	}
+	if f.Anchor != "" && !contains(FitAnchors, f.Anchor) {
+		return fmt.Errorf("unknown fit anchor %q (use %s)", f.Anchor, strings.Join(FitAnchors, ", "))
+	}
+	if f.Padding < 0 {
+		return fmt.Errorf("fit padding %d is negative", f.Padding)
+	}
+	return nil
+}
+
+// contains reports whether list holds s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// halfBlocks maps the half block characters to their top and bottom halves
+var halfBlocks = map[rune][2]bool{
+	' ': {false, false},
+	'▀': {true, false},
+	'▄': {false, true},
+	'█': {true, true},
+}
+
+// FitArt scales and crops art for a width by height screen. Art is scaled
+// the same across and down, so it keeps its shape. Art made only of half
+// and full blocks is scaled down by coverage at half-cell height; other
+// art keeps the most common character of each area it is scaled down from.
+func FitArt(text string, width, height int, fit Fit) string {
+	cells := artCells(text)
+	artW, artH := cellsWidth(cells), len(cells)
+	availW, availH := max(width-2*fit.Padding, 1), max(height-2*fit.Padding, 1)
+	if artW == 0 || artH == 0 {
+		return text
+	}
+
+	across, down := float64(availW)/float64(artW), float64(availH)/float64(artH)
+	scale := 1.0
+	switch fit.Mode {
+	case FitFit:
+		scale = math.Min(across, down)
+	case FitFill:
+		scale = math.Max(across, down)
+	case FitInteger:
+		scale = math.Min(across, down)
+		if scale >= 1 {
+			scale = math.Floor(scale)
+		}
+	}
+
+	if scale != 1 {
+		w := max(int(math.Round(float64(artW)*scale)), 1)
+		h := max(int(math.Round(float64(artH)*scale)), 1)
+		if scale < 1 && blockArt(cells) {
+			cells = scaleBlocks(cells, artW, artH, w, h)
+		} else {
+			cells = scaleCells(cells, artW, artH, w, h)
+		}
+		artW, artH = w, h
+	} else if artW <= availW && artH <= availH {
+		return text
+	}
+
+	cells = crop(cells, artW, artH, min(artW, availW), min(artH, availH), fit.Anchor)
+	lines := make([]string, len(cells))
+	for i, row := range cells {
+		lines[i] = string(row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// artCells splits art into equally wide rows of characters
+func artCells(text string) [][]rune {
+	lines := strings.Split(text, "\n")
+	cells := make([][]rune, len(lines))
+	width := 0
+	for i, line := range lines {
+		cells[i] = []rune(line)
+		width = max(width, len(cells[i]))
+	}
+	for i, row := range cells {
+		for len(row) < width {
+			row = append(row, ' ')
+		}
+		cells[i] = row
+	}
+	return cells
+}
+
+// cellsWidth returns the width of equally wide rows
+func cellsWidth(cells [][]rune) int {
+	if len(cells) == 0 {
+		return 0
+	}
+	return len(cells[0])
+}
+
+// blockArt reports whether art is drawn only with half and full blocks
+func blockArt(cells [][]rune) bool {
+	for _, row := range cells {
+		for _, r := range row {
+			if _, ok := halfBlocks[r]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// span returns the source cells target cell i of n covers, of size cells
+func span(i, n, size int) (int, int) {
+	from := i * size / n
+	to := max((i+1)*size/n, from+1)
+	return from, min(to, size)
+}
+
+// scaleCells resizes art to w by h cells. Each cell takes the most common
+// character of the area it covers, or a space when mostly blank.
+func scaleCells(cells [][]rune, artW, artH, w, h int) [][]rune {
+	out := make([][]rune, h)
+	for y := range out {
+		y0, y1 := span(y, h, artH)
+		out[y] = make([]rune, w)
+		for x := range out[y] {
+			x0, x1 := span(x, w, artW)
+			counts := make(map[rune]int)
+			inked, best := 0, ' '
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					r := cells[sy][sx]
+					if r == ' ' {
+						continue
+					}
+					inked++
+					counts[r]++
+					if counts[r] > counts[best] || (counts[r] == counts[best] && r < best) {
+						best = r
+					}
+				}
+			}
+			if inked*2 < (y1-y0)*(x1-x0) {
+				best = ' '
+			}
+			out[y][x] = best
+		}
+	}
+	return out
+}
+
+// scaleBlocks resizes block art to w by h cells by the coverage of each
+// half cell
+func scaleBlocks(cells [][]rune, artW, artH, w, h int) [][]rune {
+	// Half cells, two per character
+	pixels := make([][]bool, artH*2)
+	for y, row := range cells {
+		pixels[2*y], pixels[2*y+1] = make([]bool, artW), make([]bool, artW)
+		for x, r := range row {
+			halves := halfBlocks[r]
+			pixels[2*y][x], pixels[2*y+1][x] = halves[0], halves[1]
+		}
+	}
+
+	on := func(px, py int) bool {
+		y0, y1 := span(py, h*2, artH*2)
+		x0, x1 := span(px, w, artW)
+		covered := 0
+		for sy := y0; sy < y1; sy++ {
+			for sx := x0; sx < x1; sx++ {
+				if pixels[sy][sx] {
+					covered++
+				}
+			}
+		}
+		return covered*2 >= (y1-y0)*(x1-x0)
+	}
+
+	out := make([][]rune, h)
+	for y := range out {
+		out[y] = make([]rune, w)
+		for x := range out[y] {
+			top, bottom := on(x, 2*y), on(x, 2*y+1)
+			switch {
+			case top && bottom:
+				out[y][x] = '█'
+			case top:
+				out[y][x] = '▀'
+			case bottom:
+				out[y][x] = '▄'
+			default:
+				out[y][x] = ' '
+			}
+		}
+	}
+	return out
+}
+
+// crop cuts art down to w by h cells, keeping the part the anchor names
+func crop(cells [][]rune, artW, artH, w, h int, anchor string) [][]rune {
+	x := (artW - w) / 2
+	if strings.HasSuffix(anchor, "left") {
+		x = 0
+	} else if strings.HasSuffix(anchor, "right") {
+		x = artW - w
+	}
+	y := (artH - h) / 2
+	if strings.HasPrefix(anchor, "top") {
+		y = 0
+	} else if strings.HasPrefix(anchor, "bottom") {
+		y = artH - h
+	}
+
+	out := make([][]rune, h)
+	for i := range out {
+		out[i] = cells[y+i][x : x+w]
+	}
+	return out
+}
+
+// artFit keeps the art of a text effect wrapper as it was given, so it can
+// be fitted afresh for each size
+type artFit struct {
+	fit           Fit
+	art           string // The art before fitting
+	width, height int
+}
+
+// fitted returns the art fitted to the current size
+func (a *artFit) fitted() string {
+	return FitArt(a.art, a.width, a.height, a.fit)
+}
+
+// resize records a new size and returns the art fitted to it
+func (a *artFit) resize(width, height int) string {
+	a.width, a.height = width, height
+	return a.fitted()
+}
+
+// setArt replaces the art and returns it fitted to the current size
+func (a *artFit) setArt(text string) string {
+	a.art = text
+	return a.fitted()
+}
@@ -0,0 +1,67 @@
+package animations
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFitArt tests the fit modes, anchors and padding
+func TestFitArt(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		width, height int
+		fit           Fit
+		want          string
+	}{
+		{"none leaves art that fits", "ab\nc", 10, 10, Fit{}, "ab\nc"},
+		{"none crops the middle", "abcde\nfghij\nklmno", 3, 1, Fit{}, "ghi"},
+		{"none crops at the anchor", "abcde\nfghij\nklmno", 3, 1, Fit{Anchor: "bottom-right"}, "mno"},
+		{"fit scales up", "ab\ncd", 10, 10, Fit{Mode: FitFit, Padding: 3}, "aabb\naabb\nccdd\nccdd"},
+		{"fit keeps the shape", "ab", 6, 10, Fit{Mode: FitFit}, "aaabbb\naaabbb\naaabbb"},
+		{"integer scales by whole factors", "ab", 7, 10, Fit{Mode: FitInteger}, "aaabbb\naaabbb\naaabbb"},
+		{"integer scales down like fit", "aabb\naabb", 2, 10, Fit{Mode: FitInteger}, "ab"},
+		{"fill covers and crops", "ab", 4, 4, Fit{Mode: FitFill}, "aabb\naabb\naabb\naabb"},
+		{"fill crops at the anchor", "ab", 4, 4, Fit{Mode: FitFill, Anchor: "left"}, "aaaa\naaaa\naaaa\naaaa"},
+		{"block art keeps half blocks", "████\n    ", 2, 10, Fit{Mode: FitFit}, "▀▀"},
+		{"other art keeps common characters", "##..\n#..#", 2, 10, Fit{Mode: FitFit}, "#."},
+		{"blank areas stay blank", "#   \n    ", 2, 10, Fit{Mode: FitFit}, "  "},
+	}
+	for _, tt := range tests {
+		if got := FitArt(tt.text, tt.width, tt.height, tt.fit); got != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+
+	if err := (Fit{Mode: "stretch"}).Validate(); err == nil {
+		t.Error("Validate() accepted mode stretch")
+	}
+	if err := (Fit{Mode: FitFill, Anchor: "top-left", Padding: 2}).Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
+
+// TestFitResize tests that text effects fit their art again when resized
+func TestFitResize(t *testing.T) {
+	for _, effect := range []string{"fire-text", "matrix-art", "rain-art", "beam-text", "blackhole", "ring-text"} {
+		anim, err := CreateAnimationWithOptions(effect, 20, 10, "nord", Options{Text: "AB", Seed: goldenSeed, Fit: Fit{Mode: FitInteger}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		row := strings.Repeat("A", 10) + strings.Repeat("B", 10)
+		if got := effectOf(anim).Text(); got != strings.TrimSuffix(strings.Repeat(row+"\n", 10), "\n") {
+			t.Errorf("%s: art at 20x10 =\n%s", effect, got)
+		}
+
+		anim.Resize(4, 2)
+		if got := effectOf(anim).Text(); got != "AABB\nAABB" {
+			t.Errorf("%s: art at 4x2 = %q", effect, got)
+		}
+
+		// New text is fitted too
+		anim.(TextAnimation).SetText("C")
+		if got := effectOf(anim).Text(); got != "CC\nCC" {
+			t.Errorf("%s: art = %q", effect, got)
+		}
+	}
+}
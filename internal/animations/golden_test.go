@@ -0,0 +1,143 @@
+package animations
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Regenerate with: go test ./internal/animations -run Golden -update
+var update = flag.Bool("update", false, "rewrite golden frames")
+
+const (
+	goldenWidth  = 40
+	goldenHeight = 24 // aquarium needs at least 24 rows
+	goldenFrame  = 40 // Two seconds in at the default step
+	goldenSeed   = 1
+)
+
+// goldenEffects are the effects snapshotted by TestGolden
+var goldenEffects = []string{
+	"matrix", "matrix-art", "fire", "fire-text", "fireworks", "rain", "rain-art",
+	"beams", "beam-text", "decrypt", "pour", "aquarium", "print", "blackhole", "ring-text",
+}
+
+// goldenThemes are the themes snapshotted with color on one effect
+var goldenThemes = []string{
+	"dracula", "gruvbox", "nord", "tokyo-night", "catppuccin", "material",
+	"solarized", "monochrome", "transishardjob", "rama", "eldritch", "dark",
+}
+
+// goldenCase is one snapshot: an effect's frame in a theme, with or
+// without color
+type goldenCase struct {
+	effect  string
+	theme   string
+	colored bool
+}
+
+// path returns the golden file for the case
+func (c goldenCase) path() string {
+	ext := ".txt"
+	if c.colored {
+		ext = ".ansi"
+	}
+	return filepath.Join("testdata", "golden", c.effect+"_"+c.theme+ext)
+}
+
+// renderFrame renders frame n of a seeded effect
+func renderFrame(t *testing.T, effect, theme string, n int) string {
+	t.Helper()
+	anim, err := CreateAnimationWithSeed(effect, goldenWidth, goldenHeight, theme, "", goldenSeed)
+	if err != nil {
+		t.Fatalf("CreateAnimationWithSeed(%q) error = %v", effect, err)
+	}
+	for i := 0; i < n; i++ {
+		anim.Update(i)
+	}
+	return anim.Render()
+}
+
+// TestGolden tests frames of every effect and theme against snapshots, so
+// upgrading sysc-Go shows which effects changed
+func TestGolden(t *testing.T) {
+	// Colors shouldn't depend on the terminal running the tests
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(profile)
+
+	var cases []goldenCase
+	for _, effect := range goldenEffects {
+		cases = append(cases, goldenCase{effect, "nord", false}, goldenCase{effect, "nord", true})
+	}
+	for _, theme := range goldenThemes {
+		if theme != "nord" {
+			cases = append(cases, goldenCase{"fire", theme, true})
+		}
+	}
+
+	for _, tt := range cases {
+		t.Run(strings.TrimPrefix(tt.path(), filepath.Join("testdata", "golden")+string(filepath.Separator)), func(t *testing.T) {
+			got := renderFrame(t, tt.effect, tt.theme, goldenFrame)
+			if strings.TrimSpace(stripAnsiCodes(got)) == "" {
+				t.Fatalf("frame %d of %s is blank", goldenFrame, tt.effect)
+			}
+			if !tt.colored {
+				got = stripAnsiCodes(got)
+			}
+
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(tt.path()), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(tt.path(), []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(tt.path())
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("frame %d differs from %s\ngot:\n%s\nwant:\n%s", goldenFrame, tt.path(), stripAnsiCodes(got), stripAnsiCodes(string(want)))
+			}
+		})
+	}
+}
+
+// TestCreateAnimationWithSeed tests that seeded effects repeat across runs
+// and resizes
+func TestCreateAnimationWithSeed(t *testing.T) {
+	run := func(effect string) string {
+		anim, err := CreateAnimationWithSeed(effect, 80, 24, "nord", "", 7)
+		if err != nil {
+			t.Fatalf("CreateAnimationWithSeed(%q) error = %v", effect, err)
+		}
+		for i := 0; i < 20; i++ {
+			anim.Update(i)
+		}
+		first := anim.Render()
+		anim.Resize(100, 30)
+		for i := 0; i < 20; i++ {
+			anim.Update(i)
+		}
+		return first + anim.Render()
+	}
+
+	for _, effect := range goldenEffects {
+		if run(effect) != run(effect) {
+			t.Errorf("%s: seeded runs differ", effect)
+		}
+	}
+
+	if _, err := CreateAnimationWithSeed("invalid", 80, 24, "nord", "", 7); err == nil {
+		t.Error("CreateAnimationWithSeed(invalid) expected error, got nil")
+	}
+}
@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	syscGo "github.com/Nomadcxx/sysc-Go/animations"
+	syscGo "github.com/Nomadcxx/sysc-walls/internal/sysc-go-fork/animations"
+	"github.com/Nomadcxx/sysc-walls/internal/theme"
 )
 
 // CreateOptimizedAnimation creates an optimized animation using sysc-Go library directly
@@ -14,71 +15,53 @@ func CreateOptimizedAnimation(effect string, width, height int, theme string) (A
 }
 
 // CreateOptimizedAnimationWithText creates an optimized animation with custom text for text-based effects
-func CreateOptimizedAnimationWithText(effect string, width, height int, theme string, text string) (Animation, error) {
-	palette := getThemePalette(theme)
+func CreateOptimizedAnimationWithText(effect string, width, height int, themeName string, text string) (Animation, error) {
+	th, err := theme.Find(themeName)
+	if err != nil {
+		return nil, err
+	}
+	return newOptimizedAnimation(effect, width, height, th, text, Fit{}, nil, 0)
+}
 
+// newOptimizedAnimation creates an effect's wrapper, text effects fitting
+// their art to the screen. params have been validated. A seed of 0 leaves
+// the effect randomly seeded.
+func newOptimizedAnimation(effect string, width, height int, th *theme.Theme, text string, fit Fit, params Params, seed int64) (Animation, error) {
 	// Default text if empty
 	if text == "" {
 		text = "SYSC-WALLS"
 	}
+	art := artFit{fit: fit, art: text, width: width, height: height}
 
-	switch effect {
-	case "matrix":
-		return newOptimizedMatrix(width, height, palette)
-	case "matrix-art":
-		return newOptimizedMatrixArt(width, height, palette, text)
-	case "fire":
-		return newOptimizedFire(width, height, palette)
-	case "fire-text":
-		return newOptimizedFireText(width, height, palette, text)
-	case "fireworks":
-		return newOptimizedFireworks(width, height, palette)
-	case "rain":
-		return newOptimizedRain(width, height, palette)
-	case "rain-art":
-		return newOptimizedRainArt(width, height, palette, text)
-	case "beams":
-		return newOptimizedBeams(width, height, palette)
-	case "beam-text":
-		return newOptimizedBeamText(width, height, palette, text)
-	case "decrypt":
-		return newOptimizedDecrypt(width, height, palette)
-	case "pour":
-		return newOptimizedPour(width, height, palette)
-	case "aquarium":
-		return newOptimizedAquarium(width, height, palette)
-	case "print":
-		return newOptimizedPrint(width, height, palette)
-	case "blackhole":
-		return newOptimizedBlackhole(width, height, palette, text)
-	case "ring-text":
-		return newOptimizedRingText(width, height, palette, text)
-	default:
+	e, ok := Lookup(effect)
+	if !ok {
 		return nil, fmt.Errorf("unknown animation effect: %s", effect)
 	}
+	return e.new(width, height, th, art, params, seed)
 }
 
-// getThemePalette returns color palette for theme
-func getThemePalette(theme string) []string {
-	palettes := map[string][]string{
-		"dracula":        {"#282a36", "#44475a", "#f8f8f2", "#6272a4", "#8be9fd", "#50fa7b", "#ffb86c", "#ff79c6", "#bd93f9", "#ff5555", "#f1fa8c"},
-		"gruvbox":        {"#282828", "#cc241d", "#98971a", "#d79921", "#458588", "#b16286", "#689d6a", "#a89984", "#928374", "#fb4934", "#b8bb26", "#fabd2f", "#83a598", "#d3869b", "#8ec07c", "#ebdbb2"},
-		"nord":           {"#2e3440", "#3b4252", "#434c5e", "#4c566a", "#d8dee9", "#e5e9f0", "#eceff4", "#8fbcbb", "#88c0d0", "#81a1c1", "#5e81ac", "#bf616a", "#d08770", "#ebcb8b", "#a3be8c", "#b48ead"},
-		"tokyo-night":    {"#1a1b26", "#24283b", "#414868", "#565f89", "#787c99", "#a9b1d6", "#c0caf5", "#7aa2f7", "#bb9af7", "#7dcfff", "#73daca", "#9ece6a", "#e0af68", "#f7768e", "#ff9e64", "#db4b4b"},
-		"catppuccin":     {"#1e1e2e", "#181825", "#313244", "#45475a", "#585b70", "#cdd6f4", "#f5e0dc", "#f2cdcd", "#f5c2e7", "#cba6f7", "#f38ba8", "#eba0ac", "#fab387", "#f9e2af", "#a6e3a1", "#94e2d5", "#89dceb", "#74c7ec", "#89b4fa", "#b4befe"},
-		"material":       {"#263238", "#2e3c43", "#314549", "#37474f", "#607d8b", "#546e7a", "#b0bec5", "#80cbc4", "#4dd0e1", "#4fc3f7", "#29b6f6", "#039be5", "#0288d1", "#0277bd", "#01579b"},
-		"solarized":      {"#002b36", "#073642", "#586e75", "#657b83", "#839496", "#93a1a1", "#eee8d5", "#fdf6e3", "#b58900", "#cb4b16", "#dc322f", "#d33682", "#6c71c4", "#268bd2", "#2aa198", "#859900"},
-		"monochrome":      {"#000000", "#1a1a1a", "#333333", "#4d4d4d", "#666666", "#808080", "#999999", "#b3b3b3", "#cccccc", "#e6e6e6", "#ffffff"},
-		"trainsishardjob": {"#000000", "#ff00ff", "#00ffff", "#ff0000", "#00ff00", "#0000ff", "#ffff00", "#ffffff"},
-		"rama":            {"#2b2d42", "#8d99ae", "#d90429", "#ef233c", "#edf2f4", "#ef233c", "#d90429", "#8d99ae", "#edf2f4"},
-		"eldritch":       {"#212337", "#292e42", "#7081d0", "#04d1f9", "#37f499", "#f16c75", "#a48cf2", "#f265b5", "#f7c67f", "#ebfafa"},
-		"dark":           {"#000000", "#1a1a1a", "#333333", "#4d4d4d", "#666666", "#808080", "#999999", "#b3b3b3", "#cccccc", "#e6e6e6", "#ffffff"},
-	}
+// CreateOptimizedAnimationWithSeed creates an optimized animation whose
+// random choices are the same on every run with the same seed. A seed of 0
+// leaves the effect randomly seeded.
+func CreateOptimizedAnimationWithSeed(effect string, width, height int, theme string, text string, seed int64) (Animation, error) {
+	return CreateOptimizedAnimationWithOptions(effect, width, height, theme, Options{Text: text, Seed: seed})
+}
 
-	if palette, ok := palettes[theme]; ok {
-		return palette
+// CreateOptimizedAnimationWithOptions creates an optimized animation with
+// text, a seed, an art fit, colors other than the named theme's and effect
+// parameters
+func CreateOptimizedAnimationWithOptions(effect string, width, height int, themeName string, opts Options) (Animation, error) {
+	if err := opts.Params.Validate(effect); err != nil {
+		return nil, err
 	}
-	return palettes["rama"] // Default to rama
+	th := opts.Theme
+	if th == nil {
+		var err error
+		if th, err = theme.Find(themeName); err != nil {
+			return nil, err
+		}
+	}
+	return newOptimizedAnimation(effect, width, height, th, opts.Text, opts.Fit, opts.Params, opts.Seed)
 }
 
 // Helper function
@@ -89,14 +72,20 @@ func minInt(a, b int) int {
 	return b
 }
 
-// Matrix - uses simple constructor
+// Matrix - uses config struct
 type optimizedMatrix struct {
 	effect *syscGo.MatrixEffect
 }
 
-func newOptimizedMatrix(width, height int, palette []string) (*optimizedMatrix, error) {
+func newOptimizedMatrix(width, height int, palette []string, params Params, seed int64) (*optimizedMatrix, error) {
 	return &optimizedMatrix{
-		effect: syscGo.NewMatrixEffect(width, height, palette),
+		effect: syscGo.NewMatrixEffectWithConfig(syscGo.MatrixConfig{
+			Width:   width,
+			Height:  height,
+			Palette: palette,
+			Chars:   params.chars("chars"),
+			Seed:    seed,
+		}),
 	}, nil
 }
 
@@ -112,14 +101,20 @@ func (m *optimizedMatrix) Resize(width, height int) {
 	m.effect.Resize(width, height)
 }
 
-// Fire - uses simple constructor
+// Fire - uses config struct
 type optimizedFire struct {
 	effect *syscGo.FireEffect
 }
 
-func newOptimizedFire(width, height int, palette []string) (*optimizedFire, error) {
+func newOptimizedFire(width, height int, palette []string, params Params, seed int64) (*optimizedFire, error) {
 	return &optimizedFire{
-		effect: syscGo.NewFireEffect(width, height, palette),
+		effect: syscGo.NewFireEffectWithConfig(syscGo.FireConfig{
+			Width:   width,
+			Height:  height,
+			Palette: palette,
+			Chars:   fireChars(params.chars("chars")),
+			Seed:    seed,
+		}),
 	}, nil
 }
 
@@ -135,14 +130,32 @@ func (f *optimizedFire) Resize(width, height int) {
 	f.effect.Resize(width, height)
 }
 
+// fireChars puts the blank of cold cells before the flames' characters,
+// leaving nil for sysc-Go's
+func fireChars(chars []rune) []rune {
+	if chars == nil {
+		return nil
+	}
+	return append([]rune{' '}, chars...)
+}
+
 // FireText - fire effect with text as negative space
 type optimizedFireText struct {
+	artFit
 	effect *syscGo.FireTextEffect
 }
 
-func newOptimizedFireText(width, height int, palette []string, text string) (*optimizedFireText, error) {
+func newOptimizedFireText(width, height int, palette []string, art artFit, params Params, seed int64) (*optimizedFireText, error) {
 	return &optimizedFireText{
-		effect: syscGo.NewFireTextEffect(width, height, palette, text),
+		artFit: art,
+		effect: syscGo.NewFireTextEffectWithConfig(syscGo.FireTextConfig{
+			Width:   width,
+			Height:  height,
+			Palette: palette,
+			Text:    art.fitted(),
+			Chars:   fireChars(params.chars("chars")),
+			Seed:    seed,
+		}),
 	}, nil
 }
 
@@ -155,17 +168,28 @@ func (f *optimizedFireText) Render() string {
 }
 
 func (f *optimizedFireText) Resize(width, height int) {
+	f.effect.SetText(f.resize(width, height))
 	f.effect.Resize(width, height)
 }
 
-// Fireworks - uses simple constructor
+// SetText changes the text burned out of the fire without relighting it
+func (f *optimizedFireText) SetText(text string) {
+	f.effect.SetText(f.setArt(text))
+}
+
+// Fireworks - uses config struct
 type optimizedFireworks struct {
 	effect *syscGo.FireworksEffect
 }
 
-func newOptimizedFireworks(width, height int, palette []string) (*optimizedFireworks, error) {
+func newOptimizedFireworks(width, height int, palette []string, seed int64) (*optimizedFireworks, error) {
 	return &optimizedFireworks{
-		effect: syscGo.NewFireworksEffect(width, height, palette),
+		effect: syscGo.NewFireworksEffectWithConfig(syscGo.FireworksConfig{
+			Width:   width,
+			Height:  height,
+			Palette: palette,
+			Seed:    seed,
+		}),
 	}, nil
 }
 
@@ -181,17 +205,29 @@ func (f *optimizedFireworks) Resize(width, height int) {
 	f.effect.Resize(width, height)
 }
 
-// Rain - uses simple constructor
+// Rain - uses config struct
 type optimizedRain struct {
 	effect *syscGo.RainEffect
 }
 
-func newOptimizedRain(width, height int, palette []string) (*optimizedRain, error) {
+func newOptimizedRain(width, height int, palette []string, params Params, seed int64) (*optimizedRain, error) {
 	return &optimizedRain{
-		effect: syscGo.NewRainEffect(width, height, palette),
+		effect: syscGo.NewRainEffectWithConfig(rainConfig(width, height, palette, params, seed)),
 	}, nil
 }
 
+// rainConfig sets the drops' characters and how many fall at once
+func rainConfig(width, height int, palette []string, params Params, seed int64) syscGo.RainConfig {
+	return syscGo.RainConfig{
+		Width:   width,
+		Height:  height,
+		Palette: palette,
+		Chars:   params.chars("chars"),
+		Density: params.number("density", 0),
+		Seed:    seed,
+	}
+}
+
 func (r *optimizedRain) Update(frame int) {
 	r.effect.Update()
 }
@@ -206,22 +242,35 @@ func (r *optimizedRain) Resize(width, height int) {
 
 // Beams - uses config struct
 type optimizedBeams struct {
-	effect  *syscGo.BeamsEffect
-	palette []string
+	effect *syscGo.BeamsEffect
+	theme  *theme.Theme
+	params Params
+	seed   int64 // Nonzero for a repeatable run
 }
 
-func newOptimizedBeams(width, height int, palette []string) (*optimizedBeams, error) {
-	config := syscGo.BeamsConfig{
-		Width:             width,
-		Height:            height,
-		BeamGradientStops: palette[:minInt(len(palette), 5)],
-	}
+func newOptimizedBeams(width, height int, th *theme.Theme, params Params, seed int64) (*optimizedBeams, error) {
 	return &optimizedBeams{
-		effect:  syscGo.NewBeamsEffect(config),
-		palette: palette,
+		effect: syscGo.NewBeamsEffect(beamsConfig(width, height, th, params, seed)),
+		theme:  th,
+		params: params,
+		seed:   seed,
 	}, nil
 }
 
+// beamsConfig sets the beams' colors, characters and wipe
+func beamsConfig(width, height int, th *theme.Theme, params Params, seed int64) syscGo.BeamsConfig {
+	return syscGo.BeamsConfig{
+		Width:              width,
+		Height:             height,
+		BeamRowSymbols:     params.chars("row_chars"),
+		BeamColumnSymbols:  params.chars("column_chars"),
+		BeamGradientStops:  params.colors("colors", th.Stops(5)),
+		FinalGradientStops: params.colors("final_colors", nil),
+		FinalWipeSpeed:     params.integer("wipe_speed", 0),
+		Seed:               seed,
+	}
+}
+
 func (b *optimizedBeams) Update(frame int) {
 	b.effect.Update()
 }
@@ -231,40 +280,52 @@ func (b *optimizedBeams) Render() string {
 }
 
 func (b *optimizedBeams) Resize(width, height int) {
-	config := syscGo.BeamsConfig{
-		Width:             width,
-		Height:            height,
-		BeamGradientStops: b.palette[:minInt(len(b.palette), 5)],
-	}
-	b.effect = syscGo.NewBeamsEffect(config)
+	b.effect = syscGo.NewBeamsEffect(beamsConfig(width, height, b.theme, b.params, b.seed))
 }
 
 // BeamText - uses config struct with auto-sizing and centering wrapper
 type optimizedBeamText struct {
-	effect       *syscGo.BeamTextEffect
-	palette      []string
-	text         string
-	termWidth    int
-	termHeight   int
-}
-
-func newOptimizedBeamText(width, height int, palette []string, text string) (*optimizedBeamText, error) {
-	config := syscGo.BeamTextConfig{
-		Width:             width,  // Full terminal width
-		Height:            height, // Full terminal height
-		Text:              text,
-		Auto:              false, // Fullscreen mode with internal centering
-		BeamGradientStops: palette[:minInt(len(palette), 5)],
-	}
+	artFit
+	effect     *syscGo.BeamTextEffect
+	theme      *theme.Theme
+	params     Params
+	text       string // The art fitted to the screen
+	termWidth  int
+	termHeight int
+	seed       int64 // Nonzero for a repeatable run
+}
+
+func newOptimizedBeamText(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (*optimizedBeamText, error) {
+	text := art.fitted()
 	return &optimizedBeamText{
-		effect:     syscGo.NewBeamTextEffect(config),
-		palette:    palette,
+		artFit:     art,
+		effect:     syscGo.NewBeamTextEffect(beamTextConfig(width, height, text, th, params, seed)),
+		theme:      th,
+		params:     params,
 		text:       text,
 		termWidth:  width,
 		termHeight: height,
+		seed:       seed,
 	}, nil
 }
 
+// beamTextConfig sets the text's beams like beamsConfig
+func beamTextConfig(width, height int, text string, th *theme.Theme, params Params, seed int64) syscGo.BeamTextConfig {
+	beams := beamsConfig(width, height, th, params, seed)
+	return syscGo.BeamTextConfig{
+		Width:              width,  // Full terminal width
+		Height:             height, // Full terminal height
+		Text:               text,
+		Auto:               false, // Fullscreen mode with internal centering
+		BeamRowSymbols:     beams.BeamRowSymbols,
+		BeamColumnSymbols:  beams.BeamColumnSymbols,
+		BeamGradientStops:  beams.BeamGradientStops,
+		FinalGradientStops: beams.FinalGradientStops,
+		FinalWipeSpeed:     beams.FinalWipeSpeed,
+		Seed:               seed,
+	}
+}
+
 func (b *optimizedBeamText) Update(frame int) {
 	b.effect.Update()
 }
@@ -277,34 +338,49 @@ func (b *optimizedBeamText) Render() string {
 func (b *optimizedBeamText) Resize(width, height int) {
 	b.termWidth = width
 	b.termHeight = height
-	config := syscGo.BeamTextConfig{
-		Width:             width,
-		Height:            height,
-		Text:              b.text,
-		Auto:              false, // Fullscreen mode
-		BeamGradientStops: b.palette[:minInt(len(b.palette), 5)],
-	}
-	b.effect = syscGo.NewBeamTextEffect(config)
+	b.text = b.resize(width, height)
+	b.effect = syscGo.NewBeamTextEffect(beamTextConfig(width, height, b.text, b.theme, b.params, b.seed))
+}
+
+// SetText swaps the text without restarting the beams
+func (b *optimizedBeamText) SetText(text string) {
+	b.text = b.setArt(text)
+	b.effect.SetText(b.text)
 }
 
 // Decrypt - uses config struct
 type optimizedDecrypt struct {
+	artFit
 	effect  *syscGo.DecryptEffect
 	palette []string
+	params  Params
+	seed    int64 // Nonzero for a repeatable run
 }
 
-func newOptimizedDecrypt(width, height int, palette []string) (*optimizedDecrypt, error) {
-	config := syscGo.DecryptConfig{
-		Width:   width,
-		Height:  height,
-		Palette: palette,
-	}
+func newOptimizedDecrypt(width, height int, palette []string, art artFit, params Params, seed int64) (*optimizedDecrypt, error) {
 	return &optimizedDecrypt{
-		effect:  syscGo.NewDecryptEffect(config),
+		artFit:  art,
+		effect:  syscGo.NewDecryptEffect(decryptConfig(width, height, art.fitted(), palette, params, seed)),
 		palette: palette,
+		params:  params,
+		seed:    seed,
 	}, nil
 }
 
+// decryptConfig sets the text, colors and typing speed of the decryption
+func decryptConfig(width, height int, text string, palette []string, params Params, seed int64) syscGo.DecryptConfig {
+	return syscGo.DecryptConfig{
+		Width:              width,
+		Height:             height,
+		Text:               text,
+		Palette:            palette,
+		TypingSpeed:        params.integer("typing_speed", 2),
+		CiphertextColors:   params.colors("cipher_colors", palette),
+		FinalGradientStops: params.colors("final_colors", nil),
+		Seed:               seed,
+	}
+}
+
 func (d *optimizedDecrypt) Update(frame int) {
 	d.effect.Update()
 }
@@ -314,31 +390,47 @@ func (d *optimizedDecrypt) Render() string {
 }
 
 func (d *optimizedDecrypt) Resize(width, height int) {
-	config := syscGo.DecryptConfig{
-		Width:   width,
-		Height:  height,
-		Palette: d.palette,
-	}
-	d.effect = syscGo.NewDecryptEffect(config)
+	d.effect = syscGo.NewDecryptEffect(decryptConfig(width, height, d.resize(width, height), d.palette, d.params, d.seed))
 }
 
-// Pour - uses config struct
+// SetText decrypts the new text from the start
+func (d *optimizedDecrypt) SetText(text string) {
+	d.effect = syscGo.NewDecryptEffect(decryptConfig(d.width, d.height, d.setArt(text), d.palette, d.params, d.seed))
+}
+
+// Pour - uses config struct. It pours in a fixed order, so it needs no seed
 type optimizedPour struct {
-	effect  *syscGo.PourEffect
-	palette []string
+	artFit
+	effect *syscGo.PourEffect
+	theme  *theme.Theme
+	params Params
 }
 
-func newOptimizedPour(width, height int, palette []string) (*optimizedPour, error) {
-	config := syscGo.PourConfig{
-		Width:  width,
-		Height: height,
-	}
+func newOptimizedPour(width, height int, th *theme.Theme, art artFit, params Params) (*optimizedPour, error) {
 	return &optimizedPour{
-		effect:  syscGo.NewPourEffect(config),
-		palette: palette,
+		artFit: art,
+		effect: syscGo.NewPourEffect(pourConfig(width, height, art.fitted(), th, params)),
+		theme:  th,
+		params: params,
 	}, nil
 }
 
+// pourConfig pours the text from the theme's background into its gradient
+func pourConfig(width, height int, text string, th *theme.Theme, params Params) syscGo.PourConfig {
+	return syscGo.PourConfig{
+		Width:              width,
+		Height:             height,
+		Text:               text,
+		PourDirection:      params.value("direction", "down"),
+		PourSpeed:          params.integer("pour_speed", 3),
+		MovementSpeed:      params.number("movement_speed", 0.2),
+		EasingFunction:     params.value("easing", ""),
+		Gap:                params.integer("gap", 1),
+		StartingColor:      params.color("start_color", th.Color(theme.RoleBackground)),
+		FinalGradientStops: params.colors("colors", th.Stops(3)),
+	}
+}
+
 func (p *optimizedPour) Update(frame int) {
 	p.effect.Update()
 }
@@ -348,47 +440,47 @@ func (p *optimizedPour) Render() string {
 }
 
 func (p *optimizedPour) Resize(width, height int) {
-	config := syscGo.PourConfig{
-		Width:  width,
-		Height: height,
-	}
-	p.effect = syscGo.NewPourEffect(config)
+	p.effect = syscGo.NewPourEffect(pourConfig(width, height, p.resize(width, height), p.theme, p.params))
+}
+
+// SetText pours the new text from the start
+func (p *optimizedPour) SetText(text string) {
+	p.effect = syscGo.NewPourEffect(pourConfig(p.width, p.height, p.setArt(text), p.theme, p.params))
 }
 
 // Aquarium - uses config struct
 type optimizedAquarium struct {
-	effect  *syscGo.AquariumEffect
-	palette []string
+	effect *syscGo.AquariumEffect
+	theme  *theme.Theme
+	params Params
+	seed   int64 // Nonzero for a repeatable run
 }
 
-func newOptimizedAquarium(width, height int, palette []string) (*optimizedAquarium, error) {
-	// Split palette into appropriate color groups
-	fishColors := palette[:minInt(len(palette), 3)]
-	waterColors := []string{"#2e3440", "#3b4252", "#434c5e"}
-	if len(palette) > 3 {
-		waterColors = palette[3:minInt(len(palette), 6)]
-	}
-	seaweedColors := []string{"#a3be8c", "#8fbcbb"}
-	if len(palette) > 6 {
-		seaweedColors = palette[6:minInt(len(palette), 8)]
-	}
+func newOptimizedAquarium(width, height int, th *theme.Theme, params Params, seed int64) (*optimizedAquarium, error) {
+	return &optimizedAquarium{
+		effect: syscGo.NewAquariumEffect(aquariumConfig(width, height, th, params, seed)),
+		theme:  th,
+		params: params,
+		seed:   seed,
+	}, nil
+}
 
-	config := syscGo.AquariumConfig{
+// aquariumConfig draws each part of the aquarium in the color of its role,
+// unless its parameter sets another
+func aquariumConfig(width, height int, th *theme.Theme, params Params, seed int64) syscGo.AquariumConfig {
+	return syscGo.AquariumConfig{
 		Width:         width,
 		Height:        height,
-		FishColors:    fishColors,
-		WaterColors:   waterColors,
-		SeaweedColors: seaweedColors,
-		BubbleColor:   "#88c0d0",
-		DiverColor:    "#d08770",
-		BoatColor:     "#bf616a",
-		MermaidColor:  "#b48ead",
-		AnchorColor:   "#5e81ac",
+		FishColors:    params.colors("fish", th.Role(theme.RoleFish)),
+		WaterColors:   params.colors("water", th.Role(theme.RoleWater)),
+		SeaweedColors: params.colors("seaweed", th.Role(theme.RoleSeaweed)),
+		BubbleColor:   params.color("bubble", th.Color(theme.RoleBubble)),
+		DiverColor:    params.color("diver", th.Color(theme.RoleDiver)),
+		BoatColor:     params.color("boat", th.Color(theme.RoleBoat)),
+		MermaidColor:  params.color("mermaid", th.Color(theme.RoleMermaid)),
+		AnchorColor:   params.color("anchor", th.Color(theme.RoleAnchor)),
+		Seed:          seed,
 	}
-	return &optimizedAquarium{
-		effect:  syscGo.NewAquariumEffect(config),
-		palette: palette,
-	}, nil
 }
 
 func (a *optimizedAquarium) Update(frame int) {
@@ -401,48 +493,40 @@ func (a *optimizedAquarium) Render() string {
 
 func (a *optimizedAquarium) Resize(width, height int) {
 	// Aquarium resize needs full reconfiguration
-	fishColors := a.palette[:minInt(len(a.palette), 3)]
-	waterColors := []string{"#2e3440", "#3b4252", "#434c5e"}
-	if len(a.palette) > 3 {
-		waterColors = a.palette[3:minInt(len(a.palette), 6)]
-	}
-	seaweedColors := []string{"#a3be8c", "#8fbcbb"}
-	if len(a.palette) > 6 {
-		seaweedColors = a.palette[6:minInt(len(a.palette), 8)]
-	}
-
-	config := syscGo.AquariumConfig{
-		Width:         width,
-		Height:        height,
-		FishColors:    fishColors,
-		WaterColors:   waterColors,
-		SeaweedColors: seaweedColors,
-		BubbleColor:   "#88c0d0",
-		DiverColor:    "#d08770",
-		BoatColor:     "#bf616a",
-		MermaidColor:  "#b48ead",
-		AnchorColor:   "#5e81ac",
-	}
-	a.effect = syscGo.NewAquariumEffect(config)
+	a.effect = syscGo.NewAquariumEffect(aquariumConfig(width, height, a.theme, a.params, a.seed))
 }
 
-// Print - uses config struct
+// Print - uses config struct. It prints in a fixed order, so it needs no seed
 type optimizedPrint struct {
-	effect  *syscGo.PrintEffect
-	palette []string
+	artFit
+	effect *syscGo.PrintEffect
+	theme  *theme.Theme
+	params Params
 }
 
-func newOptimizedPrint(width, height int, palette []string) (*optimizedPrint, error) {
-	config := syscGo.PrintConfig{
-		Width:  width,
-		Height: height,
-	}
+func newOptimizedPrint(width, height int, th *theme.Theme, art artFit, params Params) (*optimizedPrint, error) {
 	return &optimizedPrint{
-		effect:  syscGo.NewPrintEffect(config),
-		palette: palette,
+		artFit: art,
+		effect: syscGo.NewPrintEffect(printConfig(width, height, art.fitted(), th, params)),
+		theme:  th,
+		params: params,
 	}, nil
 }
 
+// printConfig prints the text in the theme's gradient
+func printConfig(width, height int, text string, th *theme.Theme, params Params) syscGo.PrintConfig {
+	return syscGo.PrintConfig{
+		Width:           width,
+		Height:          height,
+		Text:            text,
+		FramesPerChar:   params.integer("frames_per_char", 0),
+		PrintSpeed:      params.integer("print_speed", 0),
+		PrintHeadSymbol: params.value("head", ""),
+		TrailSymbols:    params.symbols("trail"),
+		GradientStops:   params.colors("colors", th.Stops(3)),
+	}
+}
+
 func (p *optimizedPrint) Update(frame int) {
 	p.effect.Update()
 }
@@ -452,28 +536,49 @@ func (p *optimizedPrint) Render() string {
 }
 
 func (p *optimizedPrint) Resize(width, height int) {
-	config := syscGo.PrintConfig{
-		Width:  width,
-		Height: height,
-	}
-	p.effect = syscGo.NewPrintEffect(config)
+	p.effect = syscGo.NewPrintEffect(printConfig(width, height, p.resize(width, height), p.theme, p.params))
+}
+
+// SetText prints the new text from the start
+func (p *optimizedPrint) SetText(text string) {
+	p.effect = syscGo.NewPrintEffect(printConfig(p.width, p.height, p.setArt(text), p.theme, p.params))
 }
 
 // MatrixArt - Matrix rain that crystallizes into ASCII art
 type optimizedMatrixArt struct {
+	artFit
 	effect  *syscGo.MatrixArtEffect
 	palette []string
-	text    string
+	params  Params
+	text    string // The art fitted to the screen
+	seed    int64  // Nonzero for a repeatable run
 }
 
-func newOptimizedMatrixArt(width, height int, palette []string, text string) (*optimizedMatrixArt, error) {
+func newOptimizedMatrixArt(width, height int, palette []string, art artFit, params Params, seed int64) (*optimizedMatrixArt, error) {
+	text := art.fitted()
 	return &optimizedMatrixArt{
-		effect:  syscGo.NewMatrixArtEffect(width, height, palette, text),
+		artFit:  art,
+		effect:  syscGo.NewMatrixArtEffectWithConfig(matrixArtConfig(width, height, palette, text, params, seed)),
 		palette: palette,
+		params:  params,
 		text:    text,
+		seed:    seed,
 	}, nil
 }
 
+// matrixArtConfig crystallizes the rain into the art
+func matrixArtConfig(width, height int, palette []string, text string, params Params, seed int64) syscGo.MatrixArtConfig {
+	return syscGo.MatrixArtConfig{
+		Width:   width,
+		Height:  height,
+		Palette: palette,
+		Text:    text,
+		Chars:   params.chars("chars"),
+		Freeze:  params.number("freeze", 0),
+		Seed:    seed,
+	}
+}
+
 func (m *optimizedMatrixArt) Update(frame int) {
 	m.effect.Update()
 }
@@ -483,24 +588,52 @@ func (m *optimizedMatrixArt) Render() string {
 }
 
 func (m *optimizedMatrixArt) Resize(width, height int) {
-	m.effect = syscGo.NewMatrixArtEffect(width, height, m.palette, m.text)
+	m.text = m.resize(width, height)
+	m.effect = syscGo.NewMatrixArtEffectWithConfig(matrixArtConfig(width, height, m.palette, m.text, m.params, m.seed))
+}
+
+// SetText changes the art the rain crystallizes into
+func (m *optimizedMatrixArt) SetText(text string) {
+	m.text = m.setArt(text)
+	m.effect.SetText(m.text)
 }
 
 // RainArt - Rain drops that freeze to form ASCII art
 type optimizedRainArt struct {
+	artFit
 	effect  *syscGo.RainArtEffect
 	palette []string
-	text    string
+	params  Params
+	text    string // The art fitted to the screen
+	seed    int64  // Nonzero for a repeatable run
 }
 
-func newOptimizedRainArt(width, height int, palette []string, text string) (*optimizedRainArt, error) {
+func newOptimizedRainArt(width, height int, palette []string, art artFit, params Params, seed int64) (*optimizedRainArt, error) {
+	text := art.fitted()
 	return &optimizedRainArt{
-		effect:  syscGo.NewRainArtEffect(width, height, palette, text),
+		artFit:  art,
+		effect:  syscGo.NewRainArtEffectWithConfig(rainArtConfig(width, height, palette, text, params, seed)),
 		palette: palette,
+		params:  params,
 		text:    text,
+		seed:    seed,
 	}, nil
 }
 
+// rainArtConfig freezes the drops into the art
+func rainArtConfig(width, height int, palette []string, text string, params Params, seed int64) syscGo.RainArtConfig {
+	return syscGo.RainArtConfig{
+		Width:   width,
+		Height:  height,
+		Palette: palette,
+		Text:    text,
+		Chars:   params.chars("chars"),
+		Density: params.number("density", 0),
+		Freeze:  params.number("freeze", 0),
+		Seed:    seed,
+	}
+}
+
 func (r *optimizedRainArt) Update(frame int) {
 	r.effect.Update()
 }
@@ -510,32 +643,53 @@ func (r *optimizedRainArt) Render() string {
 }
 
 func (r *optimizedRainArt) Resize(width, height int) {
-	r.effect = syscGo.NewRainArtEffect(width, height, r.palette, r.text)
+	r.text = r.resize(width, height)
+	r.effect = syscGo.NewRainArtEffectWithConfig(rainArtConfig(width, height, r.palette, r.text, r.params, r.seed))
+}
+
+// SetText changes the art the drops freeze into
+func (r *optimizedRainArt) SetText(text string) {
+	r.text = r.setArt(text)
+	r.effect.SetText(r.text)
 }
 
 // Blackhole - Text gets consumed by a blackhole and explodes
 type optimizedBlackhole struct {
-	effect  *syscGo.BlackholeEffect
-	palette []string
-	text    string
+	artFit
+	effect *syscGo.BlackholeEffect
+	theme  *theme.Theme
+	params Params
+	text   string // The art fitted to the screen
+	seed   int64  // Nonzero for a repeatable run
 }
 
-func newOptimizedBlackhole(width, height int, palette []string, text string) (*optimizedBlackhole, error) {
-	config := syscGo.BlackholeConfig{
+func newOptimizedBlackhole(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (*optimizedBlackhole, error) {
+	text := art.fitted()
+	return &optimizedBlackhole{
+		artFit: art,
+		effect: syscGo.NewBlackholeEffect(blackholeConfig(width, height, text, th, params, seed)),
+		theme:  th,
+		params: params,
+		text:   text,
+		seed:   seed,
+	}, nil
+}
+
+// blackholeConfig draws the blackhole in the theme's foreground and the
+// stars and text in its palette
+func blackholeConfig(width, height int, text string, th *theme.Theme, params Params, seed int64) syscGo.BlackholeConfig {
+	return syscGo.BlackholeConfig{
 		Width:               width,
 		Height:              height,
 		Text:                text,
-		BlackholeColor:      "#ffffff",
-		StarColors:          palette[:minInt(len(palette), 6)],
-		FinalGradientStops:  palette[:minInt(len(palette), 3)],
-		StaticGradientStops: palette[:minInt(len(palette), 6)],
+		BlackholeColor:      params.color("core", th.Color(theme.RoleForeground)),
+		StarColors:          params.colors("stars", th.Palette[:minInt(len(th.Palette), 6)]),
+		FinalGradientStops:  params.colors("colors", th.Stops(3)),
+		StaticGradientStops: params.colors("colors", th.Stops(6)),
 		StaticGradientDir:   syscGo.GradientHorizontal,
+		StaticFrames:        params.integer("hold", 0),
+		Seed:                seed,
 	}
-	return &optimizedBlackhole{
-		effect:  syscGo.NewBlackholeEffect(config),
-		palette: palette,
-		text:    text,
-	}, nil
 }
 
 func (b *optimizedBlackhole) Update(frame int) {
@@ -547,41 +701,54 @@ func (b *optimizedBlackhole) Render() string {
 }
 
 func (b *optimizedBlackhole) Resize(width, height int) {
-	config := syscGo.BlackholeConfig{
-		Width:               width,
-		Height:              height,
-		Text:                b.text,
-		BlackholeColor:      "#ffffff",
-		StarColors:          b.palette[:minInt(len(b.palette), 6)],
-		FinalGradientStops:  b.palette[:minInt(len(b.palette), 3)],
-		StaticGradientStops: b.palette[:minInt(len(b.palette), 6)],
-		StaticGradientDir:   syscGo.GradientHorizontal,
-	}
-	b.effect = syscGo.NewBlackholeEffect(config)
+	b.text = b.resize(width, height)
+	b.effect = syscGo.NewBlackholeEffect(blackholeConfig(width, height, b.text, b.theme, b.params, b.seed))
+}
+
+// SetText changes the text, as soon as it is back in place
+func (b *optimizedBlackhole) SetText(text string) {
+	b.text = b.setArt(text)
+	b.effect.SetText(b.text)
 }
 
 // RingText - Text spins on concentric rings with vortex motion
 type optimizedRingText struct {
-	effect  *syscGo.RingTextEffect
-	palette []string
-	text    string
+	artFit
+	effect *syscGo.RingTextEffect
+	theme  *theme.Theme
+	params Params
+	text   string // The art fitted to the screen
+	seed   int64  // Nonzero for a repeatable run
+}
+
+func newOptimizedRingText(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (*optimizedRingText, error) {
+	text := art.fitted()
+	return &optimizedRingText{
+		artFit: art,
+		effect: syscGo.NewRingTextEffect(ringTextConfig(width, height, text, th, params, seed)),
+		theme:  th,
+		params: params,
+		text:   text,
+		seed:   seed,
+	}, nil
 }
 
-func newOptimizedRingText(width, height int, palette []string, text string) (*optimizedRingText, error) {
-	config := syscGo.RingTextConfig{
+// ringTextConfig draws the rings in the theme's accent and the text in its
+// gradient
+func ringTextConfig(width, height int, text string, th *theme.Theme, params Params, seed int64) syscGo.RingTextConfig {
+	return syscGo.RingTextConfig{
 		Width:               width,
 		Height:              height,
 		Text:                text,
-		RingColors:          palette[:minInt(len(palette), 3)],
-		FinalGradientStops:  palette[:minInt(len(palette), 3)],
-		StaticGradientStops: palette[:minInt(len(palette), 3)],
+		RingColors:          params.colors("rings", th.Role(theme.RoleAccent)),
+		RingGap:             params.number("ring_gap", 0),
+		SpinDisperseCycles:  params.integer("cycles", 0),
+		StaticFrames:        params.integer("hold", 0),
+		FinalGradientStops:  params.colors("colors", th.Stops(3)),
+		StaticGradientStops: params.colors("colors", th.Stops(3)),
 		StaticGradientDir:   syscGo.GradientHorizontal,
+		Seed:                seed,
 	}
-	return &optimizedRingText{
-		effect:  syscGo.NewRingTextEffect(config),
-		palette: palette,
-		text:    text,
-	}, nil
 }
 
 func (r *optimizedRingText) Update(frame int) {
@@ -593,16 +760,14 @@ func (r *optimizedRingText) Render() string {
 }
 
 func (r *optimizedRingText) Resize(width, height int) {
-	config := syscGo.RingTextConfig{
-		Width:               width,
-		Height:              height,
-		Text:                r.text,
-		RingColors:          r.palette[:minInt(len(r.palette), 3)],
-		FinalGradientStops:  r.palette[:minInt(len(r.palette), 3)],
-		StaticGradientStops: r.palette[:minInt(len(r.palette), 3)],
-		StaticGradientDir:   syscGo.GradientHorizontal,
-	}
-	r.effect = syscGo.NewRingTextEffect(config)
+	r.text = r.resize(width, height)
+	r.effect = syscGo.NewRingTextEffect(ringTextConfig(width, height, r.text, r.theme, r.params, r.seed))
+}
+
+// SetText changes the text, as soon as it is back in place
+func (r *optimizedRingText) SetText(text string) {
+	r.text = r.setArt(text)
+	r.effect.SetText(r.text)
 }
 
 // stripAnsiCodes removes ANSI escape codes from a string for width calculation
@@ -627,61 +792,3 @@ func stripAnsiCodes(s string) string {
 
 	return result.String()
 }
-
-// centerOutput centers smaller animation output in a larger terminal
-func centerOutput(output string, termWidth, termHeight int) string {
-	lines := strings.Split(output, "\n")
-	if len(lines) == 0 {
-		return output
-	}
-
-	// Calculate vertical offset to center
-	outputHeight := len(lines)
-	verticalOffset := (termHeight - outputHeight) / 2
-	if verticalOffset < 0 {
-		verticalOffset = 0
-	}
-
-	// Find max line width (stripping ANSI codes AND trailing spaces for accurate width)
-	maxWidth := 0
-	for _, line := range lines {
-		// Strip ANSI codes to get actual visual content
-		visualLine := stripAnsiCodes(line)
-		// Trim trailing spaces to get real content width
-		visualLine = strings.TrimRight(visualLine, " ")
-		visualWidth := len([]rune(visualLine))
-		if visualWidth > maxWidth {
-			maxWidth = visualWidth
-		}
-	}
-
-	// Calculate horizontal offset to center
-	horizontalOffset := (termWidth - maxWidth) / 2
-	if horizontalOffset < 0 {
-		horizontalOffset = 0
-	}
-
-	// Build centered output
-	var result strings.Builder
-
-	// Add top padding
-	for i := 0; i < verticalOffset; i++ {
-		result.WriteString("\n")
-	}
-
-	// Add horizontally centered lines
-	padding := strings.Repeat(" ", horizontalOffset)
-	for _, line := range lines {
-		result.WriteString(padding)
-		result.WriteString(line)
-		result.WriteString("\n")
-	}
-
-	// Add bottom padding to fill terminal
-	remainingLines := termHeight - verticalOffset - outputHeight
-	for i := 0; i < remainingLines; i++ {
-		result.WriteString("\n")
-	}
-
-	return result.String()
-}
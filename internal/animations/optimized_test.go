@@ -2,6 +2,9 @@ package animations
 
 import (
 	"testing"
+	"time"
+
+	syscGo "github.com/Nomadcxx/sysc-walls/internal/sysc-go-fork/animations"
 )
 
 // TestCreateOptimizedAnimation tests animation creation
@@ -79,44 +82,17 @@ func TestCreateOptimizedAnimation(t *testing.T) {
 	}
 }
 
-// TestGetThemePalette tests theme palette lookup
-func TestGetThemePalette(t *testing.T) {
-	tests := []struct {
-		theme       string
-		expectEmpty bool
-	}{
-		{"nord", false},
-		{"dracula", false},
-		{"gruvbox", false},
-		{"tokyo-night", false},
-		{"catppuccin", false},
-		{"material", false},
-		{"solarized", false},
-		{"monochrome", false},
-		{"transishardjob", false},
-		{"invalid-theme", false}, // Should return default (nord)
+// TestThemes tests that every theme sysc-Go lists can be drawn, and that
+// unknown themes are an error rather than another theme's colors
+func TestThemes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, theme := range syscGo.GetThemeNames() {
+		if _, err := CreateOptimizedAnimation("aquarium", 80, 24, theme); err != nil {
+			t.Errorf("theme %q: %v", theme, err)
+		}
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.theme, func(t *testing.T) {
-			palette := getThemePalette(tt.theme)
-
-			if tt.expectEmpty {
-				if len(palette) != 0 {
-					t.Errorf("getThemePalette(%q) expected empty, got %d colors", tt.theme, len(palette))
-				}
-			} else {
-				if len(palette) == 0 {
-					t.Errorf("getThemePalette(%q) returned empty palette", tt.theme)
-				}
-				// All palettes should have valid hex colors
-				for i, color := range palette {
-					if len(color) < 4 || color[0] != '#' {
-						t.Errorf("getThemePalette(%q) color[%d] = %q is invalid", tt.theme, i, color)
-					}
-				}
-			}
-		})
+	if _, err := CreateOptimizedAnimation("fire", 80, 24, "invalid-theme"); err == nil {
+		t.Error("unknown theme created an animation")
 	}
 }
 
@@ -158,3 +134,59 @@ func TestCreateAnimation(t *testing.T) {
 		t.Error("CreateAnimation(invalid) expected error, got nil")
 	}
 }
+
+// countingAnimation records Update calls
+type countingAnimation struct {
+	updates int
+}
+
+func (c *countingAnimation) Update(frame int)         { c.updates++ }
+func (c *countingAnimation) Render() string           { return "" }
+func (c *countingAnimation) Resize(width, height int) {}
+
+// TestTimedAnimation tests that effects step at the same rate at any FPS
+func TestTimedAnimation(t *testing.T) {
+	tests := []struct {
+		fps  int
+		want int
+	}{
+		{1, 20},
+		{2, 20},
+		{3, 20},
+		{10, 20},
+		{20, 20},
+		{60, 20},
+		{120, 20},
+	}
+
+	for _, tt := range tests {
+		counter := &countingAnimation{}
+		anim := NewTimedAnimation(counter, DefaultStep, time.Second/time.Duration(tt.fps))
+
+		// One second of frames; the first Advance always steps
+		anim.Advance(0)
+		var last time.Duration
+		for i := 1; i <= tt.fps; i++ {
+			now := time.Duration(i) * time.Second / time.Duration(tt.fps)
+			anim.Advance(now - last)
+			last = now
+		}
+
+		// One step for the first frame, then one per 50ms
+		if counter.updates != tt.want+1 {
+			t.Errorf("%d FPS: %d updates in one second, want %d", tt.fps, counter.updates, tt.want+1)
+		}
+	}
+
+	// A long stall is capped instead of fast-forwarding, at a frame's
+	// steps and a few more
+	for _, fps := range []int{1, 20} {
+		counter := &countingAnimation{}
+		anim := NewTimedAnimation(counter, DefaultStep, time.Second/time.Duration(fps))
+		anim.Advance(0)
+		anim.Advance(time.Minute)
+		if want := 1 + 20/fps + maxCatchUp; counter.updates != want {
+			t.Errorf("%d FPS stall: %d updates, want %d", fps, counter.updates, want)
+		}
+	}
+}
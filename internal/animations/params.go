@@ -0,0 +1,226 @@
+// params.go - Effect parameters, checked against the schema each effect declares
+package animations
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/theme"
+)
+
+// Kinds of parameter values
+const (
+	KindInt    = "int"    // A whole number from Min to Max
+	KindFloat  = "float"  // A number from Min to Max
+	KindChoice = "choice" // One of Choices
+	KindColor  = "color"  // A #rrggbb color
+	KindColors = "colors" // #rrggbb colors separated by spaces or commas
+	KindChars  = "chars"  // Characters to draw with, written together
+)
+
+// Param declares a parameter an effect takes
+type Param struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`
+	Min     float64  `json:"min,omitempty"`     // Smallest int or float value
+	Max     float64  `json:"max,omitempty"`     // Largest int or float value
+	Choices []string `json:"choices,omitempty"` // Values of a choice
+	Default string   `json:"default,omitempty"` // What the effect does when the parameter is unset
+	Help    string   `json:"help"`
+}
+
+// Params are the values of an effect's parameters by name, as written in
+// an [effect.<name>] section of daemon.conf
+type Params map[string]string
+
+// commonParams are taken by every effect
+var commonParams = []Param{
+	{Name: "speed", Kind: KindFloat, Min: 0.1, Max: 10, Default: "1", Help: "How many times faster than normal the effect runs"},
+}
+
+// Parameters shared by several effects
+var (
+	colorsParam     = Param{Name: "colors", Kind: KindColors, Default: "the theme's palette", Help: "Colors the effect picks from"}
+	gradientParam   = Param{Name: "colors", Kind: KindColors, Default: "the theme's gradient", Help: "Gradient the effect is drawn in"}
+	finalParam      = Param{Name: "final_colors", Kind: KindColors, Default: "sysc-Go's", Help: "Gradient the finished text is drawn in"}
+	rainCharsParam  = Param{Name: "chars", Kind: KindChars, Default: "|⋮║¦┆┊╎╏▏▎▍▌▋▊▉", Help: "Characters of the drops"}
+	fireCharsParam  = Param{Name: "chars", Kind: KindChars, Default: "░░▒▒▓▓█", Help: "Characters from the coolest to the hottest flame; cold cells stay blank"}
+	freezeParam     = Param{Name: "freeze", Kind: KindFloat, Min: 0.01, Max: 1, Help: "Chance a character passing through the art freezes in place"}
+	holdParam       = Param{Name: "hold", Kind: KindInt, Min: 1, Max: 10000, Default: "100", Help: "Updates the finished text stays in place"}
+	beamsRowParam   = Param{Name: "row_chars", Kind: KindChars, Default: "▂▁_", Help: "Characters of beams crossing rows"}
+	beamsColParam   = Param{Name: "column_chars", Kind: KindChars, Default: "▌▍▎▏", Help: "Characters of beams crossing columns"}
+	beamsWipeParam  = Param{Name: "wipe_speed", Kind: KindInt, Min: 1, Max: 100, Default: "3", Help: "Diagonals of the final gradient revealed per update"}
+	matrixCharParam = Param{Name: "chars", Kind: KindChars, Default: "digits, Latin, Greek and Cyrillic letters and blocks", Help: "Characters of the streaks"}
+)
+
+// withDefault returns a copy of a shared parameter with another default
+func withDefault(p Param, def string) Param {
+	p.Default = def
+	return p
+}
+
+// schema lists the parameters of an effect after commonParams
+func schema(params ...Param) []Param {
+	return append(append([]Param(nil), commonParams...), params...)
+}
+
+// Check checks a value against the parameter's kind and range
+func (p Param) Check(value string) error {
+	switch p.Kind {
+	case KindInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a whole number, not %q", p.Name, value)
+		}
+		return p.checkRange(float64(n))
+	case KindFloat:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) {
+			return fmt.Errorf("%s must be a number, not %q", p.Name, value)
+		}
+		return p.checkRange(f)
+	case KindChoice:
+		for _, choice := range p.Choices {
+			if value == choice {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of %s, not %q", p.Name, strings.Join(p.Choices, ", "), value)
+	case KindColor, KindColors:
+		colors, err := parseColors(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", p.Name, err)
+		}
+		if len(colors) == 0 {
+			return fmt.Errorf("%s needs at least one color", p.Name)
+		}
+		if p.Kind == KindColor && len(colors) > 1 {
+			return fmt.Errorf("%s takes one color, not %q", p.Name, value)
+		}
+	case KindChars:
+		if value == "" {
+			return fmt.Errorf("%s needs at least one character", p.Name)
+		}
+	}
+	return nil
+}
+
+// checkRange checks a number is from Min to Max
+func (p Param) checkRange(f float64) error {
+	if f < p.Min || f > p.Max {
+		return fmt.Errorf("%s must be from %g to %g, not %g", p.Name, p.Min, p.Max, f)
+	}
+	return nil
+}
+
+// Validate checks every value against the effect's schema in the registry
+func (p Params) Validate(effect string) error {
+	e, ok := Lookup(effect)
+	if !ok {
+		return fmt.Errorf("unknown animation effect: %s", effect)
+	}
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		param, ok := findParam(e.Params, name)
+		if !ok {
+			known := make([]string, len(e.Params))
+			for i, param := range e.Params {
+				known[i] = param.Name
+			}
+			return fmt.Errorf("%s has no parameter %q (parameters: %s)", effect, name, strings.Join(known, ", "))
+		}
+		if err := param.Check(p[name]); err != nil {
+			return fmt.Errorf("%s: %v", effect, err)
+		}
+	}
+	return nil
+}
+
+// findParam looks up a parameter in a schema by name
+func findParam(schema []Param, name string) (Param, bool) {
+	for _, param := range schema {
+		if param.Name == name {
+			return param, true
+		}
+	}
+	return Param{}, false
+}
+
+// Step returns the update interval that runs the effect at its speed
+func (p Params) Step() time.Duration {
+	return time.Duration(float64(DefaultStep) / p.number("speed", 1))
+}
+
+// The accessors below return def for unset parameters. Values have been
+// validated by the time the wrappers read them.
+
+func (p Params) integer(name string, def int) int {
+	if n, err := strconv.Atoi(p[name]); err == nil {
+		return n
+	}
+	return def
+}
+
+func (p Params) number(name string, def float64) float64 {
+	if f, err := strconv.ParseFloat(p[name], 64); err == nil {
+		return f
+	}
+	return def
+}
+
+func (p Params) value(name, def string) string {
+	if value, ok := p[name]; ok {
+		return value
+	}
+	return def
+}
+
+func (p Params) colors(name string, def []string) []string {
+	if colors, err := parseColors(p[name]); err == nil && len(colors) > 0 {
+		return colors
+	}
+	return def
+}
+
+func (p Params) color(name, def string) string {
+	return p.colors(name, []string{def})[0]
+}
+
+// chars returns nil for an unset parameter, leaving sysc-Go's characters
+func (p Params) chars(name string) []rune {
+	if value := p[name]; value != "" {
+		return []rune(value)
+	}
+	return nil
+}
+
+// symbols returns the characters of a parameter as strings
+func (p Params) symbols(name string) []string {
+	var symbols []string
+	for _, r := range p.chars(name) {
+		symbols = append(symbols, string(r))
+	}
+	return symbols
+}
+
+// parseColors reads #rrggbb colors separated by spaces or commas
+func parseColors(value string) ([]string, error) {
+	var colors []string
+	for _, f := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		c, err := theme.ParseColor(f)
+		if err != nil {
+			return nil, err
+		}
+		colors = append(colors, c)
+	}
+	return colors, nil
+}
@@ -0,0 +1,127 @@
+package animations
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/theme"
+)
+
+// TestValidateParams tests checking parameters against an effect's schema
+func TestValidateParams(t *testing.T) {
+	valid := []struct {
+		effect string
+		params Params
+	}{
+		{"rain", nil},
+		{"rain", Params{"speed": "2.5", "density": "0.5", "chars": "*+", "colors": "#ff0000, 00ff00"}},
+		{"aquarium", Params{"bubble": "#FFFFFF", "fish": "#ff0000 #00ff00"}},
+		{"pour", Params{"direction": "left", "easing": "easeOut", "gap": "0"}},
+	}
+	for _, tt := range valid {
+		if err := tt.params.Validate(tt.effect); err != nil {
+			t.Errorf("Validate(%s, %v) error = %v", tt.effect, tt.params, err)
+		}
+	}
+
+	invalid := []struct {
+		effect string
+		params Params
+		want   string
+	}{
+		{"rain", Params{"freeze": "0.5"}, "no parameter"},
+		{"rain", Params{"speed": "fast"}, "must be a number"},
+		{"rain", Params{"speed": "0"}, "from 0.1 to 10"},
+		{"rain", Params{"chars": ""}, "at least one character"},
+		{"rain", Params{"colors": "red"}, "invalid color"},
+		{"aquarium", Params{"bubble": "#ffffff #000000"}, "one color"},
+		{"print", Params{"print_speed": "1.5"}, "whole number"},
+		{"pour", Params{"direction": "sideways"}, "one of down, up, left, right"},
+		{"invalid", nil, "unknown animation effect"},
+	}
+	for _, tt := range invalid {
+		err := tt.params.Validate(tt.effect)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Validate(%s, %v) error = %v, want %q", tt.effect, tt.params, err, tt.want)
+		}
+	}
+}
+
+// TestSchemas tests that every effect runs with each of its parameters set
+func TestSchemas(t *testing.T) {
+	samples := map[string]string{
+		KindColor:  "#ff0000",
+		KindColors: "#ff0000 #00ff00",
+		KindChars:  "*+",
+	}
+	for _, e := range Effects() {
+		effect := e.Name
+		params := make(Params)
+		for _, p := range e.Params {
+			switch p.Kind {
+			case KindInt, KindFloat:
+				params[p.Name] = strconv.FormatFloat(p.Max, 'g', -1, 64)
+			case KindChoice:
+				params[p.Name] = p.Choices[len(p.Choices)-1]
+			default:
+				params[p.Name] = samples[p.Kind]
+			}
+		}
+		anim, err := CreateAnimationWithOptions(effect, 80, 24, "nord", Options{Params: params})
+		if err != nil {
+			t.Errorf("%s with %v: %v", effect, params, err)
+			continue
+		}
+		for i := 0; i < 5; i++ {
+			anim.Update(i)
+		}
+		anim.Resize(60, 30)
+		anim.Update(5)
+		anim.Render()
+	}
+
+	if _, err := CreateAnimationWithOptions("rain", 80, 24, "nord", Options{Params: Params{"density": "100"}}); err == nil {
+		t.Error("out of range density accepted")
+	}
+}
+
+// TestParamsApplied tests that parameters reach the sysc-Go effects
+func TestParamsApplied(t *testing.T) {
+	anim, err := CreateAnimationWithOptions("rain", 40, 10, "nord", Options{Params: Params{"chars": "@", "density": "0.5"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rain := anim.(*optimizedRain)
+	rain.Resize(80, 10)
+	for i := 0; i < 40; i++ {
+		rain.Update(i)
+	}
+	if frame := stripAnsiCodes(rain.Render()); strings.Trim(frame, "@ \n") != "" {
+		t.Errorf("rain drew characters other than @:\n%s", frame)
+	}
+
+	if got := rainConfig(40, 10, nil, Params{"density": "0.5"}, 0); got.Density != 0.5 || got.Chars != nil {
+		t.Errorf("rain config = %+v", got)
+	}
+
+	th, _ := theme.Find("nord")
+	config := aquariumConfig(80, 24, th, Params{"bubble": "#FF0000"}, 0)
+	if config.BubbleColor != "#ff0000" || config.DiverColor != th.Color(theme.RoleDiver) {
+		t.Errorf("aquarium config = %+v", config)
+	}
+	if got := pourConfig(80, 24, "A", th, nil); got.Text != "A" || got.StartingColor != th.Color(theme.RoleBackground) || len(got.FinalGradientStops) != 3 {
+		t.Errorf("pour config ignores the theme: %+v", got)
+	}
+	if got := printConfig(80, 24, "A", th, Params{"trail": "ab"}); len(got.TrailSymbols) != 2 || got.TrailSymbols[1] != "b" {
+		t.Errorf("print trail = %v", got.TrailSymbols)
+	}
+
+	if got := (Params{"speed": "2"}).Step(); got != DefaultStep/2 {
+		t.Errorf("Step() = %v, want %v", got, DefaultStep/2)
+	}
+	if got := Params(nil).Step(); got != 50*time.Millisecond {
+		t.Errorf("Step() without speed = %v", got)
+	}
+}
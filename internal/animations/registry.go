@@ -0,0 +1,320 @@
+// registry.go - The effects sysc-walls runs, with what each one supports
+package animations
+
+import (
+	"fmt"
+	"strings"
+
+	syscGo "github.com/Nomadcxx/sysc-walls/internal/sysc-go-fork/animations"
+	"github.com/Nomadcxx/sysc-walls/internal/theme"
+)
+
+// Effect records an effect sysc-walls runs and what it supports
+type Effect struct {
+	Name      string  `json:"name"`
+	Text      bool    `json:"text"`     // Draws the art or text it is given
+	Datetime  bool    `json:"datetime"` // The datetime overlay can be drawn over it
+	MinWidth  int     `json:"min_width"`
+	MinHeight int     `json:"min_height"`
+	Params    []Param `json:"params"`
+
+	// new creates the effect's wrapper. params have been validated; a seed
+	// of 0 leaves the effect randomly seeded.
+	new func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error)
+}
+
+// registry lists the effects in sysc-Go's order. Minimum sizes are the
+// smallest screens the sysc-Go effects run on without panicking.
+var registry = []Effect{
+	{
+		Name:      "matrix",
+		Datetime:  true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			colorsParam,
+			matrixCharParam,
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedMatrix(width, height, palette(th, params), params, seed)
+		},
+	},
+	{
+		Name:      "matrix-art",
+		Text:      true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			colorsParam,
+			matrixCharParam,
+			withDefault(freezeParam, "0.99"),
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedMatrixArt(width, height, palette(th, params), art, params, seed)
+		},
+	},
+	{
+		Name:      "fire",
+		Datetime:  true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			colorsParam,
+			fireCharsParam,
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedFire(width, height, palette(th, params), params, seed)
+		},
+	},
+	{
+		Name:      "fire-text",
+		Text:      true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			colorsParam,
+			fireCharsParam,
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedFireText(width, height, palette(th, params), art, params, seed)
+		},
+	},
+	{
+		Name:      "fireworks",
+		Datetime:  true,
+		MinWidth:  21,
+		MinHeight: 3,
+		Params: schema(
+			colorsParam,
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedFireworks(width, height, palette(th, params), seed)
+		},
+	},
+	{
+		Name:      "rain",
+		Datetime:  true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			colorsParam,
+			rainCharsParam,
+			Param{Name: "density", Kind: KindFloat, Min: 0.1, Max: 20, Default: "2", Help: "Most drops falling at once, per column"},
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedRain(width, height, palette(th, params), params, seed)
+		},
+	},
+	{
+		Name:      "rain-art",
+		Text:      true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			colorsParam,
+			rainCharsParam,
+			Param{Name: "density", Kind: KindFloat, Min: 0.1, Max: 20, Default: "4", Help: "Most drops falling at once, per column"},
+			withDefault(freezeParam, "0.9"),
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedRainArt(width, height, palette(th, params), art, params, seed)
+		},
+	},
+	{
+		Name:      "beams",
+		Datetime:  true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			withDefault(gradientParam, "the theme's gradient, or 5 palette colors"),
+			finalParam,
+			beamsRowParam,
+			beamsColParam,
+			beamsWipeParam,
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedBeams(width, height, th, params, seed)
+		},
+	},
+	{
+		Name:      "beam-text",
+		Text:      true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			withDefault(gradientParam, "the theme's gradient, or 5 palette colors"),
+			finalParam,
+			beamsRowParam,
+			beamsColParam,
+			beamsWipeParam,
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedBeamText(width, height, th, art, params, seed)
+		},
+	},
+	{
+		Name:      "ring-text",
+		Text:      true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			withDefault(gradientParam, "the theme's gradient, or 3 palette colors"),
+			Param{Name: "rings", Kind: KindColors, Default: "the theme's accent role", Help: "Colors of the rings"},
+			Param{Name: "ring_gap", Kind: KindFloat, Min: 0.02, Max: 0.4, Default: "0.1", Help: "Space between the rings, as a share of the screen's shorter side"},
+			Param{Name: "cycles", Kind: KindInt, Min: 1, Max: 100, Default: "3", Help: "Times the text spins and disperses before settling"},
+			holdParam,
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedRingText(width, height, th, art, params, seed)
+		},
+	},
+	{
+		Name:      "blackhole",
+		Text:      true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			withDefault(gradientParam, "the theme's gradient, or 3 palette colors"),
+			Param{Name: "core", Kind: KindColor, Default: "the theme's foreground", Help: "Color of the blackhole"},
+			Param{Name: "stars", Kind: KindColors, Default: "6 palette colors", Help: "Colors of the stars"},
+			holdParam,
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedBlackhole(width, height, th, art, params, seed)
+		},
+	},
+	{
+		Name:      "aquarium",
+		Datetime:  true,
+		MinWidth:  1,
+		MinHeight: 24,
+		Params: schema(
+			Param{Name: "fish", Kind: KindColors, Default: "the theme's fish role", Help: "Colors of the fish"},
+			Param{Name: "water", Kind: KindColors, Default: "the theme's water role", Help: "Colors of the water"},
+			Param{Name: "seaweed", Kind: KindColors, Default: "the theme's seaweed role", Help: "Colors of the seaweed"},
+			Param{Name: "bubble", Kind: KindColor, Default: "the theme's bubble role", Help: "Color of the bubbles"},
+			Param{Name: "diver", Kind: KindColor, Default: "the theme's diver role", Help: "Color of the diver"},
+			Param{Name: "boat", Kind: KindColor, Default: "the theme's boat role", Help: "Color of the boat"},
+			Param{Name: "mermaid", Kind: KindColor, Default: "the theme's mermaid role", Help: "Color of the mermaid"},
+			Param{Name: "anchor", Kind: KindColor, Default: "the theme's anchor role", Help: "Color of the anchor"},
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedAquarium(width, height, th, params, seed)
+		},
+	},
+	{
+		Name:      "pour",
+		Text:      true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			withDefault(gradientParam, "the theme's gradient, or 3 palette colors"),
+			Param{Name: "start_color", Kind: KindColor, Default: "the theme's background", Help: "Color characters start in as they pour"},
+			Param{Name: "direction", Kind: KindChoice, Choices: []string{"down", "up", "left", "right"}, Default: "down", Help: "Direction the characters pour from"},
+			Param{Name: "pour_speed", Kind: KindInt, Min: 1, Max: 100, Default: "3", Help: "Characters poured per update"},
+			Param{Name: "movement_speed", Kind: KindFloat, Min: 0.01, Max: 1, Default: "0.2", Help: "Share of the way a character moves per update"},
+			Param{Name: "easing", Kind: KindChoice, Choices: []string{"easeIn", "easeOut", "easeInOut"}, Default: "easeIn", Help: "How characters speed up and slow down"},
+			Param{Name: "gap", Kind: KindInt, Min: 0, Max: 100, Default: "1", Help: "Updates between groups of characters"},
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedPour(width, height, th, art, params)
+		},
+	},
+	{
+		Name:      "print",
+		Text:      true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			withDefault(gradientParam, "the theme's gradient, or 3 palette colors"),
+			Param{Name: "head", Kind: KindChars, Default: "█", Help: "Character of the print head"},
+			Param{Name: "trail", Kind: KindChars, Default: "░▒▓", Help: "Characters the print head leaves behind"},
+			Param{Name: "print_speed", Kind: KindInt, Min: 1, Max: 100, Default: "1", Help: "Characters printed per update"},
+			Param{Name: "frames_per_char", Kind: KindInt, Min: 1, Max: 100, Default: "1", Help: "Updates between printed characters"},
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedPrint(width, height, th, art, params)
+		},
+	},
+	{
+		Name:      "decrypt",
+		Text:      true,
+		MinWidth:  1,
+		MinHeight: 1,
+		Params: schema(
+			colorsParam,
+			Param{Name: "cipher_colors", Kind: KindColors, Default: "the theme's palette", Help: "Colors of the scrambled characters"},
+			finalParam,
+			Param{Name: "typing_speed", Kind: KindInt, Min: 1, Max: 100, Default: "2", Help: "Characters typed per update"},
+		),
+		new: func(width, height int, th *theme.Theme, art artFit, params Params, seed int64) (Animation, error) {
+			return newOptimizedDecrypt(width, height, palette(th, params), art, params, seed)
+		},
+	},
+}
+
+// palette returns the colors an effect picks from
+func palette(th *theme.Theme, params Params) []string {
+	return params.colors("colors", th.Palette)
+}
+
+// Effects returns every effect in the registry
+func Effects() []Effect {
+	return append([]Effect(nil), registry...)
+}
+
+// Names returns the names of the effects in the registry
+func Names() []string {
+	names := make([]string, len(registry))
+	for i, e := range registry {
+		names[i] = e.Name
+	}
+	return names
+}
+
+// Lookup finds an effect by name
+func Lookup(name string) (Effect, bool) {
+	for _, e := range registry {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Effect{}, false
+}
+
+// Fits reports whether the effect runs on a screen of the given size
+func (e Effect) Fits(width, height int) bool {
+	return width >= e.MinWidth && height >= e.MinHeight
+}
+
+// CheckRegistry compares the registry with sysc-Go's, reporting effects
+// only one of them knows and effects whose need for text the two disagree
+// on
+func CheckRegistry() error {
+	var problems []string
+	for _, e := range registry {
+		meta := syscGo.GetEffectMetadata(e.Name)
+		if meta == nil {
+			problems = append(problems, fmt.Sprintf("%s is not in sysc-Go", e.Name))
+			continue
+		}
+		if e.Text && !meta.RequiresText {
+			problems = append(problems, fmt.Sprintf("%s takes text but sysc-Go's does not", e.Name))
+		}
+		if !e.Text && meta.RequiresText {
+			problems = append(problems, fmt.Sprintf("%s needs text in sysc-Go but sysc-walls gives it none", e.Name))
+		}
+		if e.Datetime && meta.RequiresText {
+			problems = append(problems, fmt.Sprintf("%s takes the datetime overlay but sysc-Go's needs text", e.Name))
+		}
+	}
+	for _, name := range syscGo.GetEffectNames() {
+		if _, ok := Lookup(name); !ok {
+			problems = append(problems, fmt.Sprintf("%s is in sysc-Go but sysc-walls cannot run it", name))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("effect registry differs from sysc-Go: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package animations
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	syscGo "github.com/Nomadcxx/sysc-walls/internal/sysc-go-fork/animations"
+)
+
+// TestCheckRegistry tests that the registry agrees with sysc-Go's
+func TestCheckRegistry(t *testing.T) {
+	if err := CheckRegistry(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := Names(), syscGo.GetEffectNames(); !slices.Equal(got, want) {
+		t.Errorf("Names() = %v, want sysc-Go's order %v", got, want)
+	}
+	for _, name := range goldenEffects {
+		if _, ok := Lookup(name); !ok {
+			t.Errorf("%s is not in the registry", name)
+		}
+	}
+	if _, ok := Lookup("invalid"); ok {
+		t.Error("Lookup found an unknown effect")
+	}
+}
+
+// TestCheckRegistryText tests that CheckRegistry catches an effect that
+// sysc-Go says needs text but the registry gives none
+func TestCheckRegistryText(t *testing.T) {
+	saved := registry
+	defer func() { registry = saved }()
+	registry = append([]Effect(nil), saved...)
+	for i := range registry {
+		if registry[i].Name == "pour" {
+			registry[i].Text = false
+		}
+	}
+	if err := CheckRegistry(); err == nil || !strings.Contains(err.Error(), "pour needs text") {
+		t.Errorf("CheckRegistry() = %v, want pour flagged", err)
+	}
+}
+
+// TestMinimumSizes tests that every effect runs at its minimum size
+func TestMinimumSizes(t *testing.T) {
+	for _, e := range Effects() {
+		if !e.Fits(e.MinWidth, e.MinHeight) || e.Fits(e.MinWidth-1, e.MinHeight) || e.Fits(e.MinWidth, e.MinHeight-1) {
+			t.Errorf("%s: Fits disagrees with %dx%d", e.Name, e.MinWidth, e.MinHeight)
+		}
+		anim, err := CreateAnimationWithOptions(e.Name, e.MinWidth, e.MinHeight, "nord", Options{Text: "A"})
+		if err != nil {
+			t.Errorf("%s: %v", e.Name, err)
+			continue
+		}
+		for i := 0; i < 20; i++ {
+			anim.Update(i)
+		}
+		anim.Render()
+	}
+}
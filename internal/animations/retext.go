@@ -0,0 +1,22 @@
+// retext.go - Changing the text of running sysc-Go text effects
+package animations
+
+// TextAnimation is an Animation whose text can change while it runs, for
+// text that follows a live source such as the clock
+type TextAnimation interface {
+	Animation
+	SetText(text string)
+}
+
+// The wrappers hand new text to sysc-Go's SetText, which changes it without
+// restarting the effect:
+//
+//   - fire-text, matrix-art and rain-art re-parse their mask and carry on;
+//     matrix-art and rain-art keep the characters that still match, so only
+//     the parts of the text that changed form again.
+//   - beam-text rebuilds its characters in its current phase: while the
+//     beams run they reveal the new text, after that it is shown finished.
+//   - ring-text and blackhole fly their characters around, so they take the
+//     new text the next time the text stands still.
+//   - pour, print and decrypt reveal their text once, so they start again
+//     with the new text.
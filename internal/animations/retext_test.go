@@ -0,0 +1,189 @@
+package animations
+
+import (
+	"strings"
+	"testing"
+)
+
+// textEffect is the text API of the sysc-Go effects inside the wrappers
+type textEffect interface {
+	Text() string
+}
+
+// phasedEffect is a text effect that runs through phases
+type phasedEffect interface {
+	textEffect
+	Phase() string
+}
+
+// effectOf returns the sysc-Go effect inside a text effect's wrapper
+func effectOf(anim Animation) textEffect {
+	switch a := anim.(type) {
+	case *optimizedFireText:
+		return a.effect
+	case *optimizedMatrixArt:
+		return a.effect
+	case *optimizedRainArt:
+		return a.effect
+	case *optimizedBeamText:
+		return a.effect
+	case *optimizedBlackhole:
+		return a.effect
+	case *optimizedRingText:
+		return a.effect
+	}
+	return nil
+}
+
+// atRest reports whether a phased effect shows its text in place
+func atRest(e phasedEffect) bool {
+	return e.Phase() == "static" || e.Phase() == "hold"
+}
+
+// TestSetText tests that text effects take new text while running
+func TestSetText(t *testing.T) {
+	effects := []string{"fire-text", "matrix-art", "rain-art", "beam-text", "blackhole", "ring-text"}
+	for _, effect := range effects {
+		t.Run(effect, func(t *testing.T) {
+			var params Params
+			if effect == "matrix-art" || effect == "rain-art" {
+				// Keep the falling characters apart from the art's
+				params = Params{"chars": "|"}
+			}
+			anim, err := CreateAnimationWithOptions(effect, 80, 30, "nord", Options{Text: "AA", Seed: goldenSeed, Params: params})
+			if err != nil {
+				t.Fatal(err)
+			}
+			textAnim, ok := anim.(TextAnimation)
+			if !ok {
+				t.Fatalf("%s does not implement TextAnimation", effect)
+			}
+			for i := 0; i < 50; i++ {
+				anim.Update(i)
+			}
+
+			inner := effectOf(anim)
+			phased, _ := inner.(phasedEffect)
+			isPhased := effect == "blackhole" || effect == "ring-text"
+			if isPhased {
+				// Change the text while the characters are in flight
+				for i := 0; i < 5000 && atRest(phased); i++ {
+					anim.Update(i)
+				}
+			}
+
+			textAnim.SetText("BBBBBB")
+			if isPhased && inner.Text() != "AA" {
+				t.Error("text changed while the characters were in flight")
+			}
+			// Phased effects wait until their characters are back in place
+			for i := 0; i < 5000 && inner.Text() != "BBBBBB"; i++ {
+				anim.Update(i)
+			}
+			if got := inner.Text(); got != "BBBBBB" {
+				t.Fatalf("text = %q after 5000 frames", got)
+			}
+
+			frame := stripAnsiCodes(anim.Render())
+			switch effect {
+			case "matrix-art", "rain-art":
+				if strings.Contains(frame, "A") {
+					t.Errorf("frozen characters of the old art left over:\n%s", frame)
+				}
+			case "blackhole", "ring-text":
+				if n := strings.Count(frame, "B"); n != 6 {
+					t.Errorf("%d characters drawn, want 6", n)
+				}
+			}
+
+			// The new text survives a resize
+			anim.Resize(100, 30)
+			if got := effectOf(anim).Text(); got != "BBBBBB" {
+				t.Errorf("text after resize = %q", got)
+			}
+			anim.Update(0)
+			anim.Render()
+		})
+	}
+}
+
+// TestSetTextRestarts tests that effects which reveal their text once
+// reveal new text from the start
+func TestSetTextRestarts(t *testing.T) {
+	for _, effect := range []string{"pour", "print", "decrypt"} {
+		t.Run(effect, func(t *testing.T) {
+			anim, err := CreateAnimationWithOptions(effect, 80, 30, "nord", Options{Text: "AA", Seed: goldenSeed})
+			if err != nil {
+				t.Fatal(err)
+			}
+			textAnim, ok := anim.(TextAnimation)
+			if !ok {
+				t.Fatalf("%s does not implement TextAnimation", effect)
+			}
+			for i := 0; i < 50; i++ {
+				anim.Update(i)
+			}
+
+			textAnim.SetText("BBBBBB")
+			frame := ""
+			for i := 0; i < 5000 && !strings.Contains(frame, "BBBBBB"); i++ {
+				anim.Update(i)
+				frame = stripAnsiCodes(anim.Render())
+			}
+			if !strings.Contains(frame, "BBBBBB") || strings.Contains(frame, "A") {
+				t.Fatalf("new text not revealed after 5000 frames:\n%s", frame)
+			}
+
+			// The new text survives a resize
+			anim.Resize(100, 30)
+			frame = ""
+			for i := 0; i < 5000 && !strings.Contains(frame, "BBBBBB"); i++ {
+				anim.Update(i)
+				frame = stripAnsiCodes(anim.Render())
+			}
+			if !strings.Contains(frame, "BBBBBB") {
+				t.Errorf("text lost on resize:\n%s", frame)
+			}
+		})
+	}
+}
+
+// TestBeamTextKeepsPhase tests that new text doesn't send beam-text back to
+// an earlier phase
+func TestBeamTextKeepsPhase(t *testing.T) {
+	order := map[string]int{"beams": 0, "final_wipe": 1, "hold": 2}
+	for _, phase := range []string{"beams", "final_wipe", "hold"} {
+		t.Run(phase, func(t *testing.T) {
+			anim, err := CreateAnimationWithOptions("beam-text", 80, 30, "nord", Options{Text: "AA", Seed: goldenSeed})
+			if err != nil {
+				t.Fatal(err)
+			}
+			inner := effectOf(anim).(phasedEffect)
+			anim.Update(0)
+			for i := 0; i < 5000 && inner.Phase() != phase; i++ {
+				anim.Update(i)
+			}
+			if inner.Phase() != phase {
+				t.Fatalf("never reached %s", phase)
+			}
+
+			anim.(TextAnimation).SetText("BBBBBB")
+			if got := inner.Phase(); got != phase {
+				t.Errorf("phase = %q after SetText, want %q", got, phase)
+			}
+			for i := 0; i < 10; i++ {
+				before := inner.Phase()
+				anim.Update(i)
+				if order[inner.Phase()] < order[before] {
+					t.Fatalf("phase went from %s back to %s", before, inner.Phase())
+				}
+			}
+			if phase != "beams" {
+				// Past the beams the new text is shown finished
+				if frame := stripAnsiCodes(anim.Render()); !strings.Contains(frame, "BBBBBB") {
+					t.Errorf("new text not shown:\n%s", frame)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// timed.go - Elapsed-time driven animation steps
+package animations
+
+import "time"
+
+// DefaultStep is the update interval the sysc-Go effects are tuned for
+const DefaultStep = 50 * time.Millisecond
+
+// maxCatchUp is how many steps past a frame's share one Advance may run to
+// make up for a late frame. Beyond that a long stall (a paused display, a
+// suspended machine) is dropped rather than fast-forwarding the effect.
+const maxCatchUp = 5
+
+// TimedAnimation is an Animation driven by elapsed time rather than frame
+// count, so it runs at the same visual speed at any frame rate. It steps
+// whole effect updates; nothing is drawn in between steps.
+type TimedAnimation interface {
+	Animation
+	Advance(elapsed time.Duration)
+}
+
+// stepAnimation drives a frame-stepped Animation at a fixed simulation rate
+type stepAnimation struct {
+	Animation
+	step    time.Duration
+	limit   time.Duration // Most elapsed time one Advance steps through
+	pending time.Duration
+	frame   int
+}
+
+// NewTimedAnimation adapts anim to elapsed-time updates, for frames drawn
+// every interval. It is a step adapter, not interpolation: frame-stepped
+// animations advance once per step of elapsed time, twice per frame at half
+// the step rate and every few frames above it. Below the step rate effects
+// keep their speed but jump; above it frames repeat, since sysc-Go effects
+// can't be drawn part way through a step. Animations that already implement
+// TimedAnimation are returned unchanged.
+func NewTimedAnimation(anim Animation, step, interval time.Duration) TimedAnimation {
+	if timed, ok := anim.(TimedAnimation); ok {
+		return timed
+	}
+	if step <= 0 {
+		step = DefaultStep
+	}
+	// A frame's share of steps, rounded up, and room to catch up
+	perFrame := (interval + step - 1) / step
+	limit := (perFrame + maxCatchUp) * step
+
+	// The first Advance always produces a frame
+	return &stepAnimation{Animation: anim, step: step, limit: limit, pending: step}
+}
+
+// Advance runs as many steps as fit in the elapsed time
+func (s *stepAnimation) Advance(elapsed time.Duration) {
+	s.pending += elapsed
+	if s.pending > s.limit {
+		s.pending = s.limit
+	}
+
+	for s.pending >= s.step {
+		s.Update(s.frame)
+		s.frame++
+		s.pending -= s.step
+	}
+}
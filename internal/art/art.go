@@ -0,0 +1,280 @@
+// Package art finds the artwork text-based effects draw: a file, every file
+// in a directory or glob in turn, the output of a command, or the bundled art.
+// Images are handed out undrawn, to be converted at the screen size.
+package art
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Nomadcxx/sysc-walls/assets"
+	"github.com/Nomadcxx/sysc-walls/internal/imageart"
+	"github.com/Nomadcxx/sysc-walls/pkg/utils"
+)
+
+// CommandPrefix marks a source as a shell command whose output is the art,
+// as in "!fortune | figlet"
+const CommandPrefix = "!"
+
+// DefaultName is the art drawn when none is configured
+const DefaultName = "SYSC"
+
+// MaxSize is the largest artwork accepted, in bytes
+const MaxSize = 64 << 10
+
+// commandTimeout is how long an art command may run
+const commandTimeout = 5 * time.Second
+
+// IsCommand reports whether a source is a command
+func IsCommand(spec string) bool {
+	return strings.HasPrefix(spec, CommandPrefix)
+}
+
+// IsPattern reports whether a source is a glob
+func IsPattern(spec string) bool {
+	return strings.ContainsAny(spec, "*?[")
+}
+
+// Files returns the artwork files a file, directory or glob source names,
+// in name order. Hidden files and subdirectories are skipped.
+func Files(spec string) ([]string, error) {
+	var paths []string
+	switch info, err := os.Stat(spec); {
+	case IsPattern(spec):
+		paths, err = filepath.Glob(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid art pattern %q: %w", spec, err)
+		}
+	case err != nil:
+		return nil, err
+	case info.IsDir():
+		entries, err := os.ReadDir(spec)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			paths = append(paths, filepath.Join(spec, entry.Name()))
+		}
+	default:
+		return []string{spec}, nil
+	}
+
+	var files []string
+	for _, path := range paths {
+		if strings.HasPrefix(filepath.Base(path), ".") {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no art files in %s", spec)
+	}
+	return files, nil
+}
+
+// Source hands out artwork from a configured source. Directory and glob
+// sources rotate through their files, remembering the last one shown
+// across runs so each activation shows the next.
+type Source struct {
+	spec      string
+	statePath string // File remembering the last file shown, "" for none
+}
+
+// NewSource creates a source for an animation.file value
+func NewSource(spec string) *Source {
+	return &Source{spec: spec, statePath: filepath.Join(utils.RuntimeDir(), "art-last")}
+}
+
+// Rotates reports whether Next can return different art each call
+func (s *Source) Rotates() bool {
+	if IsCommand(s.spec) || IsPattern(s.spec) {
+		return true
+	}
+	info, err := os.Stat(s.spec)
+	return err == nil && info.IsDir()
+}
+
+// Piece is one artwork from a source
+type Piece struct {
+	Text  string
+	Image image.Image // Set instead of Text for image files
+	From  string      // Where the art came from
+}
+
+// Next returns the next artwork
+func (s *Source) Next() (Piece, error) {
+	if IsCommand(s.spec) {
+		text, err := RunCommand(strings.TrimPrefix(s.spec, CommandPrefix))
+		return Piece{Text: text, From: s.spec}, err
+	}
+
+	files, err := Files(s.spec)
+	if err != nil {
+		return Piece{}, err
+	}
+	path := files[0]
+	if len(files) > 1 {
+		path = nextFile(files, s.last())
+	}
+	piece := Piece{From: path}
+	if imageart.IsImage(path) {
+		piece.Image, err = imageart.Load(path)
+	} else {
+		piece.Text, err = ReadFile(path)
+	}
+	if err != nil {
+		return Piece{}, err
+	}
+	if len(files) > 1 {
+		s.remember(path)
+	}
+	return piece, nil
+}
+
+// nextFile returns the file after last, wrapping around. Without a last
+// file, or one that is gone, it starts at the first.
+func nextFile(files []string, last string) string {
+	for i, file := range files {
+		if file == last {
+			return files[(i+1)%len(files)]
+		}
+	}
+	return files[0]
+}
+
+// last returns the file shown last, or ""
+func (s *Source) last() string {
+	if s.statePath == "" {
+		return ""
+	}
+	data, err := os.ReadFile(s.statePath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// remember records the file shown. Failing only loses the rotation.
+func (s *Source) remember(path string) {
+	if s.statePath == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(s.statePath), 0700); err == nil {
+		os.WriteFile(s.statePath, []byte(path+"\n"), 0600)
+	}
+}
+
+// RunCommand runs a shell command and returns its output as art
+func RunCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdout = &limitWriter{w: &out, n: MaxSize}
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("art command %q timed out after %v", command, commandTimeout)
+		}
+		return "", fmt.Errorf("art command %q failed: %w", command, err)
+	}
+	return clean(out.Bytes())
+}
+
+// limitWriter keeps the first n bytes written and drops the rest
+type limitWriter struct {
+	w io.Writer
+	n int
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	keep := p
+	if len(keep) > l.n {
+		keep = keep[:l.n]
+	}
+	l.n -= len(keep)
+	if _, err := l.w.Write(keep); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ReadFile reads an artwork file
+func ReadFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, MaxSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > MaxSize {
+		return "", fmt.Errorf("%s is larger than %d KiB", path, MaxSize>>10)
+	}
+	return clean(data)
+}
+
+// clean checks that art is text and trims the blank space around it
+func clean(data []byte) (string, error) {
+	if !utf8.Valid(data) {
+		return "", errors.New("art is not UTF-8 text")
+	}
+	text := strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n"))
+	if text == "" {
+		return "", errors.New("art is empty")
+	}
+	return text, nil
+}
+
+// Default returns the art shown when none is configured: SYSC.txt from the
+// user's art directory or the system share directory, else the bundled one
+func Default() (text, from string) {
+	paths := []string{"/usr/share/sysc-walls/ascii/" + DefaultName + ".txt"}
+	if dir := UserDir(); dir != "" {
+		paths = append([]string{filepath.Join(dir, DefaultName+".txt")}, paths...)
+	}
+	for _, path := range paths {
+		if text, err := ReadFile(path); err == nil {
+			return text, path
+		}
+	}
+	text, _ = Bundled(DefaultName)
+	return text, "bundled " + DefaultName
+}
+
+// Bundled returns bundled art by name
+func Bundled(name string) (string, error) {
+	data, err := assets.ASCII.ReadFile("ascii/" + strings.TrimSuffix(name, ".txt") + ".txt")
+	if err != nil {
+		return "", fmt.Errorf("no bundled art named %q", name)
+	}
+	return clean(data)
+}
+
+// BundledNames returns the names of the bundled art
+func BundledNames() []string {
+	entries, _ := fs.ReadDir(assets.ASCII, "ascii")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+	return names
+}
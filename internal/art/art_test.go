@@ -0,0 +1,147 @@
+package art
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeArt writes art files into dir
+func writeArt(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, text := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestFiles tests file, directory and glob sources
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeArt(t, dir, map[string]string{"b.txt": "B", "a.txt": "A", "c.ans": "C", ".hidden.txt": "H"})
+	os.Mkdir(filepath.Join(dir, "sub.txt"), 0755)
+
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{filepath.Join(dir, "b.txt"), []string{"b.txt"}},
+		{dir, []string{"a.txt", "b.txt", "c.ans"}},
+		{filepath.Join(dir, "*.txt"), []string{"a.txt", "b.txt"}},
+	}
+	for _, tt := range tests {
+		files, err := Files(tt.spec)
+		if err != nil {
+			t.Fatalf("Files(%q) error = %v", tt.spec, err)
+		}
+		var names []string
+		for _, file := range files {
+			names = append(names, filepath.Base(file))
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("Files(%q) = %v, want %v", tt.spec, names, tt.want)
+		}
+	}
+
+	for _, spec := range []string{filepath.Join(dir, "*.flf"), filepath.Join(dir, "missing.txt")} {
+		if _, err := Files(spec); err == nil {
+			t.Errorf("Files(%q) should fail", spec)
+		}
+	}
+}
+
+// TestSourceRotation tests that each activation shows the next file
+func TestSourceRotation(t *testing.T) {
+	dir := t.TempDir()
+	writeArt(t, dir, map[string]string{"1.txt": "one\n", "2.txt": "\n two", "3.txt": "three"})
+
+	state := filepath.Join(t.TempDir(), "art-last")
+	var got []string
+	for i := 0; i < 4; i++ {
+		// A new source per activation, as each display starts fresh
+		source := &Source{spec: dir, statePath: state}
+		piece, err := source.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, piece.Text)
+	}
+	if want := []string{"one", "two", "three", "one"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rotation = %q, want %q", got, want)
+	}
+
+	if !(&Source{spec: dir}).Rotates() || (&Source{spec: filepath.Join(dir, "1.txt")}).Rotates() {
+		t.Error("Rotates() is wrong for a directory or a file")
+	}
+}
+
+// TestImageSource tests that images are handed out to be converted
+func TestImageSource(t *testing.T) {
+	dir := t.TempDir()
+	f, err := os.Create(filepath.Join(dir, "logo.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	png.Encode(f, image.NewGray(image.Rect(0, 0, 4, 2)))
+	f.Close()
+
+	piece, err := (&Source{spec: filepath.Join(dir, "logo.png")}).Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if piece.Image == nil || piece.Text != "" || piece.Image.Bounds().Dx() != 4 {
+		t.Errorf("image piece = %+v", piece)
+	}
+
+	writeArt(t, dir, map[string]string{"broken.gif": "GIF89a"})
+	if _, err := (&Source{spec: filepath.Join(dir, "broken.gif")}).Next(); err == nil {
+		t.Error("a broken image should fail")
+	}
+}
+
+// TestCommandSource tests art from a command's output
+func TestCommandSource(t *testing.T) {
+	source := &Source{spec: "!printf '  /\\\\_/\\\\\\n ( o.o )\\n'"}
+	piece, err := source.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := piece.Text
+	if text != "/\\_/\\\n ( o.o )" {
+		t.Errorf("command art = %q", text)
+	}
+
+	for _, command := range []string{"exit 3", "true", "head -c 100 /dev/zero | tr '\\0' '\\377'"} {
+		if _, err := RunCommand(command); err == nil {
+			t.Errorf("RunCommand(%q) should fail", command)
+		}
+	}
+
+	// Output past MaxSize is dropped
+	text, err = RunCommand("head -c 100000 /dev/zero | tr '\\0' x")
+	if err != nil || len(text) != MaxSize {
+		t.Errorf("large output = %d bytes, %v; want %d", len(text), err, MaxSize)
+	}
+}
+
+// TestBundled tests the embedded art
+func TestBundled(t *testing.T) {
+	names := BundledNames()
+	if !reflect.DeepEqual(names, []string{"SYSC", "SYSC2", "SYSC3"}) {
+		t.Errorf("BundledNames() = %v", names)
+	}
+	text, err := Bundled("SYSC")
+	if err != nil || !strings.Contains(text, "████") {
+		t.Errorf("Bundled(SYSC) = %.20q, %v", text, err)
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	if _, from := Default(); from != "bundled SYSC" && !strings.HasPrefix(from, "/usr/share/") {
+		t.Errorf("Default() came from %s", from)
+	}
+}
@@ -0,0 +1,143 @@
+// library.go - The user's art directory, managed by 'sysc-walls art'
+package art
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// UserDir returns the user's art directory, ~/.config/sysc-walls/ascii
+func UserDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "sysc-walls", "ascii")
+}
+
+// Entry is one piece of art in the library
+type Entry struct {
+	Name    string // File name without .txt
+	Path    string // File in the art directory, "" for bundled art
+	Bundled bool   // Built into the binary
+}
+
+// Library is a directory of art on top of the bundled art
+type Library struct {
+	Dir string
+}
+
+// NewLibrary returns the library in the user's art directory
+func NewLibrary() *Library {
+	return &Library{Dir: UserDir()}
+}
+
+// List returns the .txt art in the directory, then the bundled art the
+// directory doesn't override, each in name order
+func (l *Library) List() ([]Entry, error) {
+	var entries []Entry
+	seen := make(map[string]bool)
+
+	files, err := os.ReadDir(l.Dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, file := range files {
+		if !file.Type().IsRegular() || strings.HasPrefix(file.Name(), ".") || filepath.Ext(file.Name()) != ".txt" {
+			continue
+		}
+		name := strings.TrimSuffix(file.Name(), ".txt")
+		entries = append(entries, Entry{Name: name, Path: filepath.Join(l.Dir, file.Name())})
+		seen[name] = true
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	for _, name := range BundledNames() {
+		if !seen[name] {
+			entries = append(entries, Entry{Name: name, Bundled: true})
+		}
+	}
+	return entries, nil
+}
+
+// Read returns art by library name, or from a file path
+func (l *Library) Read(name string) (string, error) {
+	if strings.ContainsRune(name, os.PathSeparator) {
+		return ReadFile(name)
+	}
+	if err := checkName(name); err != nil {
+		return "", err
+	}
+	if text, err := ReadFile(l.path(name)); err == nil || !errors.Is(err, os.ErrNotExist) {
+		return text, err
+	}
+	return Bundled(name)
+}
+
+// Add copies an art file into the directory, named after the file unless
+// a name is given. Existing art is not replaced.
+func (l *Library) Add(src, name string) (string, error) {
+	if name == "" {
+		name = filepath.Base(src)
+	}
+	name = strings.TrimSuffix(name, ".txt")
+	if err := checkName(name); err != nil {
+		return "", err
+	}
+
+	text, err := ReadFile(src)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(l.Dir, 0755); err != nil {
+		return "", err
+	}
+
+	dst := l.path(name)
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return "", fmt.Errorf("art %q already exists, remove it first", name)
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(text + "\n"); err != nil {
+		f.Close()
+		os.Remove(dst)
+		return "", err
+	}
+	return dst, f.Close()
+}
+
+// Remove deletes art from the directory. Bundled art can't be removed.
+func (l *Library) Remove(name string) error {
+	name = strings.TrimSuffix(name, ".txt")
+	if err := checkName(name); err != nil {
+		return err
+	}
+	err := os.Remove(l.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		if _, bundledErr := Bundled(name); bundledErr == nil {
+			return fmt.Errorf("%q is bundled art and can't be removed", name)
+		}
+		return fmt.Errorf("no art named %q in %s", name, l.Dir)
+	}
+	return err
+}
+
+// path returns the file of art in the directory
+func (l *Library) path(name string) string {
+	return filepath.Join(l.Dir, strings.TrimSuffix(name, ".txt")+".txt")
+}
+
+// checkName rejects names that would leave the art directory
+func checkName(name string) error {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid art name %q", name)
+	}
+	return nil
+}
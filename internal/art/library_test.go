@@ -0,0 +1,68 @@
+package art
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestLibrary tests adding, listing, reading and removing art
+func TestLibrary(t *testing.T) {
+	lib := &Library{Dir: filepath.Join(t.TempDir(), "ascii")}
+	src := t.TempDir()
+	writeArt(t, src, map[string]string{"logo.txt": "  LOGO  \n", "SYSC.txt": "MINE"})
+
+	// An empty library lists the bundled art
+	entries, err := lib.List()
+	if err != nil || len(entries) != 3 || !entries[0].Bundled {
+		t.Fatalf("List() = %+v, %v", entries, err)
+	}
+
+	if _, err := lib.Add(filepath.Join(src, "logo.txt"), ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lib.Add(filepath.Join(src, "logo.txt"), ""); err == nil {
+		t.Error("Add() replaced existing art")
+	}
+	if _, err := lib.Add(filepath.Join(src, "SYSC.txt"), "SYSC"); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range []string{"../escape", ".hidden", "a/b"} {
+		if _, err := lib.Add(filepath.Join(src, "logo.txt"), bad); err == nil {
+			t.Errorf("Add() with name %q should fail", bad)
+		}
+	}
+
+	// User art overrides bundled art of the same name
+	entries, _ = lib.List()
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	if want := []string{"SYSC", "logo", "SYSC2", "SYSC3"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("List() = %v, want %v", names, want)
+	}
+	if text, err := lib.Read("logo"); err != nil || text != "LOGO" {
+		t.Errorf("Read(logo) = %q, %v", text, err)
+	}
+	if text, _ := lib.Read("SYSC"); text != "MINE" {
+		t.Errorf("Read(SYSC) = %q, want the user's copy", text)
+	}
+	if _, err := lib.Read("SYSC2"); err != nil {
+		t.Errorf("Read(SYSC2) = %v", err)
+	}
+
+	if err := lib.Remove("logo.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(lib.Dir, "logo.txt")); !os.IsNotExist(err) {
+		t.Error("Remove() left the file")
+	}
+	if err := lib.Remove("SYSC2"); err == nil {
+		t.Error("Remove() of bundled art should fail")
+	}
+	if err := lib.Remove("logo"); err == nil {
+		t.Error("Remove() of missing art should fail")
+	}
+}
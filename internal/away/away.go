@@ -0,0 +1,165 @@
+// Package away stores the away message shown by running screensavers
+package away
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Nomadcxx/sysc-walls/internal/clock"
+	"github.com/Nomadcxx/sysc-walls/pkg/utils"
+)
+
+// MaxLength is the longest away message, in characters
+const MaxLength = 200
+
+// Message is an away message, such as "In a meeting"
+type Message struct {
+	Text  string    `json:"text"`
+	Until time.Time `json:"until,omitempty"` // Zero to show it until dismissed
+}
+
+// Expired reports whether the message's until time has passed
+func (m Message) Expired(now time.Time) bool {
+	return !m.Until.IsZero() && !now.Before(m.Until)
+}
+
+// Validate checks the message text: one line of printable characters
+func (m Message) Validate() error {
+	if strings.TrimSpace(m.Text) == "" {
+		return errors.New("away message is empty")
+	}
+	if len([]rune(m.Text)) > MaxLength {
+		return fmt.Errorf("away message is longer than %d characters", MaxLength)
+	}
+	for _, r := range m.Text {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("away message contains control character %q", r)
+		}
+	}
+	return nil
+}
+
+// ParseUntil reads when a message ends: a duration such as 45m, HH:MM for
+// its next occurrence, or YYYY-MM-DD HH:MM
+func ParseUntil(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(strings.TrimSpace(value)); err == nil && d > 0 {
+		return now.Add(d), nil
+	}
+	if t, err := clock.ParseTarget(value, now); err == nil && t.After(now) {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid until %q (use 45m, HH:MM or YYYY-MM-DD HH:MM in the future)", value)
+}
+
+// Path returns the away message file in the user's runtime directory
+func Path() string {
+	return filepath.Join(utils.RuntimeDir(), "away.json")
+}
+
+// Save writes the message to path. It replaces the file in one step, so a
+// display never reads half a message.
+func Save(path string, m Message) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".away-*")
+	if err != nil {
+		return fmt.Errorf("failed to write away message: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write away message: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write away message: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads the message at path. ok is false when there is none, it is
+// invalid or it has expired.
+func Load(path string, now time.Time) (Message, bool) {
+	m, ok := read(path)
+	if !ok || m.Expired(now) {
+		return Message{}, false
+	}
+	return m, true
+}
+
+// read reads and validates the message at path
+func read(path string) (m Message, ok bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Message{}, false
+	}
+	if err := json.Unmarshal(data, &m); err != nil || m.Validate() != nil {
+		return Message{}, false
+	}
+	return m, true
+}
+
+// Clear removes the message at path, if there is one
+func Clear(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// checkInterval is how often a watcher looks at the message file
+const checkInterval = time.Second
+
+// Watcher follows the message file for a running display
+type Watcher struct {
+	path    string
+	checked time.Time // Last look at the file
+	modTime time.Time
+	size    int64
+	message Message
+	ok      bool
+}
+
+// NewWatcher creates a watcher for the message at path
+func NewWatcher(path string) *Watcher {
+	return &Watcher{path: path}
+}
+
+// Current returns the message to show at now. The file is looked at once a
+// second and only read again when it changes; an expired message is removed.
+func (w *Watcher) Current(now time.Time) (Message, bool) {
+	if !w.checked.IsZero() && now.Sub(w.checked) < checkInterval {
+		return w.message, w.ok
+	}
+	w.checked = now
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		w.modTime, w.size, w.ok = time.Time{}, 0, false
+		return Message{}, false
+	}
+	if !info.ModTime().Equal(w.modTime) || info.Size() != w.size {
+		w.modTime, w.size = info.ModTime(), info.Size()
+		w.message, w.ok = read(w.path)
+	}
+
+	if w.ok && w.message.Expired(now) {
+		Clear(w.path)
+		w.ok = false
+	}
+	return w.message, w.ok
+}
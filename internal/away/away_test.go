@@ -0,0 +1,101 @@
+package away
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSaveLoad tests storing, expiring and clearing a message
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sysc-walls", "away.json")
+	now := time.Date(2026, 3, 4, 14, 0, 0, 0, time.Local)
+
+	if _, ok := Load(path, now); ok {
+		t.Error("Load() found a message before one was saved")
+	}
+
+	m := Message{Text: "In a meeting - ping on chat", Until: now.Add(time.Hour)}
+	if err := Save(path, m); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("message file = %v, %v; want mode 0600", info, err)
+	}
+
+	got, ok := Load(path, now)
+	if !ok || got.Text != m.Text || !got.Until.Equal(m.Until) {
+		t.Errorf("Load() = %+v, %t", got, ok)
+	}
+	if _, ok := Load(path, now.Add(time.Hour)); ok {
+		t.Error("Load() returned an expired message")
+	}
+
+	if err := Clear(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := Clear(path); err != nil {
+		t.Errorf("Clear() without a message = %v", err)
+	}
+
+	for _, bad := range []string{"", "   ", "two\nlines", strings.Repeat("x", MaxLength+1)} {
+		if err := Save(path, Message{Text: bad}); err == nil {
+			t.Errorf("Save(%q) should fail", bad)
+		}
+	}
+}
+
+// TestWatcher tests that a running display follows changes to the message
+func TestWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "away.json")
+	now := time.Date(2026, 3, 4, 14, 0, 0, 0, time.Local)
+	w := NewWatcher(path)
+
+	if _, ok := w.Current(now); ok {
+		t.Error("Current() found a message before one was saved")
+	}
+
+	Save(path, Message{Text: "BRB", Until: now.Add(5 * time.Second)})
+	if m, ok := w.Current(now.Add(500 * time.Millisecond)); ok {
+		t.Errorf("Current() looked at the file again within a second: %+v", m)
+	}
+	if m, ok := w.Current(now.Add(time.Second)); !ok || m.Text != "BRB" {
+		t.Errorf("Current() = %+v, %t; want BRB", m, ok)
+	}
+
+	// Expired messages are removed
+	if _, ok := w.Current(now.Add(6 * time.Second)); ok {
+		t.Error("Current() returned an expired message")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expired message file was not removed")
+	}
+}
+
+// TestParseUntil tests --until values
+func TestParseUntil(t *testing.T) {
+	now := time.Date(2026, 3, 4, 14, 0, 0, 0, time.Local)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"45m", now.Add(45 * time.Minute)},
+		{"15:00", time.Date(2026, 3, 4, 15, 0, 0, 0, time.Local)},
+		{"09:30", time.Date(2026, 3, 5, 9, 30, 0, 0, time.Local)}, // Tomorrow
+		{"2026-03-06 08:00", time.Date(2026, 3, 6, 8, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := ParseUntil(tt.value, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseUntil(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"soon", "-5m", "2020-01-01 10:00"} {
+		if _, err := ParseUntil(bad, now); err == nil {
+			t.Errorf("ParseUntil(%q) should fail", bad)
+		}
+	}
+}
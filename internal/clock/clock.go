@@ -2,8 +2,12 @@
 package clock
 
 import (
+	"fmt"
 	"strings"
 	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/figlet"
+	"github.com/mattn/go-runewidth"
 )
 
 // ClockStyle represents a specific ASCII clock style
@@ -13,145 +17,355 @@ const (
 	StyleKompaktblk ClockStyle = "kompaktblk"
 )
 
-// Clock styles digits (using kompaktblk style from sysc-greet)
-var clockDigits = map[rune][]string{
-	'0': {
-		"▄▀▀█▄ ",
-		"█▄▀ █ ",
-		" ▀▀▀  ",
-	},
-	'1': {
-		" ▄█   ",
-		"  █   ",
-		"▀▀▀▀▀ ",
-	},
-	'2': {
-		"▀▀▀▀█ ",
-		"█▀▀▀▀ ",
-		"▀▀▀▀▀ ",
-	},
-	'3': {
-		"▀▀▀▀▄ ",
-		"  ▀▀▄ ",
-		"▀▀▀▀  ",
-	},
-	'4': {
-		"█   █ ",
-		"▀▀▀▀█ ",
-		"    ▀ ",
-	},
-	'5': {
-		"█▀▀▀▀ ",
-		"▀▀▀▀█ ",
-		"▀▀▀▀▀ ",
-	},
-	'6': {
-		"█▀▀▀▀ ",
-		"█▀▀▀█ ",
-		"▀▀▀▀▀ ",
-	},
-	'7': {
-		"▀▀▀▀█ ",
-		"   █▀ ",
-		"   ▀  ",
-	},
-	'8': {
-		"█▀▀▀█ ",
-		"█▀▀▀█ ",
-		"▀▀▀▀▀ ",
-	},
-	'9': {
-		"█▀▀▀█ ",
-		"▀▀▀▀█ ",
-		"▀▀▀▀▀ ",
-	},
-	':': {
-		"  ▄   ",
-		"  ▄   ",
-		"      ",
-	},
-	' ': {
-		"      ",
-		"      ",
-		"      ",
-	},
-	'A': {
-		"▄▀▀▀▄ ",
-		"█▀▀▀█ ",
-		"▀   ▀ ",
-	},
-	'M': {
-		"█▀▄▀█ ",
-		"█   █ ",
-		"▀   ▀ ",
-	},
-	'P': {
-		"█▀▀▀▄ ",
-		"█▀▀▀  ",
-		"▀     ",
-	},
-}
+// DefaultFont is the clock font when none is configured
+const DefaultFont = string(StyleKompaktblk)
+
+// defaultFont draws the clock unless another font is given
+var defaultFont = figlet.MustLoad(DefaultFont)
 
 // RenderClock renders time string using ASCII art
 func RenderClock(timeStr string) []string {
-	// Get the height from first digit
-	if len(clockDigits['0']) == 0 {
-		return []string{timeStr}
+	return RenderClockWith(defaultFont, timeStr)
+}
+
+// RenderClockWith renders a time string in a FIGlet font
+func RenderClockWith(font *figlet.Font, timeStr string) []string {
+	if font == nil {
+		font = defaultFont
+	}
+	return font.Render(timeStr)
+}
+
+// Clock renders the date-time overlay in a format, locale and set of
+// time zones
+type Clock struct {
+	font    *figlet.Font
+	style   Style
+	layout  string // Go layout of the time
+	pad     bool   // Pad single-digit 12-hour times
+	seconds bool
+	locale  *Locale
+	zones   []Zone
+	start   time.Time // When the away timer started
+	target  time.Time // End of the countdown
+}
+
+// Options configures a Clock. The zero value is the default clock.
+type Options struct {
+	Font      *figlet.Font // nil for the default font
+	Format    string       // "12h", "24h" or a Go time layout; "" for 12h
+	NoSeconds bool         // Leave out seconds with 12h and 24h
+	Locale    string       // Language code or "auto"; "" for English
+	Zones     []string     // "Label=Area/City" entries; none for local time
+	Style     Style        // Widget; "" for the digital clock
+	Countdown string       // Countdown target, HH:MM or YYYY-MM-DD HH:MM
+	Start     time.Time    // Start of the away timer; zero for now
+}
+
+// Zone is a labelled time zone
+type Zone struct {
+	Label    string
+	Location *time.Location
+}
+
+// Gap between clocks shown side by side
+const zoneGap = 4
+
+// New returns a clock for the options
+func New(opts Options) (*Clock, error) {
+	c := &Clock{font: opts.Font, style: opts.Style, seconds: !opts.NoSeconds, start: opts.Start}
+	if c.font == nil {
+		c.font = defaultFont
+	}
+	if c.style == "" {
+		c.style = Digital
+	}
+	if !c.style.Valid() {
+		return nil, fmt.Errorf("unknown clock style %q (styles: %s)", opts.Style, strings.Join(StyleNames(), ", "))
+	}
+	if c.start.IsZero() {
+		c.start = time.Now()
+	}
+	if c.style == Countdown {
+		if opts.Countdown == "" {
+			return nil, fmt.Errorf("the countdown style needs a target time")
+		}
+		target, err := ParseTarget(opts.Countdown, c.start)
+		if err != nil {
+			return nil, err
+		}
+		c.target = target
+	}
+
+	layout, err := ParseFormat(opts.Format, !opts.NoSeconds)
+	if err != nil {
+		return nil, err
+	}
+	c.layout = layout
+	c.pad = opts.Format == "" || opts.Format == "12h"
+
+	locale := opts.Locale
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	if c.locale, _ = LookupLocale(locale); c.locale == nil {
+		if opts.Locale != "auto" {
+			return nil, fmt.Errorf("unknown locale %q", opts.Locale)
+		}
+		// The environment names a locale without translations
+		c.locale = locales[DefaultLocale]
+	}
+
+	for _, entry := range opts.Zones {
+		zone, err := ParseZone(entry)
+		if err != nil {
+			return nil, err
+		}
+		c.zones = append(c.zones, zone)
+	}
+	return c, nil
+}
+
+// ParseFormat returns the Go layout for a time format: "12h", "24h" or a
+// layout of its own, which must show some part of the time
+func ParseFormat(format string, seconds bool) (string, error) {
+	switch format {
+	case "", "12h":
+		if seconds {
+			return "3:04:05 PM", nil
+		}
+		return "3:04 PM", nil
+	case "24h":
+		if seconds {
+			return "15:04:05", nil
+		}
+		return "15:04", nil
+	}
+
+	ref := time.Date(2001, 2, 3, 16, 5, 6, 0, time.UTC)
+	if ref.Format(format) == format {
+		return "", fmt.Errorf("format %q has no time fields (use 12h, 24h or a Go layout like 15:04)", format)
+	}
+	return format, nil
+}
+
+// ParseZone reads a zone entry: an IANA name such as Asia/Tokyo, optionally
+// prefixed with a label as in Tokyo=Asia/Tokyo. Without a label the city
+// names the clock. "Local" is the system zone.
+func ParseZone(entry string) (Zone, error) {
+	label, name, found := strings.Cut(entry, "=")
+	if !found {
+		name = label
+		label = ""
+	}
+	label, name = strings.TrimSpace(label), strings.TrimSpace(name)
+
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "" {
+		return Zone{}, fmt.Errorf("unknown time zone %q", name)
+	}
+	if label == "" {
+		label = name[strings.LastIndex(name, "/")+1:]
+		label = strings.ReplaceAll(label, "_", " ")
+	}
+	return Zone{Label: label, Location: loc}, nil
+}
+
+// Time returns the time of day as the clock formats it
+func (c *Clock) Time(t time.Time) string {
+	timeStr := c.locale.Format(t, c.layout)
+	// Pad single-digit hours for consistent width
+	if c.pad && t.Hour()%12 != 0 && t.Hour()%12 < 10 {
+		timeStr = " " + timeStr
+	}
+	return timeStr
+}
+
+// Date returns the date line, upper-cased
+func (c *Clock) Date(t time.Time) string {
+	return strings.ToUpper(c.locale.Format(t, c.locale.DateLayout))
+}
+
+// Render returns the overlay for t in a screen of width by height cells:
+// the widget above the date, or one labelled widget per zone, side by side
+// as far as width allows. Countdown and away timers ignore zones. A size
+// of 0 leaves the layout unconstrained.
+func (c *Clock) Render(t time.Time, width, height int) []string {
+	switch c.style {
+	case Countdown:
+		return c.timer(c.countdown(t), "UNTIL "+strings.ToUpper(strings.TrimSpace(c.Time(c.target))), width)
+	case Away:
+		return c.timer(away(t.Sub(c.start)), "AWAY SINCE "+strings.ToUpper(strings.TrimSpace(c.Time(c.start))), width)
 	}
-	height := len(clockDigits['0'])
 
-	// Build each line of the clock
+	if len(c.zones) == 0 {
+		return c.block(t, "", width, height)
+	}
+
+	blocks := make([][]string, len(c.zones))
+	for i, zone := range c.zones {
+		blocks[i] = c.block(t.In(zone.Location), strings.ToUpper(zone.Label), width, height)
+	}
+	return arrange(blocks, width)
+}
+
+// block lays out one clock: the face, a blank line, the label if any and
+// the date
+func (c *Clock) block(t time.Time, label string, width, height int) []string {
 	var lines []string
-	for row := 0; row < height; row++ {
-		var line strings.Builder
-		for _, ch := range timeStr {
-			digitLines, ok := clockDigits[ch]
-			if !ok {
-				// Unknown character, use space
-				digitLines = clockDigits[' ']
+	switch c.style {
+	case Analog:
+		lines = c.analog(t, width, height)
+	case Binary:
+		lines = c.binary(t)
+	default:
+		lines = c.digits(c.Time(t), width)
+	}
+
+	lines = append(lines, "")
+	if label != "" {
+		lines = append(lines, label)
+	}
+	return append(lines, c.Date(t))
+}
+
+// timer lays out a countdown or away timer: the time in the font above a
+// caption
+func (c *Clock) timer(text, caption string, width int) []string {
+	return append(c.digits(text, width), "", caption)
+}
+
+// digits draws text in the font, or as plain text when the font would be
+// wider than width
+func (c *Clock) digits(text string, width int) []string {
+	lines := DrawText(c.font, text)
+	if width > 0 && GetMaxLineWidth(lines) > width {
+		lines = []string{strings.TrimSpace(text)}
+	}
+	return lines
+}
+
+// arrange places blocks side by side, each line centered in its block,
+// starting a new row of blocks whenever the next one would pass width
+func arrange(blocks [][]string, width int) []string {
+	var out []string
+	for len(blocks) > 0 {
+		n, rowWidth := 1, GetMaxLineWidth(blocks[0])
+		for n < len(blocks) {
+			w := GetMaxLineWidth(blocks[n])
+			if width > 0 && rowWidth+zoneGap+w > width {
+				break
+			}
+			rowWidth += zoneGap + w
+			n++
+		}
+
+		if out != nil {
+			out = append(out, "")
+		}
+		out = append(out, joinBlocks(blocks[:n])...)
+		blocks = blocks[n:]
+	}
+	return out
+}
+
+// joinBlocks joins blocks line by line, bottom-aligned so dates share a row
+func joinBlocks(blocks [][]string) []string {
+	height := 0
+	for _, block := range blocks {
+		if len(block) > height {
+			height = len(block)
+		}
+	}
+
+	lines := make([]string, height)
+	for i, block := range blocks {
+		w := GetMaxLineWidth(block)
+		offset := height - len(block)
+		for y := range lines {
+			var cell string
+			if y >= offset {
+				cell = block[y-offset]
 			}
-			if row < len(digitLines) {
-				line.WriteString(digitLines[row])
+			if i > 0 {
+				lines[y] += strings.Repeat(" ", zoneGap)
 			}
+			lines[y] += padCenter(cell, w)
 		}
-		lines = append(lines, line.String())
+	}
+	for y := range lines {
+		lines[y] = strings.TrimRight(lines[y], " ")
 	}
 	return lines
 }
 
+// padCenter centers s in a field of width columns
+func padCenter(s string, width int) string {
+	w := runewidth.StringWidth(s)
+	if w >= width {
+		return s
+	}
+	left := (width - w) / 2
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", width-w-left)
+}
+
+// DrawText draws s in the font. Characters the font lacks, such as a
+// locale's AM/PM or month names, are written as they are on the font's
+// baseline instead of being left out.
+func DrawText(font *figlet.Font, s string) []string {
+	rows := make([]string, font.Height)
+	baseline := font.Baseline - 1
+	if baseline < 0 || baseline >= font.Height {
+		baseline = font.Height - 1
+	}
+
+	var run []rune
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		for i, line := range font.Render(string(run)) {
+			rows[i] += line
+		}
+		run = run[:0]
+	}
+	for _, r := range s {
+		if font.Has(r) {
+			run = append(run, r)
+			continue
+		}
+		flush()
+		for i := range rows {
+			if i == baseline {
+				rows[i] += string(r)
+			} else {
+				rows[i] += strings.Repeat(" ", runewidth.RuneWidth(r))
+			}
+		}
+	}
+	flush()
+	return rows
+}
+
+// defaultClock renders the overlay when no options are given
+var defaultClock, _ = New(Options{})
+
 // GetDateTime returns formatted time and date strings
 func GetDateTime() (timeStr string, dateStr string) {
 	now := time.Now()
-	// Format time like "3:04:05 PM"
-	timeStr = now.Format("3:04:05 PM")
-	// Pad single-digit hours for consistent width
-	if len(timeStr) > 1 && timeStr[0] != '1' && timeStr[1] == ':' {
-		timeStr = " " + timeStr
-	}
-	// Format date like "MONDAY, JANUARY 2, 2006"
-	dateStr = strings.ToUpper(now.Format("Monday, January 2, 2006"))
-	return
+	return defaultClock.Time(now), defaultClock.Date(now)
 }
 
 // RenderDateTime renders the complete date-time overlay
 func RenderDateTime() []string {
-	timeStr, dateStr := GetDateTime()
-	clockLines := RenderClock(timeStr)
-
-	// Combine clock lines and date
-	result := make([]string, 0, len(clockLines)+2)
-	result = append(result, clockLines...)
-	result = append(result, "") // Blank line
-	result = append(result, dateStr)
-
-	return result
+	return defaultClock.Render(time.Now(), 0, 0)
 }
 
 // CenterLines centers each line in the given width
 func CenterLines(lines []string, width int) []string {
 	centered := make([]string, len(lines))
 	for i, line := range lines {
-		lineLen := len([]rune(line))
+		lineLen := runewidth.StringWidth(line)
 		if lineLen >= width {
 			centered[i] = line
 		} else {
@@ -170,7 +384,7 @@ func CenterLinesBright(lines []string, width int) []string {
 
 	centered := make([]string, len(lines))
 	for i, line := range lines {
-		lineLen := len([]rune(line))
+		lineLen := runewidth.StringWidth(line)
 		if lineLen >= width {
 			centered[i] = brightWhite + line + reset
 		} else {
@@ -185,7 +399,7 @@ func CenterLinesBright(lines []string, width int) []string {
 func GetMaxLineWidth(lines []string) int {
 	maxWidth := 0
 	for _, line := range lines {
-		width := len([]rune(line))
+		width := runewidth.StringWidth(line)
 		if width > maxWidth {
 			maxWidth = width
 		}
@@ -0,0 +1,224 @@
+package clock
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// at is the time used throughout: a Sunday morning in UTC
+var at = time.Date(2026, 10, 18, 9, 5, 7, 0, time.UTC)
+
+// TestTimeFormats tests the 12h, 24h and custom formats
+func TestTimeFormats(t *testing.T) {
+	tests := []struct {
+		opts Options
+		want string
+	}{
+		{Options{}, " 9:05:07 AM"},
+		{Options{NoSeconds: true}, " 9:05 AM"},
+		{Options{Format: "24h"}, "09:05:07"},
+		{Options{Format: "24h", NoSeconds: true}, "09:05"},
+		{Options{Format: "15h04"}, "09h05"},
+		{Options{Format: "Mon 15:04", Locale: "de"}, "Son 09:05"},
+		{Options{Format: "3:04 PM", Locale: "ja"}, "9:05 午前"},
+		{Options{Format: "Jan 2 15:04", Locale: "ko"}, "10월 18 09:05"},
+	}
+
+	for _, tt := range tests {
+		c, err := New(tt.opts)
+		if err != nil {
+			t.Fatalf("New(%+v) error = %v", tt.opts, err)
+		}
+		if got := c.Time(at); got != tt.want {
+			t.Errorf("Time() with %+v = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+
+	if _, err := ParseFormat("hello", true); err == nil {
+		t.Error("ParseFormat() of a layout without time fields should fail")
+	}
+}
+
+// TestLocales tests localized date lines and locale lookup
+func TestLocales(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en", "SUNDAY, OCTOBER 18, 2026"},
+		{"de_DE.UTF-8", "SONNTAG, 18. OKTOBER 2026"},
+		{"fr", "DIMANCHE 18 OCTOBRE 2026"},
+		{"ru", "ВОСКРЕСЕНЬЕ, 18 ОКТЯБРЯ 2026"},
+		{"ja", "2026年10月18日 日曜日"},
+		{"zh-CN", "2026年10月18日 星期日"},
+	}
+
+	for _, tt := range tests {
+		c, err := New(Options{Locale: tt.locale})
+		if err != nil {
+			t.Fatalf("New(%q) error = %v", tt.locale, err)
+		}
+		if got := c.Date(at); got != tt.want {
+			t.Errorf("Date() in %s = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_TIME", "sv_SE.UTF-8")
+	if loc, ok := LookupLocale("auto"); !ok || loc != locales["sv"] {
+		t.Error("LookupLocale(auto) should follow LC_TIME")
+	}
+	if _, err := New(Options{Locale: "tlh"}); err == nil {
+		t.Error("New() with an unknown locale should fail")
+	}
+
+	// "Month" isn't a layout token, so it must survive localization
+	if got := locales["de"].Format(at, "Month Jan"); got != "Month Okt" {
+		t.Errorf("Format() = %q, want \"Month Okt\"", got)
+	}
+}
+
+// TestMissingGlyphs tests that characters the font lacks are still drawn
+func TestMissingGlyphs(t *testing.T) {
+	for name, locale := range locales {
+		c, err := New(Options{Format: "Mon Jan 3:04 PM", Locale: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, t0 := range []time.Time{at, at.Add(12 * time.Hour)} {
+			text := strings.Join(c.Render(t0, 0, 0), "\n")
+			for _, want := range []string{locale.name(t0, "Mon"), locale.name(t0, "PM")} {
+				for _, r := range want {
+					if r != ' ' && !defaultFont.Has(r) && !strings.ContainsRune(text, r) {
+						t.Errorf("%s: %q not drawn in\n%s", name, r, text)
+					}
+				}
+			}
+		}
+	}
+
+	// Every row keeps the same width around wide characters
+	lines := DrawText(defaultFont, "1午2")
+	for _, line := range lines {
+		if w := runewidth.StringWidth(line); w != runewidth.StringWidth(lines[0]) {
+			t.Errorf("rows differ in width: %q", lines)
+		}
+	}
+}
+
+// TestZones tests zone parsing and the side-by-side layout
+func TestZones(t *testing.T) {
+	zone, err := ParseZone("America/New_York")
+	if err != nil || zone.Label != "New York" {
+		t.Errorf("ParseZone() = %+v, %v", zone, err)
+	}
+	if zone, err = ParseZone("Home = Europe/Berlin"); err != nil || zone.Label != "Home" {
+		t.Errorf("ParseZone() with label = %+v, %v", zone, err)
+	}
+	if _, err := ParseZone("Mars/Olympus_Mons"); err == nil {
+		t.Error("ParseZone() of an unknown zone should fail")
+	}
+
+	c, err := New(Options{Format: "24h", NoSeconds: true, Zones: []string{"UTC", "Tokyo=Asia/Tokyo", "Local"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wide enough for all three in one row
+	wide := c.Render(at, 200, 0)
+	if len(wide) != defaultFont.Height+3 {
+		t.Errorf("one row of clocks has %d lines: %q", len(wide), wide)
+	}
+	if !strings.Contains(wide[len(wide)-2], "TOKYO") || !strings.Contains(wide[len(wide)-2], "UTC") {
+		t.Errorf("labels missing: %q", wide[len(wide)-2])
+	}
+
+	// Narrower terminals wrap, and every line fits
+	for _, width := range []int{80, 40} {
+		lines := c.Render(at, width, 0)
+		if len(lines) <= len(wide) {
+			t.Errorf("width %d: clocks did not wrap: %q", width, lines)
+		}
+		for _, line := range lines {
+			if w := runewidth.StringWidth(line); w > width {
+				t.Errorf("width %d: line is %d wide: %q", width, w, line)
+			}
+		}
+	}
+}
+
+// TestWidgets tests the analog, binary, countdown and away widgets
+func TestWidgets(t *testing.T) {
+	// Analog faces fill half the screen and stay inside it
+	c, _ := New(Options{Style: Analog})
+	for _, size := range [][2]int{{80, 24}, {200, 60}, {20, 10}} {
+		lines := c.Render(at, size[0], size[1])
+		face := lines[:len(lines)-2]
+		if len(face) < analogMinRows || (size[1] >= 2*analogMinRows && len(face) > size[1]/2) {
+			t.Errorf("%dx%d: face has %d rows", size[0], size[1], len(face))
+		}
+		if w := GetMaxLineWidth(face); w > size[0] && size[0] >= 2*analogMinRows {
+			t.Errorf("%dx%d: face is %d wide", size[0], size[1], w)
+		}
+		text := strings.Join(face, "\n")
+		for _, mark := range []string{"12", "3", "6", "9", "#", "+", "O"} {
+			if !strings.Contains(text, mark) {
+				t.Errorf("%dx%d: face missing %q:\n%s", size[0], size[1], mark, text)
+			}
+		}
+	}
+
+	// 09:05:07 in BCD
+	c, _ = New(Options{Style: Binary, Format: "24h"})
+	want := []string{
+		"   ██      ░░      ░░",
+		"   ░░   ░░ ██   ░░ ██",
+		"░░ ░░   ░░ ░░   ░░ ██",
+		"░░ ██   ░░ ██   ░░ ██",
+		"0  9    0  5    0  7",
+	}
+	if got := c.Render(at, 80, 24); strings.Join(got[:5], "\n") != strings.Join(want, "\n") {
+		t.Errorf("binary =\n%s\nwant\n%s", strings.Join(got[:5], "\n"), strings.Join(want, "\n"))
+	}
+
+	// Countdowns run to the next 17:30 and stop at zero
+	if _, err := New(Options{Style: Countdown}); err == nil {
+		t.Error("New() of a countdown without a target should fail")
+	}
+	start := time.Date(2026, 10, 18, 9, 5, 7, 0, time.Local)
+	c, err := New(Options{Style: Countdown, Countdown: "17:30", Start: start, Format: "24h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.countdown(start); got != "8:24:53" {
+		t.Errorf("countdown = %q, want 8:24:53", got)
+	}
+	if got := c.countdown(start.Add(24 * time.Hour)); got != "0:00:00" {
+		t.Errorf("countdown past the target = %q", got)
+	}
+	if target, _ := ParseTarget("08:00", start); target.Day() != 19 {
+		t.Errorf("ParseTarget() of a passed time = %v, want tomorrow", target)
+	}
+	if _, err := ParseTarget("soon", start); err == nil {
+		t.Error("ParseTarget() of a bad target should fail")
+	}
+
+	// The away timer counts from the start
+	c, _ = New(Options{Style: Away, Start: start})
+	lines := c.Render(start.Add(75*time.Minute), 80, 24)
+	if !strings.HasPrefix(lines[len(lines)-1], "AWAY SINCE") {
+		t.Errorf("away caption = %q", lines[len(lines)-1])
+	}
+	for d, want := range map[time.Duration]string{0: "0m", 12 * time.Minute: "12m", 75 * time.Minute: "1h 15m"} {
+		if got := away(d); got != want {
+			t.Errorf("away(%v) = %q, want %q", d, got, want)
+		}
+	}
+
+	if _, err := New(Options{Style: "sundial"}); err == nil {
+		t.Error("New() with an unknown style should fail")
+	}
+}
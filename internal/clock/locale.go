@@ -0,0 +1,256 @@
+// locale.go - Weekday and month names for the date line
+package clock
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Locale holds the names a date is written with
+type Locale struct {
+	Weekdays      [7]string  // Sunday first
+	Months        [12]string // In the form used after a day number
+	ShortWeekdays []string   // Optional; the first three letters otherwise
+	ShortMonths   []string   // Optional; the first three letters otherwise
+	AM, PM        string
+	DateLayout    string // Go layout of the date line
+}
+
+// locales are the supported locales by language code
+var locales = map[string]*Locale{
+	"en": {
+		Weekdays:   [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		Months:     [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		AM:         "AM",
+		PM:         "PM",
+		DateLayout: "Monday, January 2, 2006",
+	},
+	"de": {
+		Weekdays:   [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		Months:     [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		AM:         "AM",
+		PM:         "PM",
+		DateLayout: "Monday, 2. January 2006",
+	},
+	"fr": {
+		Weekdays:   [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		Months:     [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		AM:         "AM",
+		PM:         "PM",
+		DateLayout: "Monday 2 January 2006",
+	},
+	"es": {
+		Weekdays:   [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		Months:     [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		AM:         "a. m.",
+		PM:         "p. m.",
+		DateLayout: "Monday, 2 de January de 2006",
+	},
+	"it": {
+		Weekdays:   [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		Months:     [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		AM:         "AM",
+		PM:         "PM",
+		DateLayout: "Monday 2 January 2006",
+	},
+	"pt": {
+		Weekdays:   [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		Months:     [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		AM:         "AM",
+		PM:         "PM",
+		DateLayout: "Monday, 2 de January de 2006",
+	},
+	"nl": {
+		Weekdays:   [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		Months:     [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		AM:         "AM",
+		PM:         "PM",
+		DateLayout: "Monday 2 January 2006",
+	},
+	"sv": {
+		Weekdays:   [7]string{"söndag", "måndag", "tisdag", "onsdag", "torsdag", "fredag", "lördag"},
+		Months:     [12]string{"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september", "oktober", "november", "december"},
+		AM:         "fm",
+		PM:         "em",
+		DateLayout: "Monday 2 January 2006",
+	},
+	"pl": {
+		Weekdays:   [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+		Months:     [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+		AM:         "AM",
+		PM:         "PM",
+		DateLayout: "Monday, 2 January 2006",
+	},
+	"ru": {
+		Weekdays:   [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		Months:     [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		AM:         "AM",
+		PM:         "PM",
+		DateLayout: "Monday, 2 January 2006",
+	},
+	"el": {
+		Weekdays:   [7]string{"Κυριακή", "Δευτέρα", "Τρίτη", "Τετάρτη", "Πέμπτη", "Παρασκευή", "Σάββατο"},
+		Months:     [12]string{"Ιανουαρίου", "Φεβρουαρίου", "Μαρτίου", "Απριλίου", "Μαΐου", "Ιουνίου", "Ιουλίου", "Αυγούστου", "Σεπτεμβρίου", "Οκτωβρίου", "Νοεμβρίου", "Δεκεμβρίου"},
+		AM:         "π.μ.",
+		PM:         "μ.μ.",
+		DateLayout: "Monday, 2 January 2006",
+	},
+	"ja": {
+		Weekdays:      [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		Months:        [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		ShortWeekdays: []string{"日", "月", "火", "水", "木", "金", "土"},
+		ShortMonths:   []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		AM:            "午前",
+		PM:            "午後",
+		DateLayout:    "2006年1月2日 Monday",
+	},
+	"zh": {
+		Weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		Months:        [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		ShortWeekdays: []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		ShortMonths:   []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		AM:            "上午",
+		PM:            "下午",
+		DateLayout:    "2006年1月2日 Monday",
+	},
+	"ko": {
+		Weekdays:      [7]string{"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"},
+		Months:        [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		ShortWeekdays: []string{"일", "월", "화", "수", "목", "금", "토"},
+		ShortMonths:   []string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		AM:            "오전",
+		PM:            "오후",
+		DateLayout:    "2006년 1월 2일 Monday",
+	},
+}
+
+// DefaultLocale is the locale when none is configured
+const DefaultLocale = "en"
+
+// Locales returns the supported locale names, plus "auto" for the
+// environment's locale
+func Locales() []string {
+	names := make([]string, 0, len(locales)+1)
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{"auto"}, names...)
+}
+
+// LookupLocale returns a locale by language code, such as "de" or "de_DE",
+// or "auto" for the one in LC_ALL, LC_TIME or LANG
+func LookupLocale(name string) (*Locale, bool) {
+	if name == "auto" {
+		name = envLocale()
+	}
+	name = strings.ToLower(name)
+	if i := strings.IndexAny(name, "_-.@"); i >= 0 {
+		name = name[:i]
+	}
+	loc, ok := locales[name]
+	return loc, ok
+}
+
+// envLocale returns the locale the environment asks for dates in, falling
+// back to English for C, POSIX and unset
+func envLocale() string {
+	for _, key := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			if value == "C" || value == "POSIX" || strings.HasPrefix(value, "C.") {
+				return DefaultLocale
+			}
+			return value
+		}
+	}
+	return DefaultLocale
+}
+
+// shortName returns the abbreviation of a name: the first three letters
+func shortName(name string) string {
+	r := []rune(name)
+	if len(r) > 3 {
+		r = r[:3]
+	}
+	return string(r)
+}
+
+// Format formats t like time.Format, with weekday, month and AM/PM names
+// from the locale
+func (l *Locale) Format(t time.Time, layout string) string {
+	var b strings.Builder
+	for layout != "" {
+		i, token := nextNameToken(layout)
+		if i < 0 {
+			b.WriteString(t.Format(layout))
+			break
+		}
+		if i > 0 {
+			b.WriteString(t.Format(layout[:i]))
+		}
+		b.WriteString(l.name(t, token))
+		layout = layout[i+len(token):]
+	}
+	return b.String()
+}
+
+// name returns the localized text of one name token
+func (l *Locale) name(t time.Time, token string) string {
+	switch token {
+	case "Monday":
+		return l.Weekdays[t.Weekday()]
+	case "Mon":
+		if l.ShortWeekdays != nil {
+			return l.ShortWeekdays[t.Weekday()]
+		}
+		return shortName(l.Weekdays[t.Weekday()])
+	case "January":
+		return l.Months[t.Month()-1]
+	case "Jan":
+		if l.ShortMonths != nil {
+			return l.ShortMonths[t.Month()-1]
+		}
+		return shortName(l.Months[t.Month()-1])
+	case "PM":
+		if t.Hour() < 12 {
+			return l.AM
+		}
+		return l.PM
+	default: // "pm"
+		if t.Hour() < 12 {
+			return strings.ToLower(l.AM)
+		}
+		return strings.ToLower(l.PM)
+	}
+}
+
+// nextNameToken finds the first name token in a Go layout the way
+// time.Format reads it: "Mon" and "Jan" only when no lowercase letter
+// follows, so "Month" is left alone
+func nextNameToken(layout string) (int, string) {
+	for i := 0; i < len(layout); i++ {
+		rest := layout[i:]
+		switch {
+		case strings.HasPrefix(rest, "Monday"):
+			return i, "Monday"
+		case strings.HasPrefix(rest, "January"):
+			return i, "January"
+		case strings.HasPrefix(rest, "Mon") && !startsLower(rest[3:]):
+			return i, "Mon"
+		case strings.HasPrefix(rest, "Jan") && !startsLower(rest[3:]):
+			return i, "Jan"
+		case strings.HasPrefix(rest, "PM"):
+			return i, "PM"
+		case strings.HasPrefix(rest, "pm"):
+			return i, "pm"
+		}
+	}
+	return -1, ""
+}
+
+// startsLower reports whether s starts with a lowercase ASCII letter
+func startsLower(s string) bool {
+	return s != "" && s[0] >= 'a' && s[0] <= 'z'
+}
@@ -0,0 +1,248 @@
+// widgets.go - Analog, binary, countdown and away clock widgets
+package clock
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Style selects the clock widget
+type Style string
+
+const (
+	Digital   Style = "digital"   // Time in the FIGlet font
+	Analog    Style = "analog"    // ASCII clock face sized to the screen
+	Binary    Style = "binary"    // One BCD column per digit
+	Countdown Style = "countdown" // Time left until a target
+	Away      Style = "away"      // Time since the screensaver started
+)
+
+// styles lists the widgets in the order they are documented
+var styles = []Style{Digital, Analog, Binary, Countdown, Away}
+
+// Valid reports whether s names a widget
+func (s Style) Valid() bool {
+	for _, style := range styles {
+		if s == style {
+			return true
+		}
+	}
+	return false
+}
+
+// StyleNames returns the widget names
+func StyleNames() []string {
+	names := make([]string, len(styles))
+	for i, style := range styles {
+		names[i] = string(style)
+	}
+	return names
+}
+
+// ParseTarget reads a countdown target in local time: HH:MM for its next
+// occurrence after now, or a date as YYYY-MM-DD with an optional HH:MM
+func ParseTarget(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.ParseInLocation("15:04", value, time.Local); err == nil {
+		target := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local)
+		if !target.After(now) {
+			target = target.AddDate(0, 0, 1)
+		}
+		return target, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid countdown target %q (use HH:MM or YYYY-MM-DD HH:MM)", value)
+}
+
+// countdown returns the time left until the target, stopping at zero
+func (c *Clock) countdown(t time.Time) string {
+	left := c.target.Sub(t)
+	if left < 0 {
+		left = 0
+	}
+	if !c.seconds {
+		// Round up so the last minute reads 0:01, not 0:00
+		left = (left + time.Minute - 1).Truncate(time.Minute)
+	}
+
+	h := int(left / time.Hour)
+	m := int(left/time.Minute) % 60
+	if c.seconds {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, int(left/time.Second)%60)
+	}
+	return fmt.Sprintf("%d:%02d", h, m)
+}
+
+// away formats how long the screensaver has run, as in "12m" or "1h 05m"
+func away(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	m := int(d / time.Minute)
+	if m < 60 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh %02dm", m/60, m%60)
+}
+
+// Analog face size in rows, without a screen to size it to and at most
+const (
+	analogRows    = 15
+	analogMinRows = 9
+	analogMaxRows = 31
+)
+
+// analog draws a clock face half the screen's height. Cells are about twice
+// as tall as wide, so columns are doubled to keep the face round.
+func (c *Clock) analog(t time.Time, width, height int) []string {
+	rows := analogRows
+	if height > 0 {
+		rows = height / 2
+	}
+	if width > 0 && rows > width/2 {
+		rows = width / 2
+	}
+	rows = max(analogMinRows, min(rows, analogMaxRows))
+	if rows%2 == 0 {
+		rows--
+	}
+
+	r := rows / 2
+	cx, cy := 2*r, r
+	face := make([][]rune, rows)
+	for y := range face {
+		face[y] = []rune(strings.Repeat(" ", 4*r+1))
+	}
+
+	// at returns the cell at distance d (in rows) along angle a (in degrees
+	// clockwise from 12)
+	at := func(a, d float64) (int, int) {
+		rad := a * math.Pi / 180
+		return cx + int(math.Round(2*d*math.Sin(rad))), cy - int(math.Round(d*math.Cos(rad)))
+	}
+	plot := func(x, y int, ch rune) {
+		if y >= 0 && y < len(face) && x >= 0 && x < len(face[y]) {
+			face[y][x] = ch
+		}
+	}
+	hand := func(a, length float64, ch rune) {
+		for d := 0.5; d <= length; d += 0.25 {
+			x, y := at(a, d)
+			if ch == 0 {
+				plot(x, y, handRune(a))
+			} else {
+				plot(x, y, ch)
+			}
+		}
+	}
+
+	// Rim, then the hour marks on it
+	for a := 0.0; a < 360; a += 90 / float64(4*r) {
+		x, y := at(a, float64(r))
+		plot(x, y, '.')
+	}
+	for h := 1; h <= 12; h++ {
+		x, y := at(float64(h)*30, float64(r))
+		switch h {
+		case 12:
+			plot(x-1, y, '1')
+			plot(x, y, '2')
+		case 3, 6, 9:
+			plot(x, y, rune('0'+h))
+		default:
+			plot(x, y, 'o')
+		}
+	}
+
+	hours := float64(t.Hour()%12) + float64(t.Minute())/60
+	minutes := float64(t.Minute()) + float64(t.Second())/60
+	hand(hours*30, float64(r)*0.5, '#')
+	hand(minutes*6, float64(r)*0.8, 0)
+	if c.seconds {
+		hand(float64(t.Second())*6, float64(r)*0.85, '+')
+	}
+	plot(cx, cy, 'O')
+
+	lines := make([]string, rows)
+	for y, row := range face {
+		lines[y] = string(row)
+	}
+	return lines
+}
+
+// handRune picks the line character closest to the direction of a hand
+func handRune(a float64) rune {
+	switch octant := int(math.Mod(a+22.5, 180) / 45); octant {
+	case 0:
+		return '|'
+	case 1:
+		return '/'
+	case 2:
+		return '-'
+	default:
+		return '\\'
+	}
+}
+
+// Binary clock cells
+const (
+	bitOn  = "██"
+	bitOff = "░░"
+)
+
+// binary draws one column per digit of the time, its bits from 8 at the top
+// to 1 at the bottom, with the digits underneath. Tens columns only have the
+// bits their digit can use.
+func (c *Clock) binary(t time.Time) []string {
+	hour := t.Hour()
+	if c.pad {
+		// 12-hour clock, as the digital one shows
+		if hour = hour % 12; hour == 0 {
+			hour = 12
+		}
+	}
+
+	type column struct{ value, bits int }
+	groups := [][]column{
+		{{hour / 10, 2}, {hour % 10, 4}},
+		{{t.Minute() / 10, 3}, {t.Minute() % 10, 4}},
+	}
+	if c.seconds {
+		groups = append(groups, []column{{t.Second() / 10, 3}, {t.Second() % 10, 4}})
+	}
+
+	lines := make([]string, 5)
+	for g, group := range groups {
+		for i, col := range group {
+			sep := " "
+			if i == 0 && g > 0 {
+				sep = "   "
+			}
+			for bit := 3; bit >= 0; bit-- {
+				cell := "  "
+				if bit < col.bits {
+					cell = bitOff
+					if col.value&(1<<bit) != 0 {
+						cell = bitOn
+					}
+				}
+				if g > 0 || i > 0 {
+					lines[3-bit] += sep
+				}
+				lines[3-bit] += cell
+			}
+			if g > 0 || i > 0 {
+				lines[4] += sep
+			}
+			lines[4] += fmt.Sprintf("%-2d", col.value)
+		}
+	}
+	lines[4] = strings.TrimRight(lines[4], " ")
+	return lines
+}
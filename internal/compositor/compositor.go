@@ -15,6 +15,13 @@ type Output struct {
 	Focused    bool
 }
 
+// Window describes the focused toplevel window
+type Window struct {
+	AppID      string // Wayland app-id, or the X11 class for XWayland windows
+	Title      string
+	Fullscreen bool
+}
+
 // Compositor interface for compositor-specific operations
 type Compositor interface {
 	// ListOutputs returns all available outputs
@@ -26,6 +33,12 @@ type Compositor interface {
 	// FocusOutput focuses a specific output by name
 	FocusOutput(name string) error
 
+	// SetPower turns all outputs on or off (DPMS)
+	SetPower(on bool) error
+
+	// FocusedWindow returns the focused window, or nil if nothing has focus
+	FocusedWindow() (*Window, error)
+
 	// Name returns the compositor name
 	Name() string
 }
@@ -0,0 +1,78 @@
+package compositor
+
+import (
+	"testing"
+)
+
+// TestParseFocusedWindow tests focused window parsing for each compositor
+func TestParseFocusedWindow(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func() (*Window, error)
+		want  *Window
+	}{
+		{
+			name: "hyprland",
+			parse: func() (*Window, error) {
+				return NewHyprlandCompositor().parseWindow([]byte(`{"class":"code","title":"main.go","fullscreen":2}`))
+			},
+			want: &Window{AppID: "code", Title: "main.go", Fullscreen: true},
+		},
+		{
+			name: "hyprland legacy bool",
+			parse: func() (*Window, error) {
+				return NewHyprlandCompositor().parseWindow([]byte(`{"class":"kitty","title":"zsh","fullscreen":false}`))
+			},
+			want: &Window{AppID: "kitty", Title: "zsh"},
+		},
+		{
+			name: "hyprland no window",
+			parse: func() (*Window, error) {
+				return NewHyprlandCompositor().parseWindow([]byte(`{}`))
+			},
+		},
+		{
+			name: "sway xwayland",
+			parse: func() (*Window, error) {
+				return NewSwayCompositor().parseWindow([]byte(`{"nodes":[{"nodes":[
+					{"name":"a","app_id":"foot"},
+					{"name":"Slides","focused":true,"fullscreen_mode":1,"window_properties":{"class":"libreoffice"}}]}]}`))
+			},
+			want: &Window{AppID: "libreoffice", Title: "Slides", Fullscreen: true},
+		},
+		{
+			name: "sway floating",
+			parse: func() (*Window, error) {
+				return NewSwayCompositor().parseWindow([]byte(`{"nodes":[{"floating_nodes":[{"name":"mpv","app_id":"mpv","focused":true}]}]}`))
+			},
+			want: &Window{AppID: "mpv", Title: "mpv"},
+		},
+		{
+			name: "niri fullscreen",
+			parse: func() (*Window, error) {
+				return NewNiriCompositor().parseWindow(
+					[]byte(`{"title":"Video","app_id":"mpv","layout":{"window_size":[1920,1080]}}`),
+					[]byte(`{"name":"eDP-1","logical":{"width":1920,"height":1080}}`))
+			},
+			want: &Window{AppID: "mpv", Title: "Video", Fullscreen: true},
+		},
+		{
+			name: "niri no window",
+			parse: func() (*Window, error) {
+				return NewNiriCompositor().parseWindow([]byte(`null`), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse()
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -92,3 +92,55 @@ func (h *HyprlandCompositor) FocusOutput(name string) error {
 	}
 	return nil
 }
+
+// SetPower turns all outputs on or off via hyprctl dpms
+func (h *HyprlandCompositor) SetPower(on bool) error {
+	state := "off"
+	if on {
+		state = "on"
+	}
+	cmd := exec.Command("hyprctl", "dispatch", "dpms", state)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to set output power %s: %w", state, err)
+	}
+	return nil
+}
+
+// hyprlandWindow represents the active window in hyprctl's JSON output
+type hyprlandWindow struct {
+	Class string `json:"class"`
+	Title string `json:"title"`
+	// Older releases report a bool, newer ones a fullscreen mode number
+	Fullscreen json.RawMessage `json:"fullscreen"`
+}
+
+// FocusedWindow returns the active window
+func (h *HyprlandCompositor) FocusedWindow() (*Window, error) {
+	cmd := exec.Command("hyprctl", "activewindow", "-j")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run 'hyprctl activewindow -j': %w", err)
+	}
+
+	return h.parseWindow(output)
+}
+
+// parseWindow parses hyprctl's active window JSON
+func (h *HyprlandCompositor) parseWindow(data []byte) (*Window, error) {
+	var win hyprlandWindow
+	if err := json.Unmarshal(data, &win); err != nil {
+		return nil, fmt.Errorf("failed to parse hyprctl JSON: %w", err)
+	}
+
+	// hyprctl prints an empty object when no window has focus
+	if win.Class == "" && win.Title == "" {
+		return nil, nil
+	}
+
+	fullscreen := string(win.Fullscreen)
+	return &Window{
+		AppID:      win.Class,
+		Title:      win.Title,
+		Fullscreen: fullscreen != "" && fullscreen != "0" && fullscreen != "false",
+	}, nil
+}
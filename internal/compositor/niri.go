@@ -2,6 +2,7 @@
 package compositor
 
 import (
+	"encoding/json"
 	"fmt"
 	"os/exec"
 	"regexp"
@@ -98,3 +99,75 @@ func (n *NiriCompositor) FocusOutput(name string) error {
 	}
 	return nil
 }
+
+// SetPower turns all outputs on or off via niri's power actions
+func (n *NiriCompositor) SetPower(on bool) error {
+	action := "power-off-monitors"
+	if on {
+		action = "power-on-monitors"
+	}
+	cmd := exec.Command("niri", "msg", "action", action)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run 'niri msg action %s': %w", action, err)
+	}
+	return nil
+}
+
+// niriWindow represents a window in niri's JSON output
+type niriWindow struct {
+	Title  string `json:"title"`
+	AppID  string `json:"app_id"`
+	Layout struct {
+		WindowSize []int `json:"window_size"`
+	} `json:"layout"`
+}
+
+// niriOutput represents an output in niri's JSON output
+type niriOutput struct {
+	Logical *struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"logical"`
+}
+
+// FocusedWindow returns the focused window
+func (n *NiriCompositor) FocusedWindow() (*Window, error) {
+	cmd := exec.Command("niri", "msg", "--json", "focused-window")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run 'niri msg --json focused-window': %w", err)
+	}
+
+	// niri has no fullscreen flag, so compare against the output size
+	cmd = exec.Command("niri", "msg", "--json", "focused-output")
+	outputJSON, err := cmd.Output()
+	if err != nil {
+		outputJSON = nil
+	}
+
+	return n.parseWindow(output, outputJSON)
+}
+
+// parseWindow parses niri's focused window and focused output JSON
+func (n *NiriCompositor) parseWindow(windowJSON, outputJSON []byte) (*Window, error) {
+	var win *niriWindow
+	if err := json.Unmarshal(windowJSON, &win); err != nil {
+		return nil, fmt.Errorf("failed to parse niri JSON: %w", err)
+	}
+
+	// niri prints null when no window has focus
+	if win == nil {
+		return nil, nil
+	}
+
+	window := &Window{AppID: win.AppID, Title: win.Title}
+
+	var out niriOutput
+	if len(outputJSON) > 0 && json.Unmarshal(outputJSON, &out) == nil && out.Logical != nil {
+		size := win.Layout.WindowSize
+		window.Fullscreen = len(size) == 2 &&
+			size[0] >= out.Logical.Width && size[1] >= out.Logical.Height
+	}
+
+	return window, nil
+}
@@ -100,3 +100,88 @@ func (s *SwayCompositor) FocusOutput(name string) error {
 	}
 	return nil
 }
+
+// SetPower turns all outputs on or off via swaymsg
+func (s *SwayCompositor) SetPower(on bool) error {
+	state := "off"
+	if on {
+		state = "on"
+	}
+	cmd := exec.Command("swaymsg", "output", "*", "power", state)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to set output power %s: %w", state, err)
+	}
+	return nil
+}
+
+// swayNode represents a node in swaymsg's tree output
+type swayNode struct {
+	Focused          bool   `json:"focused"`
+	Name             string `json:"name"`
+	AppID            string `json:"app_id"`
+	FullscreenMode   int    `json:"fullscreen_mode"`
+	WindowProperties struct {
+		Class string `json:"class"`
+	} `json:"window_properties"`
+	Nodes         []swayNode `json:"nodes"`
+	FloatingNodes []swayNode `json:"floating_nodes"`
+}
+
+// FocusedWindow returns the focused view from the sway tree
+func (s *SwayCompositor) FocusedWindow() (*Window, error) {
+	cmd := exec.Command("swaymsg", "-t", "get_tree")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run 'swaymsg -t get_tree': %w", err)
+	}
+
+	return s.parseWindow(output)
+}
+
+// parseWindow finds the focused view in swaymsg's tree JSON
+func (s *SwayCompositor) parseWindow(data []byte) (*Window, error) {
+	var root swayNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse swaymsg JSON: %w", err)
+	}
+
+	node := findFocusedSwayNode(&root)
+	if node == nil {
+		return nil, nil
+	}
+
+	// XWayland views have no app_id, only a class
+	appID := node.AppID
+	if appID == "" {
+		appID = node.WindowProperties.Class
+	}
+
+	// A focused workspace or output has neither
+	if appID == "" {
+		return nil, nil
+	}
+
+	return &Window{
+		AppID:      appID,
+		Title:      node.Name,
+		Fullscreen: node.FullscreenMode != 0,
+	}, nil
+}
+
+// findFocusedSwayNode walks the tree for the focused node
+func findFocusedSwayNode(node *swayNode) *swayNode {
+	if node.Focused {
+		return node
+	}
+	for i := range node.Nodes {
+		if found := findFocusedSwayNode(&node.Nodes[i]); found != nil {
+			return found
+		}
+	}
+	for i := range node.FloatingNodes {
+		if found := findFocusedSwayNode(&node.FloatingNodes[i]); found != nil {
+			return found
+		}
+	}
+	return nil
+}
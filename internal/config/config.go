@@ -4,28 +4,43 @@ package config
 import (
 	"bufio"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
-
-	syscGo "github.com/Nomadcxx/sysc-Go/animations"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Nomadcxx/sysc-walls/internal/animations"
+	"github.com/Nomadcxx/sysc-walls/internal/art"
+	"github.com/Nomadcxx/sysc-walls/internal/clock"
+	"github.com/Nomadcxx/sysc-walls/internal/figlet"
+	"github.com/Nomadcxx/sysc-walls/internal/imageart"
+	"github.com/Nomadcxx/sysc-walls/internal/schedule"
+	syscGo "github.com/Nomadcxx/sysc-walls/internal/sysc-go-fork/animations"
+	"github.com/Nomadcxx/sysc-walls/internal/theme"
 )
 
-// Available animation effects - auto-generated from sysc-Go registry
-var AvailableEffects = syscGo.GetEffectNames()
+// Available animation effects - from the effect registry, which
+// animations.CheckRegistry compares with sysc-Go's
+var AvailableEffects = animations.Names()
 
-// Available color themes - auto-generated from sysc-Go registry
+// Available color themes - auto-generated from sysc-Go registry. Theme
+// files in ~/.config/sysc-walls/themes add to these (see theme.Names).
 var AvailableThemes = syscGo.GetThemeNames()
 
 // MinimumSyscGoVersion is the minimum required version of sysc-Go
 const MinimumSyscGoVersion = "1.0.1"
 
-// findDisplayBinary locates sysc-walls-display in standard locations
-func findDisplayBinary() (string, error) {
+// findDisplayBinary locates sysc-walls-display in standard locations. It is
+// a variable so tests can stand in for an installed display.
+var findDisplayBinary = func() (string, error) {
 	// Try PATH first (works for both /usr/bin and /usr/local/bin)
 	if path, err := exec.LookPath("sysc-walls-display"); err == nil {
 		return path, nil
@@ -33,9 +48,9 @@ func findDisplayBinary() (string, error) {
 
 	// Fallback to common locations
 	locations := []string{
-		"/usr/bin/sysc-walls-display",           // AUR/system package
-		"/usr/local/bin/sysc-walls-display",     // Manual install
-		"./sysc-walls-display",                  // Current directory (development)
+		"/usr/bin/sysc-walls-display",       // AUR/system package
+		"/usr/local/bin/sysc-walls-display", // Manual install
+		"./sysc-walls-display",              // Current directory (development)
 	}
 
 	for _, loc := range locations {
@@ -49,32 +64,166 @@ func findDisplayBinary() (string, error) {
 
 // Config represents the daemon configuration
 type Config struct {
-	idleTimeout         time.Duration
-	minDuration         time.Duration
-	debug               bool
-	animationEffect     string
-	animationTheme      string
-	animationFile       string // Custom artwork file path for text-based effects
-	animationDatetime   bool   // Show date/time overlay (only for non-text effects)
-	datetimePosition    string // Position of datetime: "top", "center", "bottom"
-	cycleAnimations     bool
-	terminalKitty       bool
-	terminalFullscreen  bool
+	idleTimeout          time.Duration
+	minDuration          time.Duration
+	debug                bool
+	animationEffect      string
+	animationTheme       string
+	animationThemeSource string         // Color scheme the auto theme follows, "" for pywal then the desktop portal
+	animationFile        string         // Art for text-based effects: a file, directory or glob path, or "!command"
+	animationFileCommand bool           // Lets a "!command" animationFile run; other sources must be safe paths
+	animationArtInterval time.Duration  // How often a rotating art source moves on while running, 0 for never
+	animationText        string         // Text drawn in animationFont for text-based effects, instead of a file
+	animationFont        string         // FIGlet font name or .flf path for animationText
+	animationTextSource  string         // "file" for animationFile or animationText, "clock" for the live time
+	animationFit         animations.Fit // How text-based effects fit their art to the screen
+	animationDatetime    bool           // Show date/time overlay (only for non-text effects)
+	datetimePosition     string         // Position of datetime: "top", "center", "bottom"
+	datetimeFont         string         // FIGlet font name or .flf path for the clock
+	datetimeFormat       string         // "12h", "24h" or a Go time layout
+	datetimeSeconds      bool           // Show seconds with 12h and 24h
+	datetimeLocale       string         // Locale of weekday and month names, or "auto"
+	datetimeZones        []string       // "Label=Area/City" clocks shown side by side
+	datetimeStyle        string         // Clock widget: digital, analog, binary, countdown or away
+	datetimeCountdown    string         // Countdown target, HH:MM or YYYY-MM-DD HH:MM
+	awayStyle            string         // Away message style: "center" or "banner"
+	awayFont             string         // FIGlet font name or .flf path for short away messages
+	imageMode            string         // How image art is drawn: "blocks" or "ramp"
+	imageThreshold       float64        // Ink from 0 to 1 an image pixel needs to be drawn
+	imageDither          bool           // Dither image art
+	imageInvert          bool           // Draw the dark parts of images
+	imageColors          bool           // Draw image art in the image's colors
+	cycleAnimations      bool
+	animationFPS         int // Display frame rate; effects run at the same speed at any rate, moving at most once per step
+	terminalKitty        bool
+	terminalFullscreen   bool
+	powerEnabled         bool                         // Turn outputs off after the screensaver has run a while
+	powerTimeout         time.Duration                // How long the screensaver runs before outputs go dark
+	powerMethod          string                       // Power backend: "auto", "wlr", "ipc"
+	powerDisplay         string                       // What to do with displays while dark: "stop", "pause"
+	sessionLogind        bool                         // Follow the logind session (VT switches, lock, sleep)
+	consoleMode          string                       // Virtual console mode: "auto", "on", "off"
+	consoleVT            int                          // VT to draw on, 0 for the first free one
+	policies             map[string]PowerPolicy       // Overrides keyed by power state (ac, battery, low-battery, profile name)
+	policyLowBattery     int                          // Battery percentage at or below which "low-battery" applies
+	policyProfiles       bool                         // Read power-profiles-daemon state for policy selection
+	scheduleRules        []schedule.Rule              // Time-of-day rules in config file order
+	appRules             []AppRule                    // Focused-window rules in config file order
+	widgets              []Widget                     // Info widgets in config file order
+	effectParams         map[string]animations.Params // Parameters of effects from [effect.<name>] sections
+}
+
+// PowerPolicy overrides screensaver behavior for a power state
+// Zero values mean "keep the configured default"
+type PowerPolicy struct {
+	Effects       []string      // Effects to pick from instead of animation.effect
+	FPS           int           // Display frame rate
+	NoScreensaver bool          // Skip the animation and power outputs off directly
+	Timeout       time.Duration // Idle timeout
+}
+
+// Merge applies the non-zero fields of other on top of p
+func (p PowerPolicy) Merge(other PowerPolicy) PowerPolicy {
+	if len(other.Effects) > 0 {
+		p.Effects = other.Effects
+	}
+	if other.FPS > 0 {
+		p.FPS = other.FPS
+	}
+	if other.NoScreensaver {
+		p.NoScreensaver = true
+	}
+	if other.Timeout > 0 {
+		p.Timeout = other.Timeout
+	}
+	return p
 }
 
+// AppRule changes idle behavior while a matching window has focus
+type AppRule struct {
+	Pattern      string  // App-id glob, matched case-insensitively
+	Fullscreen   bool    // Only match while the window is fullscreen
+	Inhibit      bool    // Never start the screensaver
+	TimeoutScale float64 // Idle timeout multiplier above 1, 0 to keep the timeout
+	Effect       string  // Effect to run instead of animation.effect
+}
+
+// Widget is an info widget drawn over the animation, from a
+// [widget.<kind>] section
+type Widget struct {
+	Kind     string        // One of AvailableWidgets
+	Position string        // One of WidgetPositions
+	Interval time.Duration // Refresh interval, 0 for the widget's default
+	File     string        // File the status widget shows
+}
+
+// AvailableWidgets are the widget kinds the display can draw
+var AvailableWidgets = []string{"hostname", "battery", "cpu", "nowplaying", "notifications", "status"}
+
+// WidgetPositions are where widgets can be placed on the screen
+var WidgetPositions = []string{"top-left", "top", "top-right", "left", "center", "right", "bottom-left", "bottom", "bottom-right"}
+
+// Arg returns the widget as a display --widget value
+func (w Widget) Arg() string {
+	arg := w.Kind + ",position=" + w.Position
+	if w.Interval > 0 {
+		arg += ",interval=" + w.Interval.String()
+	}
+	if w.File != "" {
+		arg += ",file=" + w.File
+	}
+	return arg
+}
+
+// LaunchOptions overrides parts of the screensaver command for a single launch
+type LaunchOptions struct {
+	Effect   string // Effect to run instead of animation.effect
+	Theme    string // Theme to use instead of animation.theme
+	FPS      int    // Frame rate passed to the display, 0 for the display default
+	Datetime *bool  // Datetime overlay instead of animation.datetime
+}
+
+// defaultFit leaves art as drawn, cropping the middle of art too large
+var defaultFit = animations.Fit{Mode: animations.FitNone, Anchor: "center"}
+
 // NewConfig creates a new configuration instance
 func NewConfig() *Config {
 	return &Config{
-		idleTimeout:        300 * time.Second, // 5 minutes default
-		minDuration:        30 * time.Second,  // 30 seconds default
-		debug:              false,
-		animationEffect:    "matrix-art",
-		animationTheme:     "rama",
-		animationDatetime:  false,    // datetime overlay disabled by default
-		datetimePosition:   "bottom", // datetime position: top, center, or bottom
-		cycleAnimations:    false,
-		terminalKitty:      true,
-		terminalFullscreen: true,
+		idleTimeout:         300 * time.Second, // 5 minutes default
+		minDuration:         30 * time.Second,  // 30 seconds default
+		debug:               false,
+		animationEffect:     "matrix-art",
+		animationTheme:      "rama",
+		animationDatetime:   false,    // datetime overlay disabled by default
+		datetimePosition:    "bottom", // datetime position: top, center, or bottom
+		animationFont:       "block",
+		animationTextSource: "file",
+		animationFit:        defaultFit,
+		awayStyle:           "center",
+		awayFont:            "block",
+		imageMode:           imageart.DefaultOptions.Mode,
+		imageThreshold:      imageart.DefaultOptions.Threshold,
+		imageColors:         true,
+		datetimeFont:        "kompaktblk",
+		datetimeFormat:      "12h",
+		datetimeSeconds:     true,
+		datetimeLocale:      clock.DefaultLocale,
+		datetimeStyle:       string(clock.Digital),
+		cycleAnimations:     false,
+		animationFPS:        20,
+		terminalKitty:       true,
+		terminalFullscreen:  true,
+		powerEnabled:        false,
+		powerTimeout:        10 * time.Minute,
+		powerMethod:         "auto",
+		powerDisplay:        "stop",
+		sessionLogind:       true,
+		consoleMode:         "auto",
+		consoleVT:           0,
+		policies:            map[string]PowerPolicy{},
+		policyLowBattery:    20,
+		policyProfiles:      false,
+		effectParams:        map[string]animations.Params{},
 	}
 }
 
@@ -163,30 +312,180 @@ func (c *Config) parseConfigLine(key, value string) {
 			fmt.Fprintf(os.Stderr, "Available effects: %s\n", strings.Join(AvailableEffects, ", "))
 		}
 	case "animation.theme":
-		if IsValidTheme(value) {
+		if t, err := theme.Find(value); err == nil {
 			c.animationTheme = value
+			warnMissingRoles(t)
 		} else {
-			fmt.Fprintf(os.Stderr, "Warning: Invalid animation theme '%s' in config file. Using default.\n", value)
-			fmt.Fprintf(os.Stderr, "Available themes: %s\n", strings.Join(AvailableThemes, ", "))
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation theme '%s' in config file: %v. Using default.\n", value, err)
+		}
+	case "animation.theme_source":
+		if value == "" {
+			c.animationThemeSource = ""
+			break
+		}
+		if expandedPath, err := expandPath(value); err == nil {
+			c.animationThemeSource = expandedPath
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation theme_source '%s': %v. Ignoring.\n", value, err)
 		}
 	case "animation.file":
-		// Expand environment variables and home directory
-		expandedPath := os.ExpandEnv(value)
-		// Only expand ~ if HOME is set and valid
-		if strings.HasPrefix(expandedPath, "~") {
-			homeDir := os.Getenv("HOME")
-			if homeDir != "" && filepath.IsAbs(homeDir) {
-				expandedPath = strings.Replace(expandedPath, "~", homeDir, 1)
-			} else {
-				fmt.Fprintf(os.Stderr, "Warning: Cannot expand '~' - HOME not set or invalid. Ignoring '%s'.\n", value)
+		// A command is run by the display, its output is the art
+		if strings.HasPrefix(value, art.CommandPrefix) {
+			if strings.TrimSpace(strings.TrimPrefix(value, art.CommandPrefix)) == "" {
+				fmt.Fprintf(os.Stderr, "Warning: Animation file command is empty. Ignoring.\n")
 				break
 			}
+			c.animationFile = value
+			break
 		}
-		// Validate that file path is absolute
-		if filepath.IsAbs(expandedPath) {
+		if value == "" {
+			c.animationFile = ""
+			break
+		}
+		// Expand environment variables and home directory
+		if expandedPath, err := expandPath(value); err == nil {
 			c.animationFile = expandedPath
 		} else {
-			fmt.Fprintf(os.Stderr, "Warning: Animation file path must be absolute, got '%s'. Ignoring.\n", value)
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation file '%s': %v. Ignoring.\n", value, err)
+		}
+	case "animation.file_command":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			c.animationFileCommand = boolVal
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation file_command '%s': %v. Ignoring.\n", value, err)
+		}
+	case "animation.art_interval":
+		if duration, err := parseDuration(value); err == nil {
+			c.animationArtInterval = duration
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation art_interval '%s': %v. Using default.\n", value, err)
+		}
+	case "animation.text":
+		if text, err := parseText(value); err == nil {
+			c.animationText = text
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation text '%s': %v. Ignoring.\n", value, err)
+		}
+	case "animation.font":
+		if font, err := parseFont(value); err == nil {
+			c.animationFont = font
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation font '%s': %v. Using default.\n", value, err)
+		}
+	case "away.style":
+		switch style := strings.ToLower(value); style {
+		case "center", "banner":
+			c.awayStyle = style
+		default:
+			fmt.Fprintf(os.Stderr, "Warning: Invalid away style '%s' (use center or banner). Using default.\n", value)
+		}
+	case "away.font":
+		if font, err := parseFont(value); err == nil {
+			c.awayFont = font
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid away font '%s': %v. Using default.\n", value, err)
+		}
+	case "image.mode":
+		if mode := strings.ToLower(value); slices.Contains(imageart.Modes, mode) {
+			c.imageMode = mode
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid image mode '%s' (use %s). Using default.\n", value, strings.Join(imageart.Modes, " or "))
+		}
+	case "image.threshold":
+		if threshold, err := strconv.ParseFloat(value, 64); err == nil && threshold >= 0 && threshold <= 1 {
+			c.imageThreshold = threshold
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid image threshold '%s' (use 0.0 to 1.0). Using default.\n", value)
+		}
+	case "image.dither":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			c.imageDither = boolVal
+		}
+	case "image.invert":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			c.imageInvert = boolVal
+		}
+	case "image.colors":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			c.imageColors = boolVal
+		}
+	case "animation.text_source":
+		switch source := strings.ToLower(value); source {
+		case "file", "clock":
+			c.animationTextSource = source
+		default:
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation text_source '%s' (use file or clock). Using default.\n", value)
+		}
+	case "animation.fit":
+		if mode := strings.ToLower(value); slices.Contains(animations.FitModes, mode) {
+			c.animationFit.Mode = mode
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation fit '%s' (use %s). Using default.\n", value, strings.Join(animations.FitModes, ", "))
+		}
+	case "animation.fit_anchor":
+		if anchor := strings.ToLower(value); slices.Contains(animations.FitAnchors, anchor) {
+			c.animationFit.Anchor = anchor
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation fit_anchor '%s' (use %s). Using default.\n", value, strings.Join(animations.FitAnchors, ", "))
+		}
+	case "animation.fit_padding":
+		if padding, err := strconv.Atoi(value); err == nil && padding >= 0 {
+			c.animationFit.Padding = padding
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid animation fit_padding '%s'. Using default.\n", value)
+		}
+	case "datetime.font":
+		if font, err := parseFont(value); err == nil {
+			c.datetimeFont = font
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid datetime font '%s': %v. Using default.\n", value, err)
+		}
+	case "datetime.format":
+		format, err := parseText(value)
+		if err == nil {
+			_, err = clock.ParseFormat(format, true)
+		}
+		if err == nil {
+			c.datetimeFormat = format
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid datetime format '%s': %v. Using default.\n", value, err)
+		}
+	case "datetime.seconds":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			c.datetimeSeconds = boolVal
+		}
+	case "datetime.locale":
+		if _, ok := clock.LookupLocale(value); ok || value == "auto" {
+			c.datetimeLocale = value
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid datetime locale '%s'. Using default.\n", value)
+			fmt.Fprintf(os.Stderr, "Available locales: %s\n", strings.Join(clock.Locales(), ", "))
+		}
+	case "datetime.zones":
+		var zones []string
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry == "" {
+				continue
+			}
+			if _, err := clock.ParseZone(entry); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Invalid datetime zone '%s': %v. Ignoring.\n", entry, err)
+				continue
+			}
+			zones = append(zones, entry)
+		}
+		c.datetimeZones = zones
+	case "datetime.style":
+		if style := clock.Style(strings.ToLower(value)); style.Valid() {
+			c.datetimeStyle = string(style)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid datetime style '%s'. Using default.\n", value)
+			fmt.Fprintf(os.Stderr, "Available styles: %s\n", strings.Join(clock.StyleNames(), ", "))
+		}
+	case "datetime.countdown":
+		if _, err := clock.ParseTarget(value, time.Now()); err == nil {
+			c.datetimeCountdown = strings.TrimSpace(value)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: %v. Ignoring.\n", err)
 		}
 	case "animation.datetime":
 		if boolVal, err := strconv.ParseBool(value); err == nil {
@@ -208,6 +507,12 @@ func (c *Config) parseConfigLine(key, value string) {
 		if boolVal, err := strconv.ParseBool(value); err == nil {
 			c.cycleAnimations = boolVal
 		}
+	case "animation.fps":
+		if fps, err := strconv.Atoi(value); err == nil && fps > 0 && fps <= 120 {
+			c.animationFPS = fps
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid fps '%s'. Must be 1-120. Using default.\n", value)
+		}
 	case "terminal.kitty":
 		if boolVal, err := strconv.ParseBool(value); err == nil {
 			c.terminalKitty = boolVal
@@ -216,9 +521,323 @@ func (c *Config) parseConfigLine(key, value string) {
 		if boolVal, err := strconv.ParseBool(value); err == nil {
 			c.terminalFullscreen = boolVal
 		}
+	case "power.enabled":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			c.powerEnabled = boolVal
+		}
+	case "power.timeout":
+		if duration, err := parseDuration(value); err == nil {
+			c.powerTimeout = duration
+		}
+	case "power.method":
+		value = strings.ToLower(value)
+		if value == "auto" || value == "wlr" || value == "ipc" {
+			c.powerMethod = value
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid power method '%s'. Must be auto, wlr, or ipc. Using default.\n", value)
+		}
+	case "power.display":
+		value = strings.ToLower(value)
+		if value == "stop" || value == "pause" {
+			c.powerDisplay = value
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid power display action '%s'. Must be stop or pause. Using default.\n", value)
+		}
+	case "session.logind":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			c.sessionLogind = boolVal
+		}
+	case "console.mode":
+		value = strings.ToLower(value)
+		if value == "auto" || value == "on" || value == "off" {
+			c.consoleMode = value
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid console mode '%s'. Must be auto, on, or off. Using default.\n", value)
+		}
+	case "console.vt":
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 63 {
+			c.consoleVT = n
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid console vt '%s'. Must be 1-63, or 0 for the first free one. Using default.\n", value)
+		}
+	case "policy.low_battery":
+		if percent, err := strconv.Atoi(value); err == nil && percent >= 0 && percent <= 100 {
+			c.policyLowBattery = percent
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid policy.low_battery '%s'. Must be 0-100. Using default.\n", value)
+		}
+	case "policy.profiles":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			c.policyProfiles = boolVal
+		}
+	default:
+		if strings.HasPrefix(key, "policy.") {
+			c.parsePolicyLine(strings.TrimPrefix(key, "policy."), value)
+		} else if strings.HasPrefix(key, "schedule.") {
+			c.parseScheduleLine(strings.TrimPrefix(key, "schedule."), value)
+		} else if strings.HasPrefix(key, "rules.") {
+			c.parseAppRuleLine(strings.TrimPrefix(key, "rules."), value)
+		} else if strings.HasPrefix(key, "widget.") {
+			c.parseWidgetLine(strings.TrimPrefix(key, "widget."), value)
+		} else if strings.HasPrefix(key, "effect.") {
+			c.parseEffectLine(strings.TrimPrefix(key, "effect."), value)
+		}
 	}
 }
 
+// parseScheduleLine parses a key from a [schedule.<name>] section
+func (c *Config) parseScheduleLine(key, value string) {
+	dot := strings.LastIndex(key, ".")
+	if dot <= 0 {
+		return
+	}
+	name, field := key[:dot], key[dot+1:]
+
+	// Rules keep the order their sections first appear in
+	idx := -1
+	for i, rule := range c.scheduleRules {
+		if rule.Name == name {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		c.scheduleRules = append(c.scheduleRules, schedule.Rule{Name: name, Days: schedule.AllDays})
+		idx = len(c.scheduleRules) - 1
+	}
+	rule := &c.scheduleRules[idx]
+
+	switch field {
+	case "days":
+		days, err := schedule.ParseDays(value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v in [schedule.%s]. Using every day.\n", err, name)
+			break
+		}
+		rule.Days = days
+	case "time":
+		start, end, err := schedule.ParseTimeRange(value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v in [schedule.%s]. Using the whole day.\n", err, name)
+			break
+		}
+		rule.Start, rule.End = start, end
+	case "effect":
+		if IsValidEffect(value) {
+			rule.Override.Effect = value
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid effect '%s' in [schedule.%s]. Ignoring.\n", value, name)
+		}
+	case "theme":
+		if t, err := theme.Find(value); err == nil {
+			rule.Override.Theme = value
+			warnMissingRoles(t)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid theme '%s' in [schedule.%s]: %v. Ignoring.\n", value, name, err)
+		}
+	case "timeout":
+		if duration, err := parseDuration(value); err == nil {
+			rule.Override.Timeout = duration
+		}
+	case "datetime":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			rule.Override.Datetime = &boolVal
+		}
+	case "power":
+		value = strings.ToLower(value)
+		if value == schedule.PowerOff || value == schedule.PowerOn {
+			rule.Override.Power = value
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid power '%s' in [schedule.%s]. Must be on or off.\n", value, name)
+		}
+	}
+}
+
+// parseWidgetLine parses a key from a [widget.<kind>] section
+func (c *Config) parseWidgetLine(key, value string) {
+	dot := strings.LastIndex(key, ".")
+	if dot <= 0 {
+		return
+	}
+	kind, field := key[:dot], key[dot+1:]
+	if !slices.Contains(AvailableWidgets, kind) {
+		fmt.Fprintf(os.Stderr, "Warning: Unknown widget [widget.%s]. Available widgets: %s\n", kind, strings.Join(AvailableWidgets, ", "))
+		return
+	}
+
+	// Widgets keep the order their sections first appear in
+	idx := -1
+	for i, widget := range c.widgets {
+		if widget.Kind == kind {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		c.widgets = append(c.widgets, Widget{Kind: kind, Position: "top-left"})
+		idx = len(c.widgets) - 1
+	}
+	widget := &c.widgets[idx]
+
+	switch field {
+	case "position":
+		value = strings.ToLower(value)
+		if slices.Contains(WidgetPositions, value) {
+			widget.Position = value
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid position '%s' in [widget.%s]. Must be one of %s.\n", value, kind, strings.Join(WidgetPositions, ", "))
+		}
+	case "interval":
+		if duration, err := parseDuration(value); err == nil && duration >= time.Second {
+			widget.Interval = duration
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid interval '%s' in [widget.%s]. Must be 1s or more.\n", value, kind)
+		}
+	case "file":
+		path, err := expandPath(value)
+		if err == nil && strings.Contains(path, ",") {
+			err = fmt.Errorf("path contains a comma")
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid file '%s' in [widget.%s]: %v. Ignoring.\n", value, kind, err)
+			break
+		}
+		widget.File = path
+	}
+}
+
+// parseEffectLine parses a parameter from an [effect.<name>] section,
+// checking it against the parameters the effect declares
+func (c *Config) parseEffectLine(key, value string) {
+	dot := strings.LastIndex(key, ".")
+	if dot <= 0 {
+		return
+	}
+	effect, name := key[:dot], key[dot+1:]
+	if !IsValidEffect(effect) {
+		fmt.Fprintf(os.Stderr, "Warning: Unknown effect [effect.%s]. Available effects: %s\n", effect, strings.Join(AvailableEffects, ", "))
+		return
+	}
+	if err := (animations.Params{name: value}).Validate(effect); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v in [effect.%s]. Using the default.\n", err, effect)
+		return
+	}
+
+	if c.effectParams[effect] == nil {
+		c.effectParams[effect] = animations.Params{}
+	}
+	c.effectParams[effect][name] = value
+}
+
+// expandPath expands environment variables and a leading ~ in a path and
+// checks that the result is absolute
+func expandPath(value string) (string, error) {
+	expandedPath := os.ExpandEnv(value)
+	if strings.HasPrefix(expandedPath, "~") {
+		homeDir := os.Getenv("HOME")
+		if homeDir == "" || !filepath.IsAbs(homeDir) {
+			return "", fmt.Errorf("cannot expand '~' - HOME not set or invalid")
+		}
+		expandedPath = strings.Replace(expandedPath, "~", homeDir, 1)
+	}
+	if !filepath.IsAbs(expandedPath) {
+		return "", fmt.Errorf("path must be absolute")
+	}
+	return expandedPath, nil
+}
+
+// parseAppRuleLine parses "<app-id> = action, action" from the [rules] section.
+// Actions are inhibit, timeout xN, effect <name> and the fullscreen qualifier.
+func (c *Config) parseAppRuleLine(pattern, value string) {
+	rule := AppRule{Pattern: strings.ToLower(pattern)}
+	if _, err := path.Match(rule.Pattern, ""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Invalid app-id pattern '%s' in [rules]. Ignoring.\n", pattern)
+		return
+	}
+
+	for _, action := range strings.Split(value, ",") {
+		fields := strings.Fields(strings.ToLower(action))
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case fields[0] == "inhibit" && len(fields) == 1:
+			rule.Inhibit = true
+		case fields[0] == "fullscreen" && len(fields) == 1:
+			rule.Fullscreen = true
+		case fields[0] == "timeout" && len(fields) == 2:
+			scale, err := strconv.ParseFloat(strings.TrimPrefix(fields[1], "x"), 64)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Invalid timeout multiplier '%s' for '%s' in [rules]. Ignoring.\n", fields[1], pattern)
+				continue
+			}
+			// Idle has already fired by the time a rule is checked, so a
+			// rule can only lengthen the timeout
+			if scale <= 1 {
+				fmt.Fprintf(os.Stderr, "Warning: Timeout multiplier '%s' in [rules] line '%s = %s' must be above 1. Ignoring.\n", fields[1], pattern, strings.TrimSpace(value))
+				continue
+			}
+			rule.TimeoutScale = scale
+		case fields[0] == "effect" && len(fields) == 2:
+			if !IsValidEffect(fields[1]) {
+				fmt.Fprintf(os.Stderr, "Warning: Invalid effect '%s' for '%s' in [rules]. Ignoring.\n", fields[1], pattern)
+				continue
+			}
+			rule.Effect = fields[1]
+		default:
+			fmt.Fprintf(os.Stderr, "Warning: Unknown action '%s' for '%s' in [rules]. Ignoring.\n", strings.TrimSpace(action), pattern)
+		}
+	}
+
+	c.appRules = append(c.appRules, rule)
+}
+
+// parsePolicyLine parses a key from a [policy.<state>] section
+func (c *Config) parsePolicyLine(key, value string) {
+	dot := strings.LastIndex(key, ".")
+	if dot <= 0 {
+		return
+	}
+	state, field := key[:dot], key[dot+1:]
+	policy := c.policies[state]
+
+	switch field {
+	case "effects":
+		var effects []string
+		for _, effect := range strings.Split(value, ",") {
+			effect = strings.TrimSpace(effect)
+			if effect == "" {
+				continue
+			}
+			if !IsValidEffect(effect) {
+				fmt.Fprintf(os.Stderr, "Warning: Invalid effect '%s' in [policy.%s]. Ignoring.\n", effect, state)
+				continue
+			}
+			effects = append(effects, effect)
+		}
+		policy.Effects = effects
+	case "fps":
+		if fps, err := strconv.Atoi(value); err == nil && fps > 0 && fps <= 120 {
+			policy.FPS = fps
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: Invalid fps '%s' in [policy.%s]. Must be 1-120.\n", value, state)
+		}
+	case "screensaver":
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			policy.NoScreensaver = !boolVal
+		}
+	case "timeout":
+		if duration, err := parseDuration(value); err == nil {
+			policy.Timeout = duration
+		}
+	default:
+		return
+	}
+
+	c.policies[state] = policy
+}
+
 // parseDuration parses a duration string (supports seconds, minutes, etc.)
 // Maximum duration is 24 hours to prevent overflow
 func parseDuration(value string) (time.Duration, error) {
@@ -312,13 +931,91 @@ func (c *Config) createDefaultConfig(configPath string) error {
 		fmt.Sprintf("effect = %s", c.animationEffect),
 		"# Available effects: " + strings.Join(AvailableEffects, ", "),
 		fmt.Sprintf("theme = %s", c.animationTheme),
-		"# Available themes: " + strings.Join(AvailableThemes, ", "),
+		"# Available themes: " + strings.Join(theme.Names(), ", "),
+		"# More themes: ~/.config/sysc-walls/themes/<name>.theme, or 'sysc-walls theme import'",
+		"# Color scheme theme = auto follows: a pywal colors.json, kitty .conf or base16 .yaml.",
+		"# Empty for ~/.cache/wal/colors.json, then the desktop's color scheme and accent color",
+		fmt.Sprintf("theme_source = %s", c.animationThemeSource),
 		fmt.Sprintf("cycle = %t", c.cycleAnimations),
+		"# Frame rate (1-120). Effects move 20 times a second, times their speed; higher rates",
+		"# redraw the same effect frame and only smooth the clock and other overlays",
+		fmt.Sprintf("fps = %d", c.animationFPS),
+		"# Art for text-based effects: a text or image file, a directory or glob to rotate through, or !command",
+		fmt.Sprintf("file = %s", c.animationFile),
+		"# Let file run a !command. It runs as you with sh -c each time art is needed",
+		fmt.Sprintf("file_command = %t", c.animationFileCommand),
+		"# Show the next art from a directory, glob or command this often while running, 0s to keep it",
+		fmt.Sprintf("art_interval = %s", formatDuration(c.animationArtInterval)),
+		"# Text for text-based effects instead of an art file, e.g. text = BRB",
+		fmt.Sprintf("text = %s", c.animationText),
+		"# FIGlet font for text: " + strings.Join(figlet.Names(), ", ") + " or a .flf path",
+		fmt.Sprintf("font = %s", c.animationFont),
+		"# Text source of text-based effects: file (file or text above) or clock for the live time in font",
+		fmt.Sprintf("text_source = %s", c.animationTextSource),
+		"# Fit art to the screen: " + strings.Join(animations.FitModes, ", "),
+		fmt.Sprintf("fit = %s", c.animationFit.Mode),
+		"# Part kept when art is cropped: " + strings.Join(animations.FitAnchors, ", "),
+		fmt.Sprintf("fit_anchor = %s", c.animationFit.Anchor),
+		"# Cells kept clear around the art",
+		fmt.Sprintf("fit_padding = %d", c.animationFit.Padding),
+		fmt.Sprintf("datetime = %t", c.animationDatetime),
+		"",
+		"[datetime]",
+		fmt.Sprintf("position = %s", c.datetimePosition),
+		fmt.Sprintf("font = %s", c.datetimeFont),
+		"# 12h, 24h or a Go time layout such as \"Mon 15:04\"",
+		fmt.Sprintf("format = %s", c.datetimeFormat),
+		fmt.Sprintf("seconds = %t", c.datetimeSeconds),
+		"# Locales: " + strings.Join(clock.Locales(), ", "),
+		fmt.Sprintf("locale = %s", c.datetimeLocale),
+		"# Clocks side by side, e.g. zones = Berlin=Europe/Berlin, Tokyo=Asia/Tokyo, UTC",
+		fmt.Sprintf("zones = %s", strings.Join(c.datetimeZones, ", ")),
+		"# Widget: " + strings.Join(clock.StyleNames(), ", "),
+		fmt.Sprintf("style = %s", c.datetimeStyle),
+		"# Countdown target for style = countdown: HH:MM or YYYY-MM-DD HH:MM",
+		fmt.Sprintf("countdown = %s", c.datetimeCountdown),
+		"",
+		"[away]",
+		"# Message set with 'sysc-walls away': center or banner",
+		fmt.Sprintf("style = %s", c.awayStyle),
+		fmt.Sprintf("font = %s", c.awayFont),
+		"",
+		"[image]",
+		"# PNG, JPEG and GIF art: blocks (half blocks) or ramp (luminance characters)",
+		fmt.Sprintf("mode = %s", c.imageMode),
+		"# Ink from 0.0 to 1.0 a pixel needs to be drawn: opacity, or brightness for opaque images",
+		fmt.Sprintf("threshold = %s", strconv.FormatFloat(c.imageThreshold, 'g', -1, 64)),
+		fmt.Sprintf("dither = %t", c.imageDither),
+		"# Draw the dark parts instead, for dark art on a light background",
+		fmt.Sprintf("invert = %t", c.imageInvert),
+		"# Draw the art in the image's colors once it is in place",
+		fmt.Sprintf("colors = %t", c.imageColors),
 		"",
 		"[terminal]",
 		fmt.Sprintf("kitty = %t", c.terminalKitty),
 		fmt.Sprintf("fullscreen = %t", c.terminalFullscreen),
+		"",
+		"[power]",
+		fmt.Sprintf("enabled = %t", c.powerEnabled),
+		fmt.Sprintf("timeout = %s", formatDuration(c.powerTimeout)),
+		fmt.Sprintf("method = %s", c.powerMethod),
+		"# Displays while outputs are off: stop or pause",
+		fmt.Sprintf("display = %s", c.powerDisplay),
+		"",
+		"[session]",
+		"# Pause on VT switch, follow lock-session and stop before sleep",
+		fmt.Sprintf("logind = %t", c.sessionLogind),
+		"",
+		"[console]",
+		"# Run on a virtual console without Wayland or X: auto, on or off",
+		fmt.Sprintf("mode = %s", c.consoleMode),
+		"# VT to draw on, 0 for the first free one",
+		fmt.Sprintf("vt = %d", c.consoleVT),
+		"",
+		"# Parameters of an effect go in its own section, e.g. [effect.rain] with",
+		"# speed = 1.5 and density = 3. See the README for each effect's parameters.",
 	}
+	lines = append(lines, c.effectParamLines()...)
 
 	for _, line := range lines {
 		if _, err := file.WriteString(line + "\n"); err != nil {
@@ -329,6 +1026,22 @@ func (c *Config) createDefaultConfig(configPath string) error {
 	return nil
 }
 
+// effectParamLines writes the [effect.<name>] sections, in effect order
+func (c *Config) effectParamLines() []string {
+	var lines []string
+	for _, effect := range AvailableEffects {
+		params := c.effectParams[effect]
+		if len(params) == 0 {
+			continue
+		}
+		lines = append(lines, "", fmt.Sprintf("[effect.%s]", effect))
+		for _, name := range slices.Sorted(maps.Keys(params)) {
+			lines = append(lines, fmt.Sprintf("%s = %s", name, params[name]))
+		}
+	}
+	return lines
+}
+
 // formatDuration formats a duration as a string
 func formatDuration(d time.Duration) string {
 	if d >= time.Hour {
@@ -381,13 +1094,91 @@ func (c *Config) SaveToFile(configPath string) error {
 		fmt.Sprintf("effect = %s", c.animationEffect),
 		"# Available effects: " + strings.Join(AvailableEffects, ", "),
 		fmt.Sprintf("theme = %s", c.animationTheme),
-		"# Available themes: " + strings.Join(AvailableThemes, ", "),
+		"# Available themes: " + strings.Join(theme.Names(), ", "),
+		"# More themes: ~/.config/sysc-walls/themes/<name>.theme, or 'sysc-walls theme import'",
+		"# Color scheme theme = auto follows: a pywal colors.json, kitty .conf or base16 .yaml.",
+		"# Empty for ~/.cache/wal/colors.json, then the desktop's color scheme and accent color",
+		fmt.Sprintf("theme_source = %s", c.animationThemeSource),
 		fmt.Sprintf("cycle = %t", c.cycleAnimations),
+		"# Frame rate (1-120). Effects move 20 times a second, times their speed; higher rates",
+		"# redraw the same effect frame and only smooth the clock and other overlays",
+		fmt.Sprintf("fps = %d", c.animationFPS),
+		"# Art for text-based effects: a text or image file, a directory or glob to rotate through, or !command",
+		fmt.Sprintf("file = %s", c.animationFile),
+		"# Let file run a !command. It runs as you with sh -c each time art is needed",
+		fmt.Sprintf("file_command = %t", c.animationFileCommand),
+		"# Show the next art from a directory, glob or command this often while running, 0s to keep it",
+		fmt.Sprintf("art_interval = %s", formatDuration(c.animationArtInterval)),
+		"# Text for text-based effects instead of an art file, e.g. text = BRB",
+		fmt.Sprintf("text = %s", c.animationText),
+		"# FIGlet font for text: " + strings.Join(figlet.Names(), ", ") + " or a .flf path",
+		fmt.Sprintf("font = %s", c.animationFont),
+		"# Text source of text-based effects: file (file or text above) or clock for the live time in font",
+		fmt.Sprintf("text_source = %s", c.animationTextSource),
+		"# Fit art to the screen: " + strings.Join(animations.FitModes, ", "),
+		fmt.Sprintf("fit = %s", c.animationFit.Mode),
+		"# Part kept when art is cropped: " + strings.Join(animations.FitAnchors, ", "),
+		fmt.Sprintf("fit_anchor = %s", c.animationFit.Anchor),
+		"# Cells kept clear around the art",
+		fmt.Sprintf("fit_padding = %d", c.animationFit.Padding),
+		fmt.Sprintf("datetime = %t", c.animationDatetime),
+		"",
+		"[datetime]",
+		fmt.Sprintf("position = %s", c.datetimePosition),
+		fmt.Sprintf("font = %s", c.datetimeFont),
+		"# 12h, 24h or a Go time layout such as \"Mon 15:04\"",
+		fmt.Sprintf("format = %s", c.datetimeFormat),
+		fmt.Sprintf("seconds = %t", c.datetimeSeconds),
+		"# Locales: " + strings.Join(clock.Locales(), ", "),
+		fmt.Sprintf("locale = %s", c.datetimeLocale),
+		"# Clocks side by side, e.g. zones = Berlin=Europe/Berlin, Tokyo=Asia/Tokyo, UTC",
+		fmt.Sprintf("zones = %s", strings.Join(c.datetimeZones, ", ")),
+		"# Widget: " + strings.Join(clock.StyleNames(), ", "),
+		fmt.Sprintf("style = %s", c.datetimeStyle),
+		"# Countdown target for style = countdown: HH:MM or YYYY-MM-DD HH:MM",
+		fmt.Sprintf("countdown = %s", c.datetimeCountdown),
+		"",
+		"[away]",
+		"# Message set with 'sysc-walls away': center or banner",
+		fmt.Sprintf("style = %s", c.awayStyle),
+		fmt.Sprintf("font = %s", c.awayFont),
+		"",
+		"[image]",
+		"# PNG, JPEG and GIF art: blocks (half blocks) or ramp (luminance characters)",
+		fmt.Sprintf("mode = %s", c.imageMode),
+		"# Ink from 0.0 to 1.0 a pixel needs to be drawn: opacity, or brightness for opaque images",
+		fmt.Sprintf("threshold = %s", strconv.FormatFloat(c.imageThreshold, 'g', -1, 64)),
+		fmt.Sprintf("dither = %t", c.imageDither),
+		"# Draw the dark parts instead, for dark art on a light background",
+		fmt.Sprintf("invert = %t", c.imageInvert),
+		"# Draw the art in the image's colors once it is in place",
+		fmt.Sprintf("colors = %t", c.imageColors),
 		"",
 		"[terminal]",
 		fmt.Sprintf("kitty = %t", c.terminalKitty),
 		fmt.Sprintf("fullscreen = %t", c.terminalFullscreen),
+		"",
+		"[power]",
+		fmt.Sprintf("enabled = %t", c.powerEnabled),
+		fmt.Sprintf("timeout = %s", formatDuration(c.powerTimeout)),
+		fmt.Sprintf("method = %s", c.powerMethod),
+		"# Displays while outputs are off: stop or pause",
+		fmt.Sprintf("display = %s", c.powerDisplay),
+		"",
+		"[session]",
+		"# Pause on VT switch, follow lock-session and stop before sleep",
+		fmt.Sprintf("logind = %t", c.sessionLogind),
+		"",
+		"[console]",
+		"# Run on a virtual console without Wayland or X: auto, on or off",
+		fmt.Sprintf("mode = %s", c.consoleMode),
+		"# VT to draw on, 0 for the first free one",
+		fmt.Sprintf("vt = %d", c.consoleVT),
+		"",
+		"# Parameters of an effect go in its own section, e.g. [effect.rain] with",
+		"# speed = 1.5 and density = 3. See the README for each effect's parameters.",
 	}
+	lines = append(lines, c.effectParamLines()...)
 
 	for _, line := range lines {
 		if _, err := file.WriteString(line + "\n"); err != nil {
@@ -447,48 +1238,172 @@ func (c *Config) GetAnimationTheme() string {
 	return c.animationTheme
 }
 
-// GetAnimationFile returns the custom animation file path
+// GetAnimationThemeSource returns the color scheme the auto theme follows,
+// or "" for pywal's colors then the desktop portal
+func (c *Config) GetAnimationThemeSource() string {
+	return c.animationThemeSource
+}
+
+// GetAnimationFile returns the art source for text-based effects: a file,
+// directory or glob path, a "!command", or "" for the default art
 func (c *Config) GetAnimationFile() string {
 	return c.animationFile
 }
 
+// GetAnimationFileCommand reports whether a "!command" art source may run
+func (c *Config) GetAnimationFileCommand() bool {
+	return c.animationFileCommand
+}
+
+// GetAnimationArtInterval returns how often a rotating art source moves on
+// while the screensaver runs, 0 to only move on with each activation
+func (c *Config) GetAnimationArtInterval() time.Duration {
+	return c.animationArtInterval
+}
+
 // GetAnimationDatetime returns whether datetime overlay is enabled
 func (c *Config) GetAnimationDatetime() bool {
 	return c.animationDatetime
 }
 
+// GetAnimationFPS returns the display frame rate
+func (c *Config) GetAnimationFPS() int {
+	return c.animationFPS
+}
+
+// SetAnimationFPS sets the display frame rate (1-120)
+func (c *Config) SetAnimationFPS(fps int) {
+	if fps > 0 && fps <= 120 {
+		c.animationFPS = fps
+	}
+}
+
 // GetDatetimePosition returns the datetime overlay position (top, center, bottom)
 func (c *Config) GetDatetimePosition() string {
 	return c.datetimePosition
 }
 
+// GetAnimationText returns the text drawn for text-based effects, or ""
+// to use the artwork file
+func (c *Config) GetAnimationText() string {
+	return c.animationText
+}
+
+// GetAnimationFont returns the FIGlet font for the animation text
+func (c *Config) GetAnimationFont() string {
+	return c.animationFont
+}
+
+// GetAnimationTextSource returns where text-based effects take their text
+// from: "file" or "clock"
+func (c *Config) GetAnimationTextSource() string {
+	return c.animationTextSource
+}
+
+// GetAnimationFit returns how text-based effects fit their art to the screen
+func (c *Config) GetAnimationFit() animations.Fit {
+	return c.animationFit
+}
+
+// GetAwayStyle returns how the away message is drawn: "center" or "banner"
+func (c *Config) GetAwayStyle() string {
+	return c.awayStyle
+}
+
+// GetAwayFont returns the FIGlet font for short away messages
+func (c *Config) GetAwayFont() string {
+	return c.awayFont
+}
+
+// GetImageOptions returns how image art is converted
+func (c *Config) GetImageOptions() imageart.Options {
+	return imageart.Options{Mode: c.imageMode, Threshold: c.imageThreshold, Dither: c.imageDither, Invert: c.imageInvert}
+}
+
+// GetImageColors returns whether image art is drawn in the image's colors
+func (c *Config) GetImageColors() bool {
+	return c.imageColors
+}
+
+// GetDatetimeFont returns the FIGlet font for the clock
+func (c *Config) GetDatetimeFont() string {
+	return c.datetimeFont
+}
+
+// GetDatetimeFormat returns the clock format: 12h, 24h or a Go time layout
+func (c *Config) GetDatetimeFormat() string {
+	return c.datetimeFormat
+}
+
+// GetDatetimeSeconds returns whether the 12h and 24h clocks show seconds
+func (c *Config) GetDatetimeSeconds() bool {
+	return c.datetimeSeconds
+}
+
+// GetDatetimeLocale returns the locale of weekday and month names
+func (c *Config) GetDatetimeLocale() string {
+	return c.datetimeLocale
+}
+
+// GetDatetimeZones returns the time zones shown side by side, if any
+func (c *Config) GetDatetimeZones() []string {
+	return c.datetimeZones
+}
+
+// GetDatetimeStyle returns the clock widget
+func (c *Config) GetDatetimeStyle() string {
+	return c.datetimeStyle
+}
+
+// GetDatetimeCountdown returns the countdown target, or "" if none is set
+func (c *Config) GetDatetimeCountdown() string {
+	return c.datetimeCountdown
+}
+
 // SetAnimationTheme sets the animation theme with validation
-func (c *Config) SetAnimationTheme(theme string) error {
-	if !IsValidTheme(theme) {
-		return fmt.Errorf("invalid animation theme: %s\nAvailable themes: %s", theme, strings.Join(AvailableThemes, ", "))
+func (c *Config) SetAnimationTheme(name string) error {
+	if _, err := theme.Find(name); err != nil {
+		return fmt.Errorf("invalid animation theme: %v", err)
 	}
-	c.animationTheme = theme
+	c.animationTheme = name
 	return nil
 }
 
+// GetEffectParams returns the parameters set in an effect's
+// [effect.<name>] section
+func (c *Config) GetEffectParams(effect string) animations.Params {
+	return c.effectParams[effect]
+}
+
 // IsValidEffect checks if the effect is valid
 func IsValidEffect(effect string) bool {
-	for _, e := range AvailableEffects {
-		if e == effect {
-			return true
+	_, ok := animations.Lookup(effect)
+	return ok
+}
+
+// datetimeEffects returns the effects the datetime overlay can be drawn over
+func datetimeEffects() []string {
+	var names []string
+	for _, e := range animations.Effects() {
+		if e.Datetime {
+			names = append(names, e.Name)
 		}
 	}
-	return false
+	return names
 }
 
-// IsValidTheme checks if the theme is valid
-func IsValidTheme(theme string) bool {
-	for _, t := range AvailableThemes {
-		if t == theme {
-			return true
-		}
+// IsValidTheme checks if the theme is built in or has a valid theme file
+func IsValidTheme(name string) bool {
+	_, err := theme.Find(name)
+	return err == nil
+}
+
+// warnMissingRoles warns about the roles a theme file leaves unset, which
+// use their defaults
+func warnMissingRoles(t *theme.Theme) {
+	if missing := t.Missing(); t.Path != "" && len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: Theme %s doesn't set the roles %s. Using their defaults.\n", t.Path, strings.Join(missing, ", "))
 	}
-	return false
 }
 
 // isSafeIdentifier checks if a string contains only safe characters (alphanumeric, hyphens, underscores)
@@ -536,6 +1451,60 @@ func isSafePath(path string) bool {
 	return false
 }
 
+// maxTextLength limits animation.text, which is drawn in a large font
+const maxTextLength = 64
+
+// parseText reads an animation.text value. Quoted values use Go escapes,
+// unquoted ones may use \n for a line break. The text ends up on the
+// display's command line, so control characters are rejected.
+func parseText(value string) (string, error) {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("bad quoting")
+		}
+		value = unquoted
+	} else {
+		value = strings.ReplaceAll(value, `\n`, "\n")
+	}
+
+	if utf8.RuneCountInString(value) > maxTextLength {
+		return "", fmt.Errorf("longer than %d characters", maxTextLength)
+	}
+	for _, r := range value {
+		if r != '\n' && unicode.IsControl(r) {
+			return "", fmt.Errorf("contains control characters")
+		}
+	}
+	return value, nil
+}
+
+// parseFont checks a font setting: a bundled or user font name, or an
+// absolute .flf path in an allowed directory
+func parseFont(value string) (string, error) {
+	if strings.HasPrefix(value, "~/") {
+		homeDir := os.Getenv("HOME")
+		if homeDir == "" || !filepath.IsAbs(homeDir) {
+			return "", fmt.Errorf("cannot expand '~', HOME not set")
+		}
+		value = filepath.Join(homeDir, value[2:])
+	}
+
+	if isSafeIdentifier(value) {
+		if _, err := figlet.Load(value); err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+	if !isSafePath(value) || !strings.HasSuffix(value, ".flf") {
+		return "", fmt.Errorf("must be a font name or an absolute .flf path in an allowed directory")
+	}
+	if _, err := figlet.LoadFile(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
 // ShouldCycleAnimations returns whether animations should be cycled
 func (c *Config) ShouldCycleAnimations() bool {
 	return c.cycleAnimations
@@ -561,6 +1530,121 @@ func (c *Config) SetTerminalFullscreen(fullscreen bool) {
 	c.terminalFullscreen = fullscreen
 }
 
+// IsPowerEnabled returns whether outputs are turned off after the screensaver runs a while
+func (c *Config) IsPowerEnabled() bool {
+	return c.powerEnabled
+}
+
+// SetPowerEnabled sets whether output power management is enabled
+func (c *Config) SetPowerEnabled(enabled bool) {
+	c.powerEnabled = enabled
+}
+
+// IsLogindEnabled returns whether the daemon follows the logind session
+func (c *Config) IsLogindEnabled() bool {
+	return c.sessionLogind
+}
+
+// GetConsoleMode returns the virtual console mode: "auto", "on" or "off"
+func (c *Config) GetConsoleMode() string {
+	return c.consoleMode
+}
+
+// GetConsoleVT returns the VT the console screensaver draws on, 0 for the first free one
+func (c *Config) GetConsoleVT() int {
+	return c.consoleVT
+}
+
+// UseConsole returns whether the screensaver runs on a virtual console.
+// In auto mode that is when no display server was detected.
+func (c *Config) UseConsole(displayServer string) bool {
+	switch c.consoleMode {
+	case "on":
+		return true
+	case "off":
+		return false
+	}
+	return displayServer == "none"
+}
+
+// GetPowerTimeout returns how long the screensaver runs before outputs are turned off
+func (c *Config) GetPowerTimeout() time.Duration {
+	return c.powerTimeout
+}
+
+// GetPowerMethod returns the output power backend (auto, wlr, ipc)
+func (c *Config) GetPowerMethod() string {
+	return c.powerMethod
+}
+
+// GetPowerDisplay returns what happens to displays while outputs are off (stop, pause)
+func (c *Config) GetPowerDisplay() string {
+	return c.powerDisplay
+}
+
+// ResolvePowerPolicy merges the policies for the given states in order
+func (c *Config) ResolvePowerPolicy(states []string) PowerPolicy {
+	var policy PowerPolicy
+	for _, state := range states {
+		if p, ok := c.policies[state]; ok {
+			policy = policy.Merge(p)
+		}
+	}
+	return policy
+}
+
+// HasPowerPolicies returns whether any [policy.<state>] section is configured
+func (c *Config) HasPowerPolicies() bool {
+	return len(c.policies) > 0
+}
+
+// HasAppRules returns true if any [rules] entry is configured
+func (c *Config) HasAppRules() bool {
+	return len(c.appRules) > 0
+}
+
+// MatchAppRule returns the first rule matching a focused window
+func (c *Config) MatchAppRule(appID string, fullscreen bool) (AppRule, bool) {
+	appID = strings.ToLower(appID)
+	for _, rule := range c.appRules {
+		if rule.Fullscreen && !fullscreen {
+			continue
+		}
+		if ok, _ := path.Match(rule.Pattern, appID); ok {
+			return rule, true
+		}
+	}
+	return AppRule{}, false
+}
+
+// GetWidgets returns the info widgets in config file order. The status
+// widget is left out until it has a file.
+func (c *Config) GetWidgets() []Widget {
+	var widgets []Widget
+	for _, widget := range c.widgets {
+		if widget.Kind == "status" && widget.File == "" {
+			continue
+		}
+		widgets = append(widgets, widget)
+	}
+	return widgets
+}
+
+// GetScheduleRules returns the time-of-day rules in config file order
+func (c *Config) GetScheduleRules() []schedule.Rule {
+	return c.scheduleRules
+}
+
+// GetPolicyLowBattery returns the battery percentage that counts as low
+func (c *Config) GetPolicyLowBattery() int {
+	return c.policyLowBattery
+}
+
+// UsePolicyProfiles returns whether power-profiles-daemon state selects policies
+func (c *Config) UsePolicyProfiles() bool {
+	return c.policyProfiles
+}
+
 // GetTerminalLauncher returns the command to launch the terminal
 func (c *Config) GetTerminalLauncher() string {
 	if c.terminalKitty {
@@ -583,9 +1667,49 @@ func (c *Config) GetTerminalArgs() []string {
 // GetScreensaverCommand returns the command and arguments to launch the screensaver
 // Returns (terminal, args, error) where terminal is the executable and args are its arguments
 func (c *Config) GetScreensaverCommand() (string, []string, error) {
+	return c.GetScreensaverCommandWith(LaunchOptions{})
+}
+
+// GetScreensaverCommandWith returns the screensaver command with per-launch overrides
+func (c *Config) GetScreensaverCommandWith(opts LaunchOptions) (string, []string, error) {
 	terminal := c.GetTerminalLauncher()
+	displayBinary, displayArgs, err := c.displayCommand(opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Build arguments array
+	args := c.GetTerminalArgs()
+	args = append(args, "--class", "sysc-walls-screensaver")
+	args = append(args, displayBinary)
+	args = append(args, displayArgs...)
+	args = append(args, "--fullscreen")
+
+	return terminal, args, nil
+}
+
+// GetConsoleCommandWith returns the display command for a virtual console.
+// The display runs directly on the VT without a terminal emulator, limited
+// to the 16 colors of the console palette.
+func (c *Config) GetConsoleCommandWith(opts LaunchOptions) (string, []string, error) {
+	displayBinary, args, err := c.displayCommand(opts)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, "--colors", "16")
+	return displayBinary, args, nil
+}
+
+// displayCommand returns the display binary and its animation arguments
+func (c *Config) displayCommand(opts LaunchOptions) (string, []string, error) {
 	effect := c.GetAnimationEffect()
-	theme := c.GetAnimationTheme()
+	if opts.Effect != "" {
+		effect = opts.Effect
+	}
+	themeName := c.GetAnimationTheme()
+	if opts.Theme != "" {
+		themeName = opts.Theme
+	}
 	file := c.GetAnimationFile()
 
 	// Validate effect name (prevent command injection)
@@ -594,8 +1718,8 @@ func (c *Config) GetScreensaverCommand() (string, []string, error) {
 	}
 
 	// Validate theme name (prevent command injection)
-	if !isSafeIdentifier(theme) {
-		return "", nil, fmt.Errorf("invalid animation theme: %s (contains unsafe characters)", theme)
+	if !isSafeIdentifier(themeName) {
+		return "", nil, fmt.Errorf("invalid animation theme: %s (contains unsafe characters)", themeName)
 	}
 
 	// Find display binary
@@ -604,38 +1728,109 @@ func (c *Config) GetScreensaverCommand() (string, []string, error) {
 		return "", nil, err
 	}
 
-	// Build arguments array
-	args := c.GetTerminalArgs()
-	args = append(args, "--class", "sysc-walls-screensaver")
-	args = append(args, displayBinary, "--effect", effect, "--theme", theme)
+	args := []string{"--effect", effect, "--theme", themeName}
+	if themeName == theme.Auto && c.GetAnimationThemeSource() != "" {
+		args = append(args, "--theme-source", c.GetAnimationThemeSource())
+	}
+
+	// The live clock takes the place of the artwork file and text
+	capabilities, _ := animations.Lookup(effect)
+	liveClock := c.GetAnimationTextSource() == "clock" && capabilities.Text
+	if liveClock {
+		args = append(args, "--text-source", "clock", "--font", c.GetAnimationFont())
+		args = append(args, "--datetime-format", c.GetDatetimeFormat())
+		args = append(args, "--datetime-locale", c.GetDatetimeLocale())
+		file = ""
+	}
 
 	// Add custom file path if specified and valid
 	if file != "" {
-		if !isSafePath(file) {
+		if art.IsCommand(file) {
+			if !c.GetAnimationFileCommand() {
+				return "", nil, fmt.Errorf("animation file %s is a command (set file_command = true in [animation] to run it)", file)
+			}
+		} else if !isSafePath(file) {
 			return "", nil, fmt.Errorf("invalid animation file path: %s (must be absolute path in allowed directory)", file)
 		}
 		args = append(args, "--file", file)
+		if interval := c.GetAnimationArtInterval(); interval > 0 {
+			args = append(args, "--art-interval", interval.String())
+		}
+		// Files may be images, converted by the display
+		if !art.IsCommand(file) {
+			image := c.GetImageOptions()
+			args = append(args, "--image-mode", image.Mode)
+			args = append(args, "--image-threshold", strconv.FormatFloat(image.Threshold, 'g', -1, 64))
+			args = append(args, fmt.Sprintf("--image-dither=%t", image.Dither), fmt.Sprintf("--image-invert=%t", image.Invert))
+			args = append(args, fmt.Sprintf("--image-colors=%t", c.GetImageColors()))
+		}
+	}
+
+	// Text drawn in a font takes the place of the artwork file
+	if text := c.GetAnimationText(); text != "" && !liveClock {
+		args = append(args, "--text", text, "--font", c.GetAnimationFont())
+	}
+
+	if fit := c.GetAnimationFit(); capabilities.Text && fit != defaultFit {
+		args = append(args, "--fit", fit.Mode, "--fit-anchor", fit.Anchor, "--fit-padding", strconv.Itoa(fit.Padding))
+	}
+
+	params := c.GetEffectParams(effect)
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		args = append(args, "--param", name+"="+params[name])
 	}
 
 	// Add datetime overlay if enabled and compatible with effect
 	datetime := c.GetAnimationDatetime()
-	if datetime {
-		// Check if effect is text-based (datetime overlay is incompatible with text-based effects)
-		if syscGo.IsTextBasedEffect(effect) {
+	if opts.Datetime != nil {
+		datetime = *opts.Datetime
+	}
+	// A live clock effect draws the time itself, without the overlay
+	if datetime && !liveClock {
+		// Check the effect takes the overlay (text-based effects draw over it)
+		if !capabilities.Datetime {
 			// Log warning but don't fail - just disable datetime for this launch
-			fmt.Fprintf(os.Stderr, "Warning: DateTime overlay disabled - incompatible with text-based effect '%s'\n", effect)
-			fmt.Fprintf(os.Stderr, "         DateTime only works with non-text effects like: matrix, fire, rain, aquarium, fireworks, beams\n")
+			fmt.Fprintf(os.Stderr, "Warning: DateTime overlay disabled - incompatible with effect '%s'\n", effect)
+			fmt.Fprintf(os.Stderr, "         DateTime only works with: %s\n", strings.Join(datetimeEffects(), ", "))
+			if capabilities.Text {
+				fmt.Fprintf(os.Stderr, "         Set animation.text_source = clock to draw the time with '%s' instead\n", effect)
+			}
 		} else {
 			// Effect is compatible, add --datetime flag and position
 			args = append(args, "--datetime")
 			position := c.GetDatetimePosition()
 			args = append(args, "--datetime-position", position)
+			args = append(args, "--datetime-font", c.GetDatetimeFont())
+			args = append(args, "--datetime-format", c.GetDatetimeFormat())
+			args = append(args, fmt.Sprintf("--datetime-seconds=%t", c.GetDatetimeSeconds()))
+			args = append(args, "--datetime-locale", c.GetDatetimeLocale())
+			if zones := c.GetDatetimeZones(); len(zones) > 0 {
+				args = append(args, "--datetime-zones", strings.Join(zones, ","))
+			}
+			style := c.GetDatetimeStyle()
+			if style == string(clock.Countdown) && c.GetDatetimeCountdown() == "" {
+				fmt.Fprintf(os.Stderr, "Warning: datetime.style = countdown needs datetime.countdown, using the digital clock\n")
+				style = string(clock.Digital)
+			}
+			args = append(args, "--datetime-style", style)
+			if countdown := c.GetDatetimeCountdown(); countdown != "" {
+				args = append(args, "--datetime-countdown", countdown)
+			}
 		}
 	}
 
-	args = append(args, "--fullscreen")
+	for _, widget := range c.GetWidgets() {
+		args = append(args, "--widget", widget.Arg())
+	}
+	args = append(args, "--away-style", c.GetAwayStyle(), "--away-font", c.GetAwayFont())
 
-	return terminal, args, nil
+	fps := c.GetAnimationFPS()
+	if opts.FPS > 0 {
+		fps = opts.FPS
+	}
+	args = append(args, "--fps", strconv.Itoa(fps))
+
+	return displayBinary, args, nil
 }
 
 // GetScreensaverCommandString returns the command as a string for logging purposes only
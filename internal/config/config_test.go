@@ -3,10 +3,24 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/animations"
+	"github.com/Nomadcxx/sysc-walls/internal/schedule"
 )
 
+// TestMain stands in for an installed sysc-walls-display, which building
+// commands needs
+func TestMain(m *testing.M) {
+	findDisplayBinary = func() (string, error) {
+		return "/usr/bin/sysc-walls-display", nil
+	}
+	os.Exit(m.Run())
+}
+
 // TestNewConfig verifies default configuration values
 func TestNewConfig(t *testing.T) {
 	cfg := NewConfig()
@@ -39,6 +53,10 @@ func TestNewConfig(t *testing.T) {
 	if cfg.ShouldCycleAnimations() != false {
 		t.Error("Default cycle animations should be false")
 	}
+
+	if cfg.GetAnimationFPS() != 20 {
+		t.Errorf("Default fps = %d, want 20", cfg.GetAnimationFPS())
+	}
 }
 
 // TestParseDuration tests duration string parsing
@@ -191,6 +209,7 @@ debug = true
 effect = fire
 theme = gruvbox
 cycle = false
+fps = 60
 
 [terminal]
 kitty = false
@@ -226,6 +245,9 @@ fullscreen = false
 	if cfg2.ShouldCycleAnimations() {
 		t.Error("Loaded cycle = true, want false")
 	}
+	if cfg2.GetAnimationFPS() != 60 {
+		t.Errorf("Loaded fps = %d, want 60", cfg2.GetAnimationFPS())
+	}
 	if cfg2.IsTerminalKitty() {
 		t.Error("Loaded kitty = true, want false")
 	}
@@ -234,6 +256,705 @@ fullscreen = false
 	}
 }
 
+// TestLoadPowerSection tests parsing of the [power] section
+func TestLoadPowerSection(t *testing.T) {
+	cfg := NewConfig()
+	if cfg.IsPowerEnabled() {
+		t.Error("Default power enabled should be false")
+	}
+	if cfg.GetPowerTimeout() != 10*time.Minute {
+		t.Errorf("Default power timeout = %v, want 10m", cfg.GetPowerTimeout())
+	}
+
+	configPath := filepath.Join(t.TempDir(), "power.conf")
+	content := `[power]
+enabled = true
+timeout = 20m
+method = IPC
+display = pause
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	if err := cfg.LoadFromFile(configPath); err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+
+	if !cfg.IsPowerEnabled() {
+		t.Error("Loaded power.enabled = false, want true")
+	}
+	if cfg.GetPowerTimeout() != 20*time.Minute {
+		t.Errorf("Loaded power.timeout = %v, want 20m", cfg.GetPowerTimeout())
+	}
+	if cfg.GetPowerMethod() != "ipc" {
+		t.Errorf("Loaded power.method = %s, want ipc", cfg.GetPowerMethod())
+	}
+	if cfg.GetPowerDisplay() != "pause" {
+		t.Errorf("Loaded power.display = %s, want pause", cfg.GetPowerDisplay())
+	}
+
+	// Invalid values keep the previous setting
+	cfg.parseConfigLine("power.method", "x11")
+	cfg.parseConfigLine("power.display", "hide")
+	if cfg.GetPowerMethod() != "ipc" || cfg.GetPowerDisplay() != "pause" {
+		t.Errorf("Invalid power values should be ignored, got method=%s display=%s", cfg.GetPowerMethod(), cfg.GetPowerDisplay())
+	}
+}
+
+// TestConsoleSection tests [console] parsing and when console mode applies
+func TestConsoleSection(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		displayServer string
+		want          bool
+	}{
+		{"auto without display server", "auto", "none", true},
+		{"auto on wayland", "auto", "wayland", false},
+		{"auto on x11", "auto", "x11", false},
+		{"on", "on", "wayland", true},
+		{"off", "off", "none", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.parseConfigLine("console.mode", tt.mode)
+			if got := cfg.UseConsole(tt.displayServer); got != tt.want {
+				t.Errorf("UseConsole(%q) with mode %s = %v, want %v", tt.displayServer, tt.mode, got, tt.want)
+			}
+		})
+	}
+
+	cfg := NewConfig()
+	if cfg.GetConsoleMode() != "auto" || cfg.GetConsoleVT() != 0 {
+		t.Errorf("Default console = %s vt %d, want auto vt 0", cfg.GetConsoleMode(), cfg.GetConsoleVT())
+	}
+	cfg.parseConfigLine("console.mode", "ON")
+	cfg.parseConfigLine("console.vt", "8")
+	if cfg.GetConsoleMode() != "on" || cfg.GetConsoleVT() != 8 {
+		t.Errorf("Loaded console = %s vt %d, want on vt 8", cfg.GetConsoleMode(), cfg.GetConsoleVT())
+	}
+
+	// Invalid values keep the previous setting
+	cfg.parseConfigLine("console.mode", "always")
+	cfg.parseConfigLine("console.vt", "64")
+	if cfg.GetConsoleMode() != "on" || cfg.GetConsoleVT() != 8 {
+		t.Errorf("Invalid console values should be ignored, got %s vt %d", cfg.GetConsoleMode(), cfg.GetConsoleVT())
+	}
+}
+
+// TestTextAndFonts tests animation.text and the font settings
+func TestTextAndFonts(t *testing.T) {
+	textTests := []struct {
+		value string
+		want  string
+	}{
+		{"BRB", "BRB"},
+		{`"Back in 5"`, "Back in 5"},
+		{`BRB\nSOON`, "BRB\nSOON"},
+		{`"A\tB"`, ""}, // Control characters are rejected
+		{strings.Repeat("x", 65), ""},
+	}
+	for _, tt := range textTests {
+		cfg := NewConfig()
+		cfg.parseConfigLine("animation.text", tt.value)
+		if got := cfg.GetAnimationText(); got != tt.want {
+			t.Errorf("animation.text = %q: got %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	cfg := NewConfig()
+	if cfg.GetAnimationFont() != "block" || cfg.GetDatetimeFont() != "kompaktblk" {
+		t.Errorf("Default fonts = %s, %s", cfg.GetAnimationFont(), cfg.GetDatetimeFont())
+	}
+	cfg.parseConfigLine("animation.font", "dots")
+	cfg.parseConfigLine("datetime.font", "block")
+	if cfg.GetAnimationFont() != "dots" || cfg.GetDatetimeFont() != "block" {
+		t.Errorf("Loaded fonts = %s, %s", cfg.GetAnimationFont(), cfg.GetDatetimeFont())
+	}
+
+	// Unknown fonts and unsafe paths keep the previous font
+	cfg.parseConfigLine("animation.font", "no-such-font")
+	cfg.parseConfigLine("datetime.font", "/tmp/evil.flf")
+	if cfg.GetAnimationFont() != "dots" || cfg.GetDatetimeFont() != "block" {
+		t.Errorf("Invalid fonts should be ignored, got %s, %s", cfg.GetAnimationFont(), cfg.GetDatetimeFont())
+	}
+
+	// Text and fonts reach the display command
+	cfg.SetAnimationEffect("fire-text")
+	cfg.parseConfigLine("animation.text", "BRB")
+	cfg.parseConfigLine("animation.datetime", "true")
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "fire"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	cmd := strings.Join(args, " ")
+	for _, want := range []string{"--text BRB --font dots", "--datetime-font block"} {
+		if !contains(cmd, want) {
+			t.Errorf("Command missing %q: %s", want, cmd)
+		}
+	}
+}
+
+// TestDatetimeClock tests the clock format, locale, zone and widget settings
+func TestDatetimeClock(t *testing.T) {
+	cfg := NewConfig()
+	if cfg.GetDatetimeFormat() != "12h" || !cfg.GetDatetimeSeconds() || cfg.GetDatetimeLocale() != "en" || cfg.GetDatetimeZones() != nil {
+		t.Errorf("Defaults = %s, %t, %s, %v", cfg.GetDatetimeFormat(), cfg.GetDatetimeSeconds(), cfg.GetDatetimeLocale(), cfg.GetDatetimeZones())
+	}
+
+	cfg.parseConfigLine("datetime.format", "24h")
+	cfg.parseConfigLine("datetime.seconds", "false")
+	cfg.parseConfigLine("datetime.locale", "ja")
+	cfg.parseConfigLine("datetime.zones", "Tokyo=Asia/Tokyo, Nowhere/Else, UTC")
+	if cfg.GetDatetimeFormat() != "24h" || cfg.GetDatetimeSeconds() || cfg.GetDatetimeLocale() != "ja" {
+		t.Errorf("Loaded = %s, %t, %s", cfg.GetDatetimeFormat(), cfg.GetDatetimeSeconds(), cfg.GetDatetimeLocale())
+	}
+	if zones := cfg.GetDatetimeZones(); len(zones) != 2 || zones[0] != "Tokyo=Asia/Tokyo" || zones[1] != "UTC" {
+		t.Errorf("Zones = %v, want the two valid entries", zones)
+	}
+
+	// Invalid values keep the previous setting
+	cfg.parseConfigLine("datetime.format", "no fields")
+	cfg.parseConfigLine("datetime.locale", "klingon")
+	if cfg.GetDatetimeFormat() != "24h" || cfg.GetDatetimeLocale() != "ja" {
+		t.Errorf("Invalid values should be ignored, got %s, %s", cfg.GetDatetimeFormat(), cfg.GetDatetimeLocale())
+	}
+	cfg.parseConfigLine("datetime.format", `"Mon 15:04"`)
+	if cfg.GetDatetimeFormat() != "Mon 15:04" {
+		t.Errorf("Custom format = %q", cfg.GetDatetimeFormat())
+	}
+
+	cfg.parseConfigLine("animation.datetime", "true")
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "fire"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	cmd := strings.Join(args, " ")
+	for _, want := range []string{"--datetime-format Mon 15:04", "--datetime-seconds=false", "--datetime-locale ja", "--datetime-zones Tokyo=Asia/Tokyo,UTC"} {
+		if !contains(cmd, want) {
+			t.Errorf("Command missing %q: %s", want, cmd)
+		}
+	}
+
+	// A countdown without a target falls back to the digital clock
+	cfg.parseConfigLine("datetime.style", "Countdown")
+	if cfg.GetDatetimeStyle() != "countdown" {
+		t.Errorf("Style = %q, want countdown", cfg.GetDatetimeStyle())
+	}
+	_, args, _ = cfg.GetConsoleCommandWith(LaunchOptions{Effect: "fire"})
+	if cmd := strings.Join(args, " "); !contains(cmd, "--datetime-style digital") {
+		t.Errorf("Countdown without a target: %s", cmd)
+	}
+	cfg.parseConfigLine("datetime.countdown", "tomorrow")
+	cfg.parseConfigLine("datetime.countdown", "2030-01-01 09:00")
+	_, args, _ = cfg.GetConsoleCommandWith(LaunchOptions{Effect: "fire"})
+	if cmd := strings.Join(args, " "); !contains(cmd, "--datetime-style countdown --datetime-countdown 2030-01-01 09:00") {
+		t.Errorf("Countdown: %s", cmd)
+	}
+	cfg.parseConfigLine("datetime.style", "sundial")
+	if cfg.GetDatetimeStyle() != "countdown" {
+		t.Errorf("Invalid style should be ignored, got %q", cfg.GetDatetimeStyle())
+	}
+
+	// Settings survive a save and reload
+	path := filepath.Join(t.TempDir(), "daemon.conf")
+	if err := cfg.SaveToFile(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewConfig()
+	if err := loaded.LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.GetDatetimeFormat() != "Mon 15:04" || loaded.GetDatetimeLocale() != "ja" || len(loaded.GetDatetimeZones()) != 2 ||
+		loaded.GetDatetimeStyle() != "countdown" || loaded.GetDatetimeCountdown() != "2030-01-01 09:00" {
+		t.Errorf("Reloaded = %s, %s, %v, %s, %s", loaded.GetDatetimeFormat(), loaded.GetDatetimeLocale(), loaded.GetDatetimeZones(),
+			loaded.GetDatetimeStyle(), loaded.GetDatetimeCountdown())
+	}
+}
+
+// TestClockTextSource tests text effects drawing the live time
+func TestClockTextSource(t *testing.T) {
+	cfg := NewConfig()
+	if cfg.GetAnimationTextSource() != "file" {
+		t.Errorf("Default text source = %q, want file", cfg.GetAnimationTextSource())
+	}
+	cfg.parseConfigLine("animation.text_source", "Clock")
+	cfg.parseConfigLine("animation.text_source", "weather")
+	if cfg.GetAnimationTextSource() != "clock" {
+		t.Errorf("Text source = %q, want clock", cfg.GetAnimationTextSource())
+	}
+
+	// Text effects draw the clock instead of the text, without the overlay
+	cfg.parseConfigLine("animation.text", "BRB")
+	cfg.parseConfigLine("animation.datetime", "true")
+	cfg.parseConfigLine("datetime.format", "24h")
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "fire-text"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	cmd := strings.Join(args, " ")
+	if !contains(cmd, "--text-source clock --font block --datetime-format 24h --datetime-locale en") {
+		t.Errorf("Command missing the clock source: %s", cmd)
+	}
+	for _, unwanted := range []string{"--text ", "--datetime "} {
+		if contains(cmd+" ", unwanted) {
+			t.Errorf("Command has %q: %s", unwanted, cmd)
+		}
+	}
+
+	// Other effects keep the overlay
+	_, args, _ = cfg.GetConsoleCommandWith(LaunchOptions{Effect: "fire"})
+	if cmd := strings.Join(args, " "); contains(cmd, "--text-source") || !contains(cmd, "--datetime ") {
+		t.Errorf("Non-text effect: %s", cmd)
+	}
+}
+
+// TestWidgets tests [widget.<kind>] sections
+func TestWidgets(t *testing.T) {
+	cfg := NewConfig()
+	cfg.parseConfigLine("widget.battery.position", "Top-Right")
+	cfg.parseConfigLine("widget.hostname.position", "top-left")
+	cfg.parseConfigLine("widget.battery.interval", "1m")
+	cfg.parseConfigLine("widget.weather.position", "top")       // Unknown kind
+	cfg.parseConfigLine("widget.hostname.position", "sideways") // Keeps top-left
+	cfg.parseConfigLine("widget.hostname.interval", "0s")
+	cfg.parseConfigLine("widget.status.position", "bottom") // No file yet
+
+	want := []Widget{
+		{Kind: "battery", Position: "top-right", Interval: time.Minute},
+		{Kind: "hostname", Position: "top-left"},
+	}
+	if got := cfg.GetWidgets(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetWidgets() = %+v, want %+v", got, want)
+	}
+
+	cfg.parseConfigLine("widget.status.file", "relative/status")
+	cfg.parseConfigLine("widget.status.file", "/run/status,1")
+	if len(cfg.GetWidgets()) != 2 {
+		t.Errorf("status widget with an invalid file should be left out")
+	}
+	cfg.parseConfigLine("widget.status.file", "/run/user/1000/status")
+
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "fire"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	cmd := strings.Join(args, " ")
+	for _, want := range []string{
+		"--widget battery,position=top-right,interval=1m0s",
+		"--widget hostname,position=top-left --widget status,position=bottom,file=/run/user/1000/status",
+	} {
+		if !contains(cmd, want) {
+			t.Errorf("Command missing %q: %s", want, cmd)
+		}
+	}
+}
+
+// TestEffectParams tests [effect.<name>] sections and their schemas
+func TestEffectParams(t *testing.T) {
+	cfg := NewConfig()
+	cfg.parseConfigLine("effect.rain.density", "3")
+	cfg.parseConfigLine("effect.rain.speed", "1.5")
+	cfg.parseConfigLine("effect.rain.speed", "fast") // Keeps 1.5
+	cfg.parseConfigLine("effect.rain.freeze", "0.5") // Not a rain parameter
+	cfg.parseConfigLine("effect.aquarium.bubble", "#ff0000")
+	cfg.parseConfigLine("effect.snow.density", "2")      // Unknown effect
+	cfg.parseConfigLine("effect.aquarium.fish", "green") // Invalid color
+
+	want := map[string]animations.Params{
+		"rain":     {"density": "3", "speed": "1.5"},
+		"aquarium": {"bubble": "#ff0000"},
+	}
+	for effect, params := range want {
+		if got := cfg.GetEffectParams(effect); !reflect.DeepEqual(got, params) {
+			t.Errorf("GetEffectParams(%s) = %v, want %v", effect, got, params)
+		}
+	}
+	if got := cfg.GetEffectParams("snow"); got != nil {
+		t.Errorf("GetEffectParams(snow) = %v", got)
+	}
+
+	// Only the running effect's parameters are passed to the display
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "rain"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	if cmd := strings.Join(args, " "); !contains(cmd, "--param density=3 --param speed=1.5") || contains(cmd, "bubble") {
+		t.Errorf("Command = %s", cmd)
+	}
+
+	// Parameters survive a save and reload
+	path := filepath.Join(t.TempDir(), "daemon.conf")
+	if err := cfg.SaveToFile(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewConfig()
+	if err := loaded.LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.effectParams, cfg.effectParams) {
+		t.Errorf("Reloaded = %v, want %v", loaded.effectParams, cfg.effectParams)
+	}
+}
+
+// TestAwaySection tests the [away] message settings
+func TestAwaySection(t *testing.T) {
+	cfg := NewConfig()
+	if cfg.GetAwayStyle() != "center" || cfg.GetAwayFont() != "block" {
+		t.Errorf("Defaults = %s, %s", cfg.GetAwayStyle(), cfg.GetAwayFont())
+	}
+	cfg.parseConfigLine("away.style", "Banner")
+	cfg.parseConfigLine("away.font", "dots")
+	cfg.parseConfigLine("away.style", "marquee")
+	cfg.parseConfigLine("away.font", "no-such-font")
+	if cfg.GetAwayStyle() != "banner" || cfg.GetAwayFont() != "dots" {
+		t.Errorf("Loaded = %s, %s", cfg.GetAwayStyle(), cfg.GetAwayFont())
+	}
+
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "fire"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	if cmd := strings.Join(args, " "); !contains(cmd, "--away-style banner --away-font dots") {
+		t.Errorf("Command missing the away settings: %s", cmd)
+	}
+}
+
+// TestArtSources tests directory, glob and command values of animation.file
+func TestArtSources(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg := NewConfig()
+	cfg.parseConfigLine("animation.file", "~/.config/sysc-walls/ascii")
+	cfg.parseConfigLine("animation.art_interval", "10m")
+	if want := filepath.Join(home, ".config", "sysc-walls", "ascii"); cfg.GetAnimationFile() != want {
+		t.Errorf("Directory source = %q, want %q", cfg.GetAnimationFile(), want)
+	}
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "matrix-art"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	if cmd := strings.Join(args, " "); !contains(cmd, "/.config/sysc-walls/ascii --art-interval 10m0s") {
+		t.Errorf("Command missing the art source: %s", cmd)
+	}
+
+	cfg.parseConfigLine("animation.file", "~/.config/sysc-walls/ascii/*.txt")
+	if !strings.HasSuffix(cfg.GetAnimationFile(), "/ascii/*.txt") {
+		t.Errorf("Glob source = %q", cfg.GetAnimationFile())
+	}
+
+	// Commands are kept as written, and only run once opted in
+	cfg.parseConfigLine("animation.file", "!fortune -s | figlet")
+	cfg.parseConfigLine("animation.file", "!  ")
+	if cfg.GetAnimationFile() != "!fortune -s | figlet" {
+		t.Errorf("Command source = %q", cfg.GetAnimationFile())
+	}
+	if _, _, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "matrix-art"}); err == nil {
+		t.Error("GetConsoleCommandWith() ran an art command without file_command")
+	}
+	cfg.parseConfigLine("animation.file_command", "true")
+	_, args, err = cfg.GetConsoleCommandWith(LaunchOptions{Effect: "matrix-art"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	if !contains(strings.Join(args, " "), "--file !fortune -s | figlet") {
+		t.Errorf("Command missing the art command: %v", args)
+	}
+
+	// Art outside the allowed directories is still refused, even with
+	// commands allowed
+	cfg.parseConfigLine("animation.file", "/etc/*")
+	if _, _, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "matrix-art"}); err == nil {
+		t.Error("GetConsoleCommandWith() accepted art from /etc")
+	}
+}
+
+// TestImageSection tests the [image] options passed with image art
+func TestImageSection(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg := NewConfig()
+	if opts := cfg.GetImageOptions(); opts.Mode != "blocks" || opts.Threshold != 0.5 || !cfg.GetImageColors() {
+		t.Errorf("Defaults = %+v, colors %v", opts, cfg.GetImageColors())
+	}
+	cfg.parseConfigLine("image.mode", "Ramp")
+	cfg.parseConfigLine("image.threshold", "0.25")
+	cfg.parseConfigLine("image.dither", "true")
+	cfg.parseConfigLine("image.colors", "false")
+	cfg.parseConfigLine("image.mode", "sixel")
+	cfg.parseConfigLine("image.threshold", "2")
+	if opts := cfg.GetImageOptions(); opts.Mode != "ramp" || opts.Threshold != 0.25 || !opts.Dither || opts.Invert || cfg.GetImageColors() {
+		t.Errorf("Loaded = %+v, colors %v", opts, cfg.GetImageColors())
+	}
+
+	cfg.parseConfigLine("animation.file", "~/.config/sysc-walls/logo.png")
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "matrix-art"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	want := "logo.png --image-mode ramp --image-threshold 0.25 --image-dither=true --image-invert=false --image-colors=false"
+	if cmd := strings.Join(args, " "); !contains(cmd, want) {
+		t.Errorf("Command missing the image options: %s", cmd)
+	}
+}
+
+// TestAnimationFit tests the art fit passed to text-based effects
+func TestAnimationFit(t *testing.T) {
+	cfg := NewConfig()
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Effect: "matrix-art"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	if cmd := strings.Join(args, " "); contains(cmd, "--fit") {
+		t.Errorf("Command has the default fit: %s", cmd)
+	}
+
+	cfg.parseConfigLine("animation.fit", "Integer")
+	cfg.parseConfigLine("animation.fit_anchor", "top-left")
+	cfg.parseConfigLine("animation.fit_padding", "2")
+	cfg.parseConfigLine("animation.fit", "stretch")
+	cfg.parseConfigLine("animation.fit_anchor", "middle")
+	cfg.parseConfigLine("animation.fit_padding", "-1")
+	if fit := cfg.GetAnimationFit(); fit.Mode != "integer" || fit.Anchor != "top-left" || fit.Padding != 2 {
+		t.Errorf("Loaded = %+v", fit)
+	}
+
+	_, args, err = cfg.GetConsoleCommandWith(LaunchOptions{Effect: "matrix-art"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	if cmd := strings.Join(args, " "); !contains(cmd, "--fit integer --fit-anchor top-left --fit-padding 2") {
+		t.Errorf("Command missing the fit: %s", cmd)
+	}
+	_, args, _ = cfg.GetConsoleCommandWith(LaunchOptions{Effect: "fire"})
+	if cmd := strings.Join(args, " "); contains(cmd, "--fit") {
+		t.Errorf("Non-text effect given a fit: %s", cmd)
+	}
+}
+
+// TestThemeFiles tests themes from the user's theme directory
+func TestThemeFiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", "sysc-walls", "themes")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "ocean.theme"), []byte("[theme]\npalette = #0b1d2a #2a9d8f\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "broken.theme"), []byte("[theme]\npalette = blue\n"), 0644)
+
+	cfg := NewConfig()
+	cfg.parseConfigLine("animation.theme", "ocean")
+	if cfg.GetAnimationTheme() != "ocean" {
+		t.Errorf("theme = %s, want ocean", cfg.GetAnimationTheme())
+	}
+	cfg.parseConfigLine("animation.theme", "broken")
+	cfg.parseConfigLine("animation.theme", "missing")
+	if cfg.GetAnimationTheme() != "ocean" {
+		t.Errorf("invalid themes replaced ocean with %s", cfg.GetAnimationTheme())
+	}
+
+	// Aliases sysc-Go lists are themes too
+	if err := cfg.SetAnimationTheme("tokyonight"); err != nil {
+		t.Errorf("SetAnimationTheme(tokyonight) = %v", err)
+	}
+	if err := cfg.SetAnimationTheme("broken"); err == nil || !contains(err.Error(), "broken.theme") {
+		t.Errorf("SetAnimationTheme(broken) = %v", err)
+	}
+}
+
+// TestAutoTheme tests the auto theme and the scheme it follows
+func TestAutoTheme(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	os.MkdirAll(filepath.Join(home, ".cache", "wal"), 0755)
+	os.WriteFile(filepath.Join(home, ".cache", "wal", "colors.json"), []byte(`{"special": {"background": "#000000"}}`), 0644)
+
+	cfg := NewConfig()
+	cfg.parseConfigLine("animation.theme", "auto")
+	if cfg.GetAnimationTheme() != "auto" {
+		t.Fatalf("theme = %s, want auto", cfg.GetAnimationTheme())
+	}
+	_, args, err := cfg.GetConsoleCommandWith(LaunchOptions{})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	if cmd := strings.Join(args, " "); !contains(cmd, "--theme auto") || contains(cmd, "--theme-source") {
+		t.Errorf("Command = %s", cmd)
+	}
+
+	cfg.parseConfigLine("animation.theme_source", "~/.config/kitty/current-theme.conf")
+	cfg.parseConfigLine("animation.theme_source", "relative.conf")
+	_, args, _ = cfg.GetConsoleCommandWith(LaunchOptions{})
+	if cmd := strings.Join(args, " "); !contains(cmd, "--theme-source "+filepath.Join(home, ".config/kitty/current-theme.conf")) {
+		t.Errorf("Command missing the theme source: %s", cmd)
+	}
+	_, args, _ = cfg.GetConsoleCommandWith(LaunchOptions{Theme: "nord"})
+	if cmd := strings.Join(args, " "); contains(cmd, "--theme-source") {
+		t.Errorf("Named theme given a source: %s", cmd)
+	}
+}
+
+// TestPowerPolicies tests [policy.<state>] parsing and merging
+func TestPowerPolicies(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "policy.conf")
+	content := `[policy]
+low_battery = 15
+profiles = true
+
+[policy.battery]
+effects = rain, fire, bogus
+fps = 10
+timeout = 2m
+
+[policy.low-battery]
+screensaver = false
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg := NewConfig()
+	if cfg.HasPowerPolicies() {
+		t.Error("Default config should have no power policies")
+	}
+	if err := cfg.LoadFromFile(configPath); err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+
+	if cfg.GetPolicyLowBattery() != 15 {
+		t.Errorf("low_battery = %d, want 15", cfg.GetPolicyLowBattery())
+	}
+	if !cfg.UsePolicyProfiles() {
+		t.Error("profiles = false, want true")
+	}
+
+	battery := cfg.ResolvePowerPolicy([]string{"battery"})
+	if len(battery.Effects) != 2 || battery.Effects[0] != "rain" || battery.Effects[1] != "fire" {
+		t.Errorf("battery effects = %v, want [rain fire]", battery.Effects)
+	}
+	if battery.FPS != 10 || battery.Timeout != 2*time.Minute || battery.NoScreensaver {
+		t.Errorf("battery policy = %+v", battery)
+	}
+
+	low := cfg.ResolvePowerPolicy([]string{"battery", "low-battery"})
+	if !low.NoScreensaver || low.FPS != 10 {
+		t.Errorf("merged low-battery policy = %+v, want screensaver off and fps 10", low)
+	}
+
+	ac := cfg.ResolvePowerPolicy([]string{"ac"})
+	if ac.FPS != 0 || len(ac.Effects) != 0 {
+		t.Errorf("unconfigured ac policy = %+v, want zero value", ac)
+	}
+}
+
+// TestScheduleRules tests parsing of [schedule.*] sections
+func TestScheduleRules(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "schedule.conf")
+	content := `[schedule.work]
+days = mon-fri
+time = 09:00-17:30
+effect = rain
+theme = dracula
+datetime = false
+
+[schedule.night]
+time = 23:00-07:00
+power = off
+timeout = 1m
+theme = not-a-theme
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg := NewConfig()
+	if err := cfg.LoadFromFile(configPath); err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+
+	rules := cfg.GetScheduleRules()
+	if len(rules) != 2 || rules[0].Name != "work" || rules[1].Name != "night" {
+		t.Fatalf("rules = %+v, want work then night", rules)
+	}
+
+	work := rules[0]
+	if work.Days.Has(time.Saturday) || !work.Days.Has(time.Monday) {
+		t.Errorf("work days = %07b, want mon-fri", work.Days)
+	}
+	if work.Start != 9*60 || work.End != 17*60+30 {
+		t.Errorf("work time = %d-%d, want 540-1050", work.Start, work.End)
+	}
+	if work.Override.Effect != "rain" || work.Override.Theme != "dracula" {
+		t.Errorf("work override = %+v", work.Override)
+	}
+	if work.Override.Datetime == nil || *work.Override.Datetime {
+		t.Error("work datetime should be set to false")
+	}
+
+	night := rules[1]
+	if night.Days != schedule.AllDays {
+		t.Errorf("night days = %07b, want every day", night.Days)
+	}
+	if night.Override.Power != schedule.PowerOff || night.Override.Timeout != time.Minute {
+		t.Errorf("night override = %+v", night.Override)
+	}
+	if night.Override.Theme != "" {
+		t.Errorf("invalid theme should be ignored, got %q", night.Override.Theme)
+	}
+}
+
+// TestAppRules tests parsing and matching of [rules] entries
+func TestAppRules(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "rules.conf")
+	content := `[rules]
+code = timeout x3
+org.pwmt.zathura = inhibit, effect rain
+jetbrains-* = timeout 2
+* = inhibit, fullscreen
+bad = timeout xx
+short = timeout x0.5, inhibit
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg := NewConfig()
+	if cfg.HasAppRules() {
+		t.Error("Default config should have no app rules")
+	}
+	if err := cfg.LoadFromFile(configPath); err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+
+	tests := []struct {
+		appID      string
+		fullscreen bool
+		wantMatch  bool
+		want       AppRule
+	}{
+		{"code", false, true, AppRule{Pattern: "code", TimeoutScale: 3}},
+		{"Code", true, true, AppRule{Pattern: "code", TimeoutScale: 3}},
+		{"org.pwmt.zathura", false, true, AppRule{Pattern: "org.pwmt.zathura", Inhibit: true, Effect: "rain"}},
+		{"jetbrains-idea", false, true, AppRule{Pattern: "jetbrains-*", TimeoutScale: 2}},
+		{"mpv", true, true, AppRule{Pattern: "*", Inhibit: true, Fullscreen: true}},
+		{"mpv", false, false, AppRule{}},
+		{"bad", false, true, AppRule{Pattern: "bad"}},
+		{"short", false, true, AppRule{Pattern: "short", Inhibit: true}},
+	}
+
+	for _, tt := range tests {
+		rule, ok := cfg.MatchAppRule(tt.appID, tt.fullscreen)
+		if ok != tt.wantMatch || rule != tt.want {
+			t.Errorf("MatchAppRule(%q, %v) = %+v, %v; want %+v, %v", tt.appID, tt.fullscreen, rule, ok, tt.want, tt.wantMatch)
+		}
+	}
+}
+
 // TestSaveToFile tests saving configuration to file
 func TestSaveToFile(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -352,6 +1073,32 @@ func TestGetScreensaverCommand(t *testing.T) {
 	}
 }
 
+// TestGetConsoleCommand tests the display command for virtual consoles
+func TestGetConsoleCommand(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SetAnimationEffect("matrix")
+
+	binary, args, err := cfg.GetConsoleCommandWith(LaunchOptions{Theme: "nord"})
+	if err != nil {
+		t.Fatalf("GetConsoleCommandWith() error = %v", err)
+	}
+	if !contains(binary, "sysc-walls-display") {
+		t.Errorf("Command binary = %s, want sysc-walls-display", binary)
+	}
+
+	cmd := strings.Join(args, " ")
+	for _, want := range []string{"--effect matrix", "--theme nord", "--colors 16"} {
+		if !contains(cmd, want) {
+			t.Errorf("Command missing %q: %s", want, cmd)
+		}
+	}
+	for _, unwanted := range []string{"--class", "--fullscreen", "--start-as"} {
+		if contains(cmd, unwanted) {
+			t.Errorf("Console command has terminal option %q: %s", unwanted, cmd)
+		}
+	}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || containsMiddle(s, substr)))
@@ -0,0 +1,261 @@
+// figlet.go - FIGlet font loading
+package figlet
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed fonts/*.flf
+var bundled embed.FS
+
+// Layout selects how characters are fitted together. The low six bits are
+// the controlled smushing rules of the FIGfont 2 spec.
+type Layout int
+
+const (
+	SmushEqual      Layout = 1 << iota // Equal characters merge
+	SmushUnderscore                    // '_' is replaced by |/\[]{}()<>
+	SmushHierarchy                     // Of | /\ [] {} () <>, the later class wins
+	SmushPair                          // Opposite brackets become '|'
+	SmushBigX                          // /\ becomes |, \/ becomes Y, >< becomes X
+	SmushHardblank                     // Two hardblanks merge
+	Kerning                            // Characters move together until they touch
+	Smushing                           // Characters overlap by one column
+
+	// FullWidth keeps every character at its designed width
+	FullWidth Layout = 0
+)
+
+// smushRules masks the controlled smushing rules
+const smushRules = SmushEqual | SmushUnderscore | SmushHierarchy | SmushPair | SmushBigX | SmushHardblank
+
+// deutschCodes are the characters every font defines after ASCII
+var deutschCodes = []rune{196, 214, 220, 228, 246, 252, 223}
+
+// Font is a parsed FIGlet font
+type Font struct {
+	Name     string
+	Height   int    // Rows per character
+	Baseline int    // Rows from the top to the baseline
+	Layout   Layout // Horizontal layout, from the font header by default
+
+	hardblank rune
+	glyphs    map[rune][][]rune
+}
+
+// Parse reads a .flf font
+func Parse(name string, r io.Reader) (*Font, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("font %s: empty file", name)
+	}
+	header := scanner.Text()
+	if !strings.HasPrefix(header, "flf2a") || len(header) < 6 {
+		return nil, fmt.Errorf("font %s: not a FIGlet font", name)
+	}
+	fields := strings.Fields(header[5:])
+	if len(fields) < 6 {
+		return nil, fmt.Errorf("font %s: short header %q", name, header)
+	}
+	hardblank := []rune(fields[0])[0]
+
+	nums := make([]int, len(fields)-1)
+	for i, field := range fields[1:] {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("font %s: bad header field %q", name, field)
+		}
+		nums[i] = n
+	}
+	height, baseline, oldLayout, comments := nums[0], nums[1], nums[3], nums[4]
+	if height < 1 {
+		return nil, fmt.Errorf("font %s: invalid height %d", name, height)
+	}
+
+	f := &Font{
+		Name:      name,
+		Height:    height,
+		Baseline:  baseline,
+		Layout:    layoutFromHeader(oldLayout, nums[5:]),
+		hardblank: hardblank,
+		glyphs:    make(map[rune][][]rune),
+	}
+
+	for i := 0; i < comments; i++ {
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("font %s: truncated comments", name)
+		}
+	}
+
+	// ASCII 32-126 and the Deutsch characters, in order
+	for code := rune(32); code <= 126; code++ {
+		glyph, err := f.readGlyph(scanner)
+		if err != nil {
+			return nil, fmt.Errorf("font %s: character %d: %w", name, code, err)
+		}
+		f.glyphs[code] = glyph
+	}
+	for _, code := range deutschCodes {
+		glyph, err := f.readGlyph(scanner)
+		if err == io.EOF {
+			return f, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("font %s: character %d: %w", name, code, err)
+		}
+		f.glyphs[code] = glyph
+	}
+
+	// Code-tagged characters: a line with the code, then the character
+	for scanner.Scan() {
+		tag := strings.Fields(scanner.Text())
+		if len(tag) == 0 {
+			continue
+		}
+		code, err := strconv.ParseInt(tag[0], 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("font %s: bad code tag %q", name, tag[0])
+		}
+		glyph, err := f.readGlyph(scanner)
+		if err != nil {
+			return nil, fmt.Errorf("font %s: character %d: %w", name, code, err)
+		}
+		// Negative codes are reserved for translation tables
+		if code >= 0 {
+			f.glyphs[rune(code)] = glyph
+		}
+	}
+	return f, scanner.Err()
+}
+
+// layoutFromHeader picks the horizontal layout. The full layout field
+// takes precedence when present; the old field is -1 for full width, 0
+// for kerning and otherwise the smushing rules.
+func layoutFromHeader(oldLayout int, rest []int) Layout {
+	// rest is print direction, full layout, code tag count
+	if len(rest) >= 2 {
+		return Layout(rest[1]) & (smushRules | Kerning | Smushing)
+	}
+	switch {
+	case oldLayout < 0:
+		return FullWidth
+	case oldLayout == 0:
+		return Kerning
+	default:
+		return Smushing | Layout(oldLayout)&smushRules
+	}
+}
+
+// readGlyph reads one character's rows, dropping the endmarks
+func (f *Font) readGlyph(scanner *bufio.Scanner) ([][]rune, error) {
+	rows := make([][]rune, f.Height)
+	width := 0
+	for i := range rows {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+			if i == 0 {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("truncated character")
+		}
+		line := []rune(strings.TrimRight(scanner.Text(), " \t\r"))
+		if n := len(line); n > 0 {
+			end := line[n-1]
+			for n > 0 && line[n-1] == end {
+				n--
+			}
+			line = line[:n]
+		}
+		rows[i] = line
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+
+	// Every row of a character has the same width
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, ' ')
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// Has reports whether the font draws r
+func (f *Font) Has(r rune) bool {
+	_, ok := f.glyphs[r]
+	return ok
+}
+
+// LoadFile reads a .flf font from disk
+func LoadFile(path string) (*Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(strings.TrimSuffix(filepath.Base(path), ".flf"), bytes.NewReader(data))
+}
+
+// Load returns a font by name: a bundled font, then one in the user's font
+// directory (~/.config/sysc-walls/fonts). Names with a slash or ending in
+// .flf are read as paths.
+func Load(name string) (*Font, error) {
+	if strings.ContainsRune(name, '/') || strings.HasSuffix(name, ".flf") {
+		return LoadFile(name)
+	}
+	if data, err := bundled.ReadFile("fonts/" + name + ".flf"); err == nil {
+		return Parse(name, bytes.NewReader(data))
+	}
+	if dir := UserFontDir(); dir != "" {
+		path := filepath.Join(dir, name+".flf")
+		if _, err := os.Stat(path); err == nil {
+			return LoadFile(path)
+		}
+	}
+	return nil, fmt.Errorf("unknown font %q (bundled fonts: %s)", name, strings.Join(Names(), ", "))
+}
+
+// MustLoad is like Load but panics if the font can't be loaded. It is meant
+// for bundled fonts.
+func MustLoad(name string) *Font {
+	f, err := Load(name)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Names returns the bundled font names
+func Names() []string {
+	entries, _ := bundled.ReadDir("fonts")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".flf"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UserFontDir returns the directory searched for extra fonts, or "" if
+// HOME is unset
+func UserFontDir() string {
+	homeDir := os.Getenv("HOME")
+	if homeDir == "" || !filepath.IsAbs(homeDir) {
+		return ""
+	}
+	return filepath.Join(homeDir, ".config", "sysc-walls", "fonts")
+}
@@ -0,0 +1,339 @@
+package figlet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testFont builds a one-row font where only the given characters are drawn
+func testFont(t *testing.T, layout Layout, glyphs map[rune]string) *Font {
+	t.Helper()
+	var b strings.Builder
+	fmt.Fprintf(&b, "flf2a$ 1 1 10 -1 1 0 %d 0\n", layout)
+	b.WriteString("test font\n")
+	for code := rune(32); code <= 126; code++ {
+		b.WriteString(glyphs[code] + "@@\n")
+	}
+	for _, code := range deutschCodes {
+		b.WriteString(glyphs[code] + "@@\n")
+	}
+	if g, ok := glyphs[0]; ok {
+		b.WriteString("0 missing\n" + g + "@@\n")
+	}
+
+	f, err := Parse("test", strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return f
+}
+
+// TestParse tests the header, endmarks and code-tagged characters
+func TestParse(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("flf2a# 2 1 8 0 2 0 64 2\r\n")
+	b.WriteString("comment one\ncomment two\n")
+	for code := 32; code <= 126; code++ {
+		if code == 'A' {
+			b.WriteString(" /\\ @\n/##\\@@\n")
+			continue
+		}
+		b.WriteString("#@\n#@@\n")
+	}
+	for range deutschCodes {
+		b.WriteString("@\n@@\n")
+	}
+	b.WriteString("0x263A smiley\n:)$\n:)$$\n")
+	b.WriteString("-2 translation table\nx@\nx@@\n")
+
+	f, err := Parse("test", strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if f.Height != 2 || f.Baseline != 1 || f.Layout != Kerning || f.hardblank != '#' {
+		t.Errorf("header = height %d, baseline %d, layout %d, hardblank %q", f.Height, f.Baseline, f.Layout, f.hardblank)
+	}
+	if got := string(f.glyphs['A'][0]) + "|" + string(f.glyphs['A'][1]); got != " /\\ |/##\\" {
+		t.Errorf("'A' = %q", got)
+	}
+	if got := string(f.glyphs['☺'][1]); got != ":)" {
+		t.Errorf("code-tagged character = %q, want \":)\"", got)
+	}
+	if f.Has(-2) || !f.Has('ä') {
+		t.Error("negative codes should be skipped and Deutsch characters kept")
+	}
+
+	if _, err := Parse("bad", strings.NewReader("flf2a$ 2 1\n")); err == nil {
+		t.Error("Parse() with a short header should fail")
+	}
+	if _, err := Parse("bad", strings.NewReader("flf2a$ 2 1 8 0 0\n#@\n")); err == nil {
+		t.Error("Parse() with a truncated character should fail")
+	}
+}
+
+// TestLayoutFromHeader tests the old and full layout fields
+func TestLayoutFromHeader(t *testing.T) {
+	tests := []struct {
+		old  int
+		rest []int
+		want Layout
+	}{
+		{-1, nil, FullWidth},
+		{0, nil, Kerning},
+		{15, nil, Smushing | SmushEqual | SmushUnderscore | SmushHierarchy | SmushPair},
+		{15, []int{0, 24463, 229}, Smushing | SmushEqual | SmushUnderscore | SmushHierarchy | SmushPair},
+		{0, []int{0, 64}, Kerning},
+		{-1, []int{0, 0}, FullWidth},
+	}
+
+	for _, tt := range tests {
+		if got := layoutFromHeader(tt.old, tt.rest); got != tt.want {
+			t.Errorf("layoutFromHeader(%d, %v) = %d, want %d", tt.old, tt.rest, got, tt.want)
+		}
+	}
+}
+
+// TestRenderRules tests fitting and each smushing rule against renders
+// worked out from the FIGfont spec
+func TestRenderRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		a, b   string
+		want   string
+	}{
+		{"full width", FullWidth, "a| ", " |b", "a|  |b"},
+		{"kerning", Kerning, "a| ", " |b", "a||b"},
+		{"equal", Smushing | SmushEqual, "a| ", " |b", "a|b"},
+		{"underscore", Smushing | SmushUnderscore, "a_", "|b", "a|b"},
+		{"hierarchy", Smushing | SmushHierarchy, "a/", "|b", "a/b"},
+		{"opposite pair", Smushing | SmushPair, "a[", "]b", "a|b"},
+		{"big X", Smushing | SmushBigX, "a>", "<b", "aXb"},
+		{"big X slashes", Smushing | SmushBigX, "a\\", "/b", "aYb"},
+		{"universal", Smushing, "a/", "|b", "a|b"},
+		{"no rule applies", Smushing | SmushEqual, "a/", "|b", "a/|b"},
+		{"hardblank rule", Smushing | SmushHardblank, "a$", "$b", "a b"},
+		{"hardblanks kept apart", Smushing | SmushEqual, "a$", "$b", "a  b"},
+		{"universal keeps visible over hardblank", Smushing, "a$", "|b", "a|b"},
+		{"one-column characters", Smushing | SmushEqual, "|", "|", "||"},
+	}
+
+	for _, tt := range tests {
+		f := testFont(t, tt.layout, map[rune]string{'a': tt.a, 'b': tt.b})
+		if got := f.Render("ab"); len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%s: Render(\"ab\") = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestRenderRows tests fitting over several rows, line breaks and missing
+// characters
+func TestRenderRows(t *testing.T) {
+	font := "flf2a$ 2 2 6 0 0\n"
+	for code := rune(32); code <= 126; code++ {
+		switch code {
+		case 'L':
+			font += "#  @\n#  @@\n"
+		case 'R':
+			font += " #@\n##@@\n"
+		default:
+			font += "@\n@@\n"
+		}
+	}
+	f, err := Parse("rows", strings.NewReader(font))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// The bottom row touches first
+	want := []string{"# #", "###", "#  ", "#  "}
+	got := f.Render("L€R\nL")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	// Character 0 stands in for missing characters
+	g := testFont(t, FullWidth, map[rune]string{'a': "a", 0: "?"})
+	if got := g.Render("a€a"); got[0] != "a?a" {
+		t.Errorf("Render() with character 0 = %q, want \"a?a\"", got[0])
+	}
+}
+
+// TestBundledFonts tests that every bundled font parses and draws ASCII
+func TestBundledFonts(t *testing.T) {
+	names := Names()
+	if len(names) < 3 {
+		t.Fatalf("Names() = %v", names)
+	}
+	for _, name := range names {
+		f, err := Load(name)
+		if err != nil {
+			t.Fatalf("Load(%q) error = %v", name, err)
+		}
+		for code := rune(32); code <= 126; code++ {
+			if !f.Has(code) {
+				t.Errorf("%s: missing %q", name, code)
+			}
+		}
+		if lines := f.Render("Hi 42"); len(lines) != f.Height || strings.TrimSpace(strings.Join(lines, "")) == "" {
+			t.Errorf("%s: Render() = %q", name, lines)
+		}
+	}
+
+	if _, err := Load("no-such-font"); err == nil {
+		t.Error("Load() of an unknown font should fail")
+	}
+}
+
+// TestBundledRenders tests bundled fonts against renders this parser made
+// once they looked right, to catch regressions. TestFigletRenders checks
+// them against figlet itself.
+func TestBundledRenders(t *testing.T) {
+	tests := []struct {
+		font string
+		text string
+		want []string
+	}{
+		// The clock digits from before fonts were configurable
+		{"kompaktblk", "12:30 PM", []string{
+			" ▄█   ▀▀▀▀█   ▄   ▀▀▀▀▄ ▄▀▀█▄       █▀▀▀▄ █▀▄▀█ ",
+			"  █   █▀▀▀▀   ▄     ▀▀▄ █▄▀ █       █▀▀▀  █   █ ",
+			"▀▀▀▀▀ ▀▀▀▀▀       ▀▀▀▀   ▀▀▀        ▀     ▀   ▀ ",
+		}},
+		{"block", "HI", []string{
+			"█   █ ███ ",
+			"█   █  █  ",
+			"█████  █  ",
+			"█   █  █  ",
+			"█   █ ███ ",
+		}},
+		{"dots", "Ok", []string{
+			"⡎⠉⡆⡧⢔⠁",
+			"⠈⠉ ⠁ ⠁",
+		}},
+	}
+
+	for _, tt := range tests {
+		got := MustLoad(tt.font).Render(tt.text)
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s %q:\n%s\nwant:\n%s", tt.font, tt.text, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+	}
+}
+
+// TestSmushFixture tests testdata/smush.flf, whose character pairs each
+// need one smushing rule, against renders worked out from the FIGfont spec.
+// TestFigletRenders checks the font against figlet itself.
+func TestSmushFixture(t *testing.T) {
+	f, err := Load(filepath.Join("testdata", "smush.flf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Layout != Smushing|smushRules {
+		t.Fatalf("layout = %d, want every rule", f.Layout)
+	}
+
+	tests := []struct {
+		rule string
+		text string
+		want string
+	}{
+		{"equal", "EF", "x|y"},
+		{"underscore", "UV", "x/y"},
+		{"hierarchy", "HI", "x[y"},
+		{"opposite pair", "PQ", "x|y"},
+		{"big X", "XY", "xXy"},
+		{"big X /\\", "ST", "x|y"},
+		{"big X \\/", "ZW", "xYy"},
+		{"hardblank", "BC", "x y"},
+		{"no rule between pairs", "FU", "|yx_"},
+	}
+	var text, want string
+	for _, tt := range tests {
+		if got := f.Render(tt.text); got[0] != tt.want {
+			t.Errorf("%s: Render(%q) = %q, want %q", tt.rule, tt.text, got[0], tt.want)
+		}
+		if tt.rule != "no rule between pairs" {
+			text += tt.text
+			want += tt.want
+		}
+	}
+	if got := f.Render(text); got[0] != want {
+		t.Errorf("Render(%q) = %q, want %q", text, got[0], want)
+	}
+}
+
+// TestFigletRenders tests bundled fonts and the fixtures in testdata against
+// renders of the real figlet, written by scripts/figlet-references.sh.
+// Trailing spaces are ignored.
+func TestFigletRenders(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "figlet", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Skip("no figlet renders, run scripts/figlet-references.sh")
+	}
+
+	trim := func(lines []string) string {
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " ")
+		}
+		return strings.Join(lines, "\n")
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The text is on the first line, figlet's render below it
+		text, render, _ := strings.Cut(strings.TrimSuffix(string(data), "\n"), "\n")
+		font := strings.TrimSuffix(filepath.Base(path), ".txt")
+
+		f, err := Load(font)
+		if err != nil {
+			// Fixtures are in testdata rather than bundled
+			f, err = Load(filepath.Join("testdata", font+".flf"))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := trim(f.Render(text))
+		if want := trim(strings.Split(render, "\n")); got != want {
+			t.Errorf("%s %q:\n%s\nfiglet:\n%s", font, text, got, want)
+		}
+	}
+}
+
+// TestLoadUserFont tests fonts from the user's font directory and by path
+func TestLoadUserFont(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := UserFontDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, err := bundled.ReadFile("fonts/block.flf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "mine.flf")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"mine", path} {
+		f, err := Load(name)
+		if err != nil {
+			t.Fatalf("Load(%q) error = %v", name, err)
+		}
+		if f.Name != "mine" || f.Height != 5 {
+			t.Errorf("Load(%q) = %s, height %d", name, f.Name, f.Height)
+		}
+	}
+}
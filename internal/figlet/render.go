@@ -0,0 +1,170 @@
+// render.go - Text to FIGlet art with kerning and smushing
+package figlet
+
+import "strings"
+
+// Render draws text in the font, Height rows per line of text. Lines are
+// stacked without vertical smushing. Characters the font lacks are drawn
+// with its character 0 if it has one, or left out.
+//
+// Fitting follows the reference figlet: each character moves left as far
+// as its layout allows, measured over all rows.
+func (f *Font) Render(text string) []string {
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		out = append(out, f.renderLine(line)...)
+	}
+	return out
+}
+
+// renderLine draws one line of text
+func (f *Font) renderLine(text string) []string {
+	rows := make([][]rune, f.Height)
+	prevWidth := 0
+	for _, r := range text {
+		glyph, ok := f.glyphs[r]
+		if !ok {
+			if glyph, ok = f.glyphs[0]; !ok {
+				continue
+			}
+		}
+		width := len(glyph[0])
+
+		amount := f.smushAmount(rows, glyph, prevWidth, width)
+		for i, row := range rows {
+			for k := 0; k < amount; k++ {
+				if col := len(row) - amount + k; col >= 0 {
+					row[col] = f.smush(row[col], glyph[i][k], prevWidth, width)
+				}
+			}
+			rows[i] = append(row, glyph[i][amount:]...)
+		}
+		prevWidth = width
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = strings.ReplaceAll(string(row), string(f.hardblank), " ")
+	}
+	return lines
+}
+
+// smushAmount returns how many columns the next character can overlap the
+// output: the blank gap between them in the tightest row, plus one where
+// the touching characters smush
+func (f *Font) smushAmount(rows [][]rune, glyph [][]rune, prevWidth, width int) int {
+	if f.Layout&(Kerning|Smushing) == 0 {
+		return 0
+	}
+
+	amount := width
+	for i, row := range rows {
+		// Last visible column of the output row
+		end := len(row)
+		var left rune
+		for end > 0 && (left == 0 || left == ' ') {
+			end--
+			left = row[end]
+		}
+
+		// First visible column of the character row
+		start := 0
+		var right rune
+		for ; start < len(glyph[i]); start++ {
+			if right = glyph[i][start]; right != ' ' {
+				break
+			}
+		}
+		if start == len(glyph[i]) {
+			right = 0
+		}
+
+		n := start + len(row) - 1 - end
+		if left == 0 || left == ' ' {
+			n++
+		} else if right != 0 && f.smush(left, right, prevWidth, width) != 0 {
+			n++
+		}
+		if n < amount {
+			amount = n
+		}
+	}
+	return amount
+}
+
+// smush returns the character that results from overlapping left and
+// right, or 0 if they can't overlap
+func (f *Font) smush(left, right rune, prevWidth, width int) rune {
+	if left == ' ' {
+		return right
+	}
+	if right == ' ' {
+		return left
+	}
+	// One-column characters never smush
+	if prevWidth < 2 || width < 2 {
+		return 0
+	}
+	if f.Layout&Smushing == 0 {
+		return 0
+	}
+
+	// Universal smushing: the later character wins over visible ones
+	if f.Layout&smushRules == 0 {
+		if left == f.hardblank {
+			return right
+		}
+		if right == f.hardblank {
+			return left
+		}
+		return right
+	}
+
+	if f.Layout&SmushHardblank != 0 && left == f.hardblank && right == f.hardblank {
+		return left
+	}
+	if left == f.hardblank || right == f.hardblank {
+		return 0
+	}
+	if f.Layout&SmushEqual != 0 && left == right {
+		return left
+	}
+	if f.Layout&SmushUnderscore != 0 {
+		const replacers = "|/\\[]{}()<>"
+		if left == '_' && strings.ContainsRune(replacers, right) {
+			return right
+		}
+		if right == '_' && strings.ContainsRune(replacers, left) {
+			return left
+		}
+	}
+	if f.Layout&SmushHierarchy != 0 {
+		classes := []string{"|", "/\\", "[]", "{}", "()", "<>"}
+		for i, class := range classes {
+			later := strings.Join(classes[i+1:], "")
+			if strings.ContainsRune(class, left) && strings.ContainsRune(later, right) {
+				return right
+			}
+			if strings.ContainsRune(class, right) && strings.ContainsRune(later, left) {
+				return left
+			}
+		}
+	}
+	if f.Layout&SmushPair != 0 {
+		switch string([]rune{left, right}) {
+		case "[]", "][", "{}", "}{", "()", ")(":
+			return '|'
+		}
+	}
+	if f.Layout&SmushBigX != 0 {
+		switch string([]rune{left, right}) {
+		case "/\\":
+			return '|'
+		case "\\/":
+			return 'Y'
+		case "><":
+			return 'X'
+		}
+	}
+	return 0
+}
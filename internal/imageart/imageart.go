@@ -0,0 +1,397 @@
+// Package imageart converts images into art for the text-based effects: a
+// mask of characters the effects form, plus the image's colors for them
+package imageart
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // Registers the GIF decoder
+	_ "image/jpeg" // Registers the JPEG decoder
+	_ "image/png"  // Registers the PNG decoder
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Conversion modes
+const (
+	ModeBlocks = "blocks" // Unicode half blocks, two square pixels per cell
+	ModeRamp   = "ramp"   // One character per cell from a luminance ramp
+)
+
+// Modes lists the conversion modes
+var Modes = []string{ModeBlocks, ModeRamp}
+
+// ramp holds the characters of ModeRamp from no ink to full ink
+const ramp = " .:-=+*#%@"
+
+// Half blocks of ModeBlocks
+const (
+	upperHalf = '▀'
+	lowerHalf = '▄'
+	fullBlock = '█'
+)
+
+// MaxPixels is the largest image accepted, in pixels
+const MaxPixels = 6000 * 6000
+
+// Extensions are the image file extensions converted instead of read as text
+var Extensions = []string{".png", ".jpg", ".jpeg", ".gif"}
+
+// IsImage reports whether a file is an image by its extension
+func IsImage(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range Extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Load decodes a PNG, JPEG or GIF file. Animated GIFs give their first frame.
+func Load(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	config, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a PNG, JPEG or GIF image: %w", path, err)
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, fmt.Errorf("%s is too large (%dx%d)", path, config.Width, config.Height)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return img, nil
+}
+
+// Options controls a conversion
+type Options struct {
+	Mode string // ModeBlocks or ModeRamp
+
+	// Threshold is the ink, from 0 to 1, a pixel needs to be drawn. Ink is
+	// opacity for images with transparency and brightness for the rest.
+	Threshold float64
+
+	Dither bool // Diffuse the rounding error (Floyd-Steinberg) for smoother shading
+	Invert bool // Dark pixels are ink, for dark art on a light background
+}
+
+// DefaultOptions are the options used when none are configured
+var DefaultOptions = Options{Mode: ModeBlocks, Threshold: 0.5}
+
+// Validate checks the options
+func (o Options) Validate() error {
+	if o.Mode != ModeBlocks && o.Mode != ModeRamp {
+		return fmt.Errorf("unknown image mode %q (use %s)", o.Mode, strings.Join(Modes, " or "))
+	}
+	if o.Threshold < 0 || o.Threshold > 1 {
+		return fmt.Errorf("image threshold %g is outside 0-1", o.Threshold)
+	}
+	return nil
+}
+
+// Cell is how one cell of the art looks in the image's colors
+type Cell struct {
+	Rune rune       // The character to draw, ' ' for none
+	Fg   color.RGBA // Color of the character
+	Bg   color.RGBA // Background, for two-color half blocks; zero alpha for none
+}
+
+// Art is a converted image
+type Art struct {
+	Lines  []string // The mask, equally wide lines of characters
+	Colors [][]Cell // The image's colors for each cell of Lines
+}
+
+// Text returns the art as text for an effect
+func (a *Art) Text() string {
+	return strings.Join(a.Lines, "\n")
+}
+
+// Width returns the width of the art in cells
+func (a *Art) Width() int {
+	if len(a.Lines) == 0 {
+		return 0
+	}
+	return len([]rune(a.Lines[0]))
+}
+
+// pixel is a resampled pixel: its straight color and its ink
+type pixel struct {
+	r, g, b float64
+	ink     float64
+}
+
+// Convert turns an image into art at most cols by rows cells. The image is
+// scaled to fit, keeping its aspect ratio with terminal cells twice as tall
+// as they are wide, and blank rows and columns around it are trimmed.
+func Convert(img image.Image, cols, rows int, opts Options) *Art {
+	if cols < 1 || rows < 1 {
+		return &Art{}
+	}
+	if opts.Mode != ModeRamp {
+		opts.Mode = ModeBlocks
+	}
+
+	// Size in square pixels: a cell is one wide and two tall
+	bounds := img.Bounds()
+	w, h := fit(bounds.Dx(), bounds.Dy(), cols, rows*2)
+	if opts.Mode == ModeBlocks {
+		h += h % 2
+	} else {
+		h = (h + 1) / 2 // Ramp samples one pixel per cell
+	}
+	if w == 0 || h == 0 {
+		return &Art{}
+	}
+
+	pixels := resample(img, w, h)
+	inkOf(pixels, opts.Invert)
+
+	levels := []float64{0, 1}
+	if opts.Mode == ModeRamp {
+		levels = rampLevels(opts.Threshold)
+	}
+	quantized := quantize(pixels, w, h, levels, opts.Threshold, opts.Dither)
+
+	var art *Art
+	if opts.Mode == ModeBlocks {
+		art = blocks(pixels, quantized, w, h)
+	} else {
+		art = rampArt(pixels, quantized, w, h)
+	}
+	return art.trim()
+}
+
+// fit scales w by h to the largest size within maxW by maxH
+func fit(w, h, maxW, maxH int) (int, int) {
+	if w <= 0 || h <= 0 {
+		return 0, 0
+	}
+	scale := math.Min(float64(maxW)/float64(w), float64(maxH)/float64(h))
+	fw := int(math.Max(1, math.Round(float64(w)*scale)))
+	fh := int(math.Max(1, math.Round(float64(h)*scale)))
+	return min(fw, maxW), min(fh, maxH)
+}
+
+// resample averages the image over a w by h grid, weighting colors by
+// opacity
+func resample(img image.Image, w, h int) []pixel {
+	bounds := img.Bounds()
+	pixels := make([]pixel, w*h)
+	sx := float64(bounds.Dx()) / float64(w)
+	sy := float64(bounds.Dy()) / float64(h)
+
+	for y := 0; y < h; y++ {
+		y0 := bounds.Min.Y + int(float64(y)*sy)
+		y1 := max(bounds.Min.Y+int(float64(y+1)*sy), y0+1)
+		for x := 0; x < w; x++ {
+			x0 := bounds.Min.X + int(float64(x)*sx)
+			x1 := max(bounds.Min.X+int(float64(x+1)*sx), x0+1)
+
+			var r, g, b, a float64
+			n := 0
+			for py := y0; py < y1 && py < bounds.Max.Y; py++ {
+				for px := x0; px < x1 && px < bounds.Max.X; px++ {
+					cr, cg, cb, ca := img.At(px, py).RGBA()
+					r += float64(cr)
+					g += float64(cg)
+					b += float64(cb)
+					a += float64(ca)
+					n++
+				}
+			}
+
+			p := &pixels[y*w+x]
+			if a > 0 {
+				// Premultiplied sums back to straight color
+				p.r, p.g, p.b = r/a, g/a, b/a
+			}
+			if n > 0 {
+				p.ink = a / float64(n) / 0xffff // Opacity for now
+			}
+		}
+	}
+	return pixels
+}
+
+// inkOf sets the ink of each pixel: its opacity when the image has
+// transparency, else its brightness
+func inkOf(pixels []pixel, invert bool) {
+	transparent := false
+	for _, p := range pixels {
+		if p.ink < 0.99 {
+			transparent = true
+			break
+		}
+	}
+
+	for i := range pixels {
+		p := &pixels[i]
+		if !transparent {
+			p.ink = 0.2126*p.r + 0.7152*p.g + 0.0722*p.b
+		}
+		if invert {
+			p.ink = 1 - p.ink
+		}
+	}
+}
+
+// rampLevels returns the ink each ramp character stands for: none, then
+// evenly from the threshold to full
+func rampLevels(threshold float64) []float64 {
+	n := len(ramp) - 1
+	levels := []float64{0}
+	for i := 0; i < n; i++ {
+		levels = append(levels, threshold+(1-threshold)*float64(i)/float64(n-1))
+	}
+	return levels
+}
+
+// quantize picks a level for each pixel. Pixels below the threshold get
+// level 0, the rest the nearest of the other levels. Dithering carries each
+// pixel's error to the pixels right of and below it.
+func quantize(pixels []pixel, w, h int, levels []float64, threshold float64, dither bool) []int {
+	ink := make([]float64, len(pixels))
+	for i, p := range pixels {
+		ink[i] = p.ink
+	}
+
+	out := make([]int, len(pixels))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := y*w + x
+			v := ink[i]
+			level := 0
+			if v >= threshold && (threshold > 0 || v > 0) {
+				level = 1
+				for l := 2; l < len(levels); l++ {
+					if math.Abs(v-levels[l]) < math.Abs(v-levels[level]) {
+						level = l
+					}
+				}
+			}
+			out[i] = level
+			if !dither {
+				continue
+			}
+
+			err := v - levels[level]
+			spread := func(dx, dy int, weight float64) {
+				nx, ny := x+dx, y+dy
+				if nx >= 0 && nx < w && ny < h {
+					ink[ny*w+nx] += err * weight
+				}
+			}
+			spread(1, 0, 7.0/16)
+			spread(-1, 1, 3.0/16)
+			spread(0, 1, 5.0/16)
+			spread(1, 1, 1.0/16)
+		}
+	}
+	return out
+}
+
+// blocks draws pairs of pixel rows as half blocks
+func blocks(pixels []pixel, levels []int, w, h int) *Art {
+	art := &Art{}
+	on := func(i int) bool { return levels[i] > 0 }
+	for y := 0; y < h; y += 2 {
+		line := make([]rune, w)
+		colors := make([]Cell, w)
+		for x := 0; x < w; x++ {
+			top, bottom := y*w+x, (y+1)*w+x
+			switch {
+			case on(top) && on(bottom):
+				line[x] = fullBlock
+				colors[x] = Cell{Rune: fullBlock, Fg: rgba(pixels[top])}
+				if rgba(pixels[top]) != rgba(pixels[bottom]) {
+					// Two colors in one cell: the upper half over a background
+					colors[x] = Cell{Rune: upperHalf, Fg: rgba(pixels[top]), Bg: rgba(pixels[bottom])}
+				}
+			case on(top):
+				line[x] = upperHalf
+				colors[x] = Cell{Rune: upperHalf, Fg: rgba(pixels[top])}
+			case on(bottom):
+				line[x] = lowerHalf
+				colors[x] = Cell{Rune: lowerHalf, Fg: rgba(pixels[bottom])}
+			default:
+				line[x] = ' '
+				colors[x] = Cell{Rune: ' '}
+			}
+		}
+		art.Lines = append(art.Lines, string(line))
+		art.Colors = append(art.Colors, colors)
+	}
+	return art
+}
+
+// rampArt draws each pixel as the ramp character of its level
+func rampArt(pixels []pixel, levels []int, w, h int) *Art {
+	art := &Art{}
+	for y := 0; y < h; y++ {
+		line := make([]rune, w)
+		colors := make([]Cell, w)
+		for x := 0; x < w; x++ {
+			i := y*w + x
+			line[x] = rune(ramp[levels[i]])
+			colors[x] = Cell{Rune: line[x]}
+			if levels[i] > 0 {
+				colors[x].Fg = rgba(pixels[i])
+			}
+		}
+		art.Lines = append(art.Lines, string(line))
+		art.Colors = append(art.Colors, colors)
+	}
+	return art
+}
+
+// rgba returns the color of a pixel
+func rgba(p pixel) color.RGBA {
+	to8 := func(v float64) uint8 { return uint8(math.Round(math.Min(1, math.Max(0, v)) * 255)) }
+	return color.RGBA{to8(p.r), to8(p.g), to8(p.b), 0xff}
+}
+
+// trim removes the blank rows and columns around the art
+func (a *Art) trim() *Art {
+	blankRow := func(y int) bool { return strings.TrimSpace(a.Lines[y]) == "" }
+	for len(a.Lines) > 0 && blankRow(0) {
+		a.Lines, a.Colors = a.Lines[1:], a.Colors[1:]
+	}
+	for len(a.Lines) > 0 && blankRow(len(a.Lines)-1) {
+		a.Lines, a.Colors = a.Lines[:len(a.Lines)-1], a.Colors[:len(a.Colors)-1]
+	}
+	if len(a.Lines) == 0 {
+		return &Art{}
+	}
+
+	width := a.Width()
+	left, right := width, 0
+	for _, line := range a.Lines {
+		runes := []rune(line)
+		for x, r := range runes {
+			if r != ' ' {
+				left = min(left, x)
+				right = max(right, x+1)
+			}
+		}
+	}
+	for y, line := range a.Lines {
+		a.Lines[y] = string([]rune(line)[left:right])
+		a.Colors[y] = a.Colors[y][left:right]
+	}
+	return a
+}
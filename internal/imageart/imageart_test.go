@@ -0,0 +1,156 @@
+package imageart
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// filled returns a w by h image of one color
+func filled(w, h int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// TestConvertBlocks tests half block masks and colors of a transparent image
+func TestConvertBlocks(t *testing.T) {
+	red := color.NRGBA{255, 0, 0, 255}
+	img := filled(20, 10, color.Transparent)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			img.Set(x, y, red)
+		}
+	}
+
+	art := Convert(img, 10, 10, DefaultOptions)
+	if want := "█████\n█████\n█████"; art.Text() != want {
+		t.Fatalf("art =\n%s\nwant\n%s", art.Text(), want)
+	}
+	if got := art.Colors[1][2]; got != (Cell{Rune: '█', Fg: color.RGBA{255, 0, 0, 255}}) {
+		t.Errorf("color = %+v", got)
+	}
+
+	// A cell with two colors is an upper half over a background
+	img = filled(2, 2, color.NRGBA{0, 0, 255, 255})
+	img.Set(0, 0, red)
+	img.Set(1, 0, red)
+	art = Convert(img, 1, 1, Options{Mode: ModeBlocks, Threshold: 0.05})
+	if got := art.Colors[0][0]; got.Rune != '▀' || got.Fg.R != 255 || got.Bg.B != 255 {
+		t.Errorf("two-color cell = %+v", got)
+	}
+}
+
+// TestConvertFits tests that images keep their shape at any size
+func TestConvertFits(t *testing.T) {
+	square := filled(10, 10, color.White)
+	for _, tc := range []struct {
+		mode       string
+		cols, rows int
+		w, h       int
+	}{
+		{ModeBlocks, 100, 10, 20, 10},
+		{ModeBlocks, 8, 50, 8, 4},
+		{ModeRamp, 100, 10, 20, 10},
+		{ModeRamp, 8, 50, 8, 4},
+	} {
+		art := Convert(square, tc.cols, tc.rows, Options{Mode: tc.mode, Threshold: 0.5})
+		if art.Width() != tc.w || len(art.Lines) != tc.h {
+			t.Errorf("%s in %dx%d = %dx%d, want %dx%d", tc.mode, tc.cols, tc.rows, art.Width(), len(art.Lines), tc.w, tc.h)
+		}
+	}
+
+	if art := Convert(square, 0, 10, DefaultOptions); len(art.Lines) != 0 {
+		t.Errorf("art without room = %q", art.Lines)
+	}
+}
+
+// TestConvertOptions tests the threshold, invert, ramp and dither options
+func TestConvertOptions(t *testing.T) {
+	// A white square in the middle of black
+	img := filled(8, 8, color.Black)
+	for y := 2; y < 6; y++ {
+		for x := 2; x < 6; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	if art := Convert(img, 8, 4, DefaultOptions); art.Text() != "████\n████" {
+		t.Errorf("bright ink =\n%s", art.Text())
+	}
+	inverted := Convert(img, 8, 4, Options{Mode: ModeBlocks, Threshold: 0.5, Invert: true})
+	if want := "████████\n██    ██\n██    ██\n████████"; inverted.Text() != want {
+		t.Errorf("inverted =\n%s", inverted.Text())
+	}
+
+	// Ramp characters follow the brightness
+	if art := Convert(filled(4, 4, color.White), 4, 2, Options{Mode: ModeRamp}); art.Text() != "@@@@\n@@@@" {
+		t.Errorf("white ramp =\n%s", art.Text())
+	}
+	gray := Convert(filled(4, 4, color.Gray{100}), 4, 2, Options{Mode: ModeRamp, Threshold: 0.1})
+	if strings.Trim(gray.Text(), "\n") == "" || strings.ContainsAny(gray.Text(), "@ ") {
+		t.Errorf("gray ramp =\n%s", gray.Text())
+	}
+
+	// A dark gray is all blank until dithering shades it
+	dark := filled(40, 40, color.Gray{64})
+	if art := Convert(dark, 40, 20, DefaultOptions); len(art.Lines) != 0 {
+		t.Errorf("undithered dark gray = %q", art.Lines)
+	}
+	dithered := Convert(dark, 40, 20, Options{Mode: ModeBlocks, Threshold: 0.5, Dither: true})
+	on := 0
+	for _, row := range dithered.Colors {
+		for _, cell := range row {
+			switch cell.Rune {
+			case '█':
+				on += 2
+			case '▀', '▄':
+				on++
+			}
+		}
+	}
+	if on < 40*40/8 || on > 40*40*3/8 {
+		t.Errorf("dithered dark gray has %d of %d pixels on", on, 40*40)
+	}
+}
+
+// TestLoad tests loading image files
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logo.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, filled(3, 2, color.White)); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	img, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != 3 || img.Bounds().Dy() != 2 {
+		t.Errorf("bounds = %v", img.Bounds())
+	}
+
+	text := filepath.Join(dir, "art.png")
+	os.WriteFile(text, []byte("not an image"), 0644)
+	if _, err := Load(text); err == nil {
+		t.Error("loading text should fail")
+	}
+
+	for path, want := range map[string]bool{"a.PNG": true, "b.jpeg": true, "c.gif": true, "d.txt": false, "png": false} {
+		if IsImage(path) != want {
+			t.Errorf("IsImage(%q) = %v", path, !want)
+		}
+	}
+}
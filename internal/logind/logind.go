@@ -0,0 +1,297 @@
+// Package logind follows the user's login1 session on the system bus
+package logind
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	busName          = "org.freedesktop.login1"
+	managerPath      = dbus.ObjectPath("/org/freedesktop/login1")
+	managerInterface = "org.freedesktop.login1.Manager"
+	sessionInterface = "org.freedesktop.login1.Session"
+	userInterface    = "org.freedesktop.login1.User"
+	propsInterface   = "org.freedesktop.DBus.Properties"
+)
+
+// Event is a session state change reported by logind
+type Event int
+
+const (
+	// Lock is sent for loginctl lock-session and similar requests
+	Lock Event = iota
+	// Unlock is sent for loginctl unlock-session
+	Unlock
+	// Active is sent when the session is switched back to the foreground
+	Active
+	// Inactive is sent when another session takes the seat (VT switch)
+	Inactive
+	// Sleep is sent before the system suspends or hibernates
+	Sleep
+	// Resume is sent after the system wakes up
+	Resume
+)
+
+// String returns a readable event name
+func (e Event) String() string {
+	switch e {
+	case Lock:
+		return "lock"
+	case Unlock:
+		return "unlock"
+	case Active:
+		return "active"
+	case Inactive:
+		return "inactive"
+	case Sleep:
+		return "sleep"
+	case Resume:
+		return "resume"
+	}
+	return fmt.Sprintf("event(%d)", int(e))
+}
+
+// Conn is the part of a bus connection the session needs.
+// *dbus.Conn satisfies it; tests substitute a fake bus.
+type Conn interface {
+	Object(dest string, path dbus.ObjectPath) dbus.BusObject
+	AddMatchSignal(options ...dbus.MatchOption) error
+	Signal(ch chan<- *dbus.Signal)
+	Close() error
+}
+
+// Session tracks one logind session
+type Session struct {
+	conn    Conn
+	manager dbus.BusObject
+	session dbus.BusObject
+	path    dbus.ObjectPath
+	signals chan *dbus.Signal
+	events  chan Event
+
+	mu      sync.Mutex
+	inhibit *os.File // Sleep delay lock, held while awake
+}
+
+// Connect opens the system bus and resolves the user's session
+func Connect() (*Session, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to system bus: %w", err)
+	}
+
+	session, err := NewSession(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return session, nil
+}
+
+// NewSession resolves the user's session on an existing connection
+func NewSession(conn Conn) (*Session, error) {
+	manager := conn.Object(busName, managerPath)
+
+	path, err := resolveSession(conn, manager)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		conn:    conn,
+		manager: manager,
+		session: conn.Object(busName, path),
+		path:    path,
+		signals: make(chan *dbus.Signal, 16),
+		events:  make(chan Event, 16),
+	}, nil
+}
+
+// resolveSession finds the session object. XDG_SESSION_ID is set when the
+// daemon was started from the session itself; a systemd user service is not
+// part of any session, so fall back to the user's display session.
+func resolveSession(conn Conn, manager dbus.BusObject) (dbus.ObjectPath, error) {
+	var path dbus.ObjectPath
+
+	if id := os.Getenv("XDG_SESSION_ID"); id != "" {
+		if err := manager.Call(managerInterface+".GetSession", 0, id).Store(&path); err == nil {
+			return path, nil
+		}
+	}
+
+	var userPath dbus.ObjectPath
+	if err := manager.Call(managerInterface+".GetUser", 0, uint32(os.Getuid())).Store(&userPath); err != nil {
+		return "", fmt.Errorf("failed to look up logind user: %w", err)
+	}
+
+	display, err := conn.Object(busName, userPath).GetProperty(userInterface + ".Display")
+	if err != nil {
+		return "", fmt.Errorf("failed to read display session: %w", err)
+	}
+
+	// Display is a (session id, object path) struct
+	fields, ok := display.Value().([]interface{})
+	if !ok || len(fields) != 2 {
+		return "", fmt.Errorf("unexpected display session value %v", display.Value())
+	}
+	path, ok = fields[1].(dbus.ObjectPath)
+	if !ok || path == "/" {
+		return "", fmt.Errorf("user has no graphical session")
+	}
+	return path, nil
+}
+
+// Path returns the session's object path
+func (s *Session) Path() dbus.ObjectPath {
+	return s.path
+}
+
+// Events returns the channel session events are delivered on
+func (s *Session) Events() <-chan Event {
+	return s.events
+}
+
+// Start subscribes to session and sleep signals and forwards them as events
+func (s *Session) Start(ctx context.Context) error {
+	matches := [][]dbus.MatchOption{
+		{dbus.WithMatchObjectPath(s.path), dbus.WithMatchInterface(sessionInterface), dbus.WithMatchMember("Lock")},
+		{dbus.WithMatchObjectPath(s.path), dbus.WithMatchInterface(sessionInterface), dbus.WithMatchMember("Unlock")},
+		{dbus.WithMatchObjectPath(s.path), dbus.WithMatchInterface(propsInterface), dbus.WithMatchMember("PropertiesChanged")},
+		{dbus.WithMatchObjectPath(managerPath), dbus.WithMatchInterface(managerInterface), dbus.WithMatchMember("PrepareForSleep")},
+	}
+	for _, match := range matches {
+		if err := s.conn.AddMatchSignal(match...); err != nil {
+			return fmt.Errorf("failed to subscribe to logind signals: %w", err)
+		}
+	}
+	s.conn.Signal(s.signals)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig, ok := <-s.signals:
+				if !ok {
+					return
+				}
+				if event, ok := s.translate(sig); ok {
+					select {
+					case s.events <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// translate maps a bus signal to a session event
+func (s *Session) translate(sig *dbus.Signal) (Event, bool) {
+	switch sig.Name {
+	case sessionInterface + ".Lock":
+		if sig.Path == s.path {
+			return Lock, true
+		}
+	case sessionInterface + ".Unlock":
+		if sig.Path == s.path {
+			return Unlock, true
+		}
+	case managerInterface + ".PrepareForSleep":
+		if len(sig.Body) == 1 {
+			if sleeping, ok := sig.Body[0].(bool); ok {
+				if sleeping {
+					return Sleep, true
+				}
+				return Resume, true
+			}
+		}
+	case propsInterface + ".PropertiesChanged":
+		if sig.Path != s.path || len(sig.Body) < 2 {
+			break
+		}
+		if iface, _ := sig.Body[0].(string); iface != sessionInterface {
+			break
+		}
+		changed, ok := sig.Body[1].(map[string]dbus.Variant)
+		if !ok {
+			break
+		}
+		if active, ok := changed["Active"]; ok {
+			if value, ok := active.Value().(bool); ok {
+				if value {
+					return Active, true
+				}
+				return Inactive, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// IsActive reports whether the session is in the foreground of its seat
+func (s *Session) IsActive() (bool, error) {
+	value, err := s.session.GetProperty(sessionInterface + ".Active")
+	if err != nil {
+		return false, fmt.Errorf("failed to read session state: %w", err)
+	}
+	active, ok := value.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected Active value %v", value.Value())
+	}
+	return active, nil
+}
+
+// SetIdleHint tells logind whether the session is idle
+func (s *Session) SetIdleHint(idle bool) error {
+	if err := s.session.Call(sessionInterface+".SetIdleHint", 0, idle).Err; err != nil {
+		return fmt.Errorf("failed to set idle hint: %w", err)
+	}
+	return nil
+}
+
+// TakeSleepLock takes a delay inhibitor so displays can be stopped before
+// sleep. Take it after Start and again after every Resume event.
+func (s *Session) TakeSleepLock() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.inhibit != nil {
+		return nil
+	}
+
+	var fd dbus.UnixFD
+	call := s.manager.Call(managerInterface+".Inhibit", 0,
+		"sleep", "sysc-walls", "Stop screensaver before sleep", "delay")
+	if err := call.Store(&fd); err != nil {
+		return fmt.Errorf("failed to take sleep inhibitor: %w", err)
+	}
+	s.inhibit = os.NewFile(uintptr(fd), "logind-inhibit")
+	return nil
+}
+
+// ReleaseSleepLock lets a pending suspend continue. Call it once displays
+// are stopped after a Sleep event.
+func (s *Session) ReleaseSleepLock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.inhibit != nil {
+		s.inhibit.Close()
+		s.inhibit = nil
+	}
+}
+
+// Close releases the inhibitor and the bus connection
+func (s *Session) Close() error {
+	s.ReleaseSleepLock()
+	return s.conn.Close()
+}
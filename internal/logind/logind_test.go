@@ -0,0 +1,236 @@
+package logind
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const testSession = dbus.ObjectPath("/org/freedesktop/login1/session/_32")
+
+// fakeObject answers method calls and property reads for one bus object
+type fakeObject struct {
+	dbus.BusObject // Unused methods panic
+	path           dbus.ObjectPath
+	bus            *fakeBus
+}
+
+func (o *fakeObject) Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	o.bus.calls = append(o.bus.calls, fmt.Sprintf("%s %s %v", o.path, method, args))
+	if reply, ok := o.bus.replies[method]; ok {
+		return &dbus.Call{Body: reply}
+	}
+	return &dbus.Call{Err: fmt.Errorf("unexpected call %s", method)}
+}
+
+func (o *fakeObject) GetProperty(p string) (dbus.Variant, error) {
+	if value, ok := o.bus.props[string(o.path)+" "+p]; ok {
+		return dbus.MakeVariant(value), nil
+	}
+	return dbus.Variant{}, fmt.Errorf("no property %s on %s", p, o.path)
+}
+
+// fakeBus is an in-memory stand-in for the system bus
+type fakeBus struct {
+	replies map[string][]interface{}
+	props   map[string]interface{}
+	calls   []string
+	matches int
+	signals chan<- *dbus.Signal
+	closed  bool
+}
+
+func newFakeBus() *fakeBus {
+	return &fakeBus{
+		replies: map[string][]interface{}{
+			managerInterface + ".GetUser":     {dbus.ObjectPath("/org/freedesktop/login1/user/_1000")},
+			sessionInterface + ".SetIdleHint": {},
+		},
+		props: map[string]interface{}{
+			"/org/freedesktop/login1/user/_1000 " + userInterface + ".Display": []interface{}{"2", testSession},
+			string(testSession) + " " + sessionInterface + ".Active":           true,
+		},
+	}
+}
+
+func (b *fakeBus) Object(dest string, path dbus.ObjectPath) dbus.BusObject {
+	return &fakeObject{path: path, bus: b}
+}
+
+func (b *fakeBus) AddMatchSignal(options ...dbus.MatchOption) error {
+	b.matches++
+	return nil
+}
+
+func (b *fakeBus) Signal(ch chan<- *dbus.Signal) {
+	b.signals = ch
+}
+
+func (b *fakeBus) Close() error {
+	b.closed = true
+	return nil
+}
+
+// TestNewSession tests session resolution through the user's display session
+func TestNewSession(t *testing.T) {
+	t.Setenv("XDG_SESSION_ID", "")
+
+	bus := newFakeBus()
+	session, err := NewSession(bus)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	if session.Path() != testSession {
+		t.Errorf("Path() = %s, want %s", session.Path(), testSession)
+	}
+
+	active, err := session.IsActive()
+	if err != nil || !active {
+		t.Errorf("IsActive() = %v, %v; want true", active, err)
+	}
+
+	// XDG_SESSION_ID takes precedence when set
+	t.Setenv("XDG_SESSION_ID", "7")
+	bus.replies[managerInterface+".GetSession"] = []interface{}{dbus.ObjectPath("/org/freedesktop/login1/session/_37")}
+	session, err = NewSession(bus)
+	if err != nil || session.Path() != "/org/freedesktop/login1/session/_37" {
+		t.Errorf("NewSession() with XDG_SESSION_ID = %v, %v", session, err)
+	}
+
+	// No graphical session
+	t.Setenv("XDG_SESSION_ID", "")
+	bus.props["/org/freedesktop/login1/user/_1000 "+userInterface+".Display"] = []interface{}{"", dbus.ObjectPath("/")}
+	if _, err := NewSession(bus); err == nil {
+		t.Error("NewSession() without a display session should fail")
+	}
+}
+
+// TestEvents tests that bus signals are delivered as session events
+func TestEvents(t *testing.T) {
+	t.Setenv("XDG_SESSION_ID", "")
+
+	bus := newFakeBus()
+	session, err := NewSession(bus)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := session.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if bus.matches != 4 || bus.signals == nil {
+		t.Fatalf("Start() added %d matches, signal channel set: %v", bus.matches, bus.signals != nil)
+	}
+
+	other := dbus.ObjectPath("/org/freedesktop/login1/session/_33")
+	signals := []*dbus.Signal{
+		{Path: other, Name: sessionInterface + ".Lock"}, // Not our session
+		{Path: testSession, Name: sessionInterface + ".Lock"},
+		{Path: testSession, Name: sessionInterface + ".Unlock"},
+		{Path: testSession, Name: propsInterface + ".PropertiesChanged", Body: []interface{}{
+			sessionInterface, map[string]dbus.Variant{"IdleHint": dbus.MakeVariant(true)}, []string{},
+		}},
+		{Path: testSession, Name: propsInterface + ".PropertiesChanged", Body: []interface{}{
+			sessionInterface, map[string]dbus.Variant{"Active": dbus.MakeVariant(false)}, []string{},
+		}},
+		{Path: testSession, Name: propsInterface + ".PropertiesChanged", Body: []interface{}{
+			sessionInterface, map[string]dbus.Variant{"Active": dbus.MakeVariant(true)}, []string{},
+		}},
+		{Path: managerPath, Name: managerInterface + ".PrepareForSleep", Body: []interface{}{true}},
+		{Path: managerPath, Name: managerInterface + ".PrepareForSleep", Body: []interface{}{false}},
+	}
+	for _, sig := range signals {
+		bus.signals <- sig
+	}
+
+	want := []Event{Lock, Unlock, Inactive, Active, Sleep, Resume}
+	for _, expected := range want {
+		select {
+		case got := <-session.Events():
+			if got != expected {
+				t.Errorf("event = %v, want %v", got, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %v", expected)
+		}
+	}
+}
+
+// TestSetIdleHint tests the idle hint method call
+func TestSetIdleHint(t *testing.T) {
+	t.Setenv("XDG_SESSION_ID", "")
+
+	bus := newFakeBus()
+	session, err := NewSession(bus)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	if err := session.SetIdleHint(true); err != nil {
+		t.Fatalf("SetIdleHint() error = %v", err)
+	}
+	want := fmt.Sprintf("%s %s.SetIdleHint [true]", testSession, sessionInterface)
+	if last := bus.calls[len(bus.calls)-1]; last != want {
+		t.Errorf("last call = %q, want %q", last, want)
+	}
+}
+
+// TestSleepLock tests taking and releasing the sleep inhibitor
+func TestSleepLock(t *testing.T) {
+	t.Setenv("XDG_SESSION_ID", "")
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	defer r.Close()
+
+	// The session owns the inhibitor fd, so hand it a duplicate
+	fd, err := syscall.Dup(int(w.Fd()))
+	if err != nil {
+		t.Fatalf("Dup() error = %v", err)
+	}
+	w.Close()
+
+	bus := newFakeBus()
+	bus.replies[managerInterface+".Inhibit"] = []interface{}{dbus.UnixFD(fd)}
+	session, err := NewSession(bus)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	if err := session.TakeSleepLock(); err != nil {
+		t.Fatalf("TakeSleepLock() error = %v", err)
+	}
+	// A second call keeps the existing lock
+	if err := session.TakeSleepLock(); err != nil {
+		t.Fatalf("TakeSleepLock() again error = %v", err)
+	}
+	inhibits := 0
+	for _, call := range bus.calls {
+		if call == fmt.Sprintf("%s %s.Inhibit [sleep sysc-walls Stop screensaver before sleep delay]", managerPath, managerInterface) {
+			inhibits++
+		}
+	}
+	if inhibits != 1 {
+		t.Errorf("Inhibit called %d times, want 1", inhibits)
+	}
+
+	// Releasing closes the write end, so the read end sees EOF
+	if err := session.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if n, err := r.Read(make([]byte, 1)); n != 0 || err == nil {
+		t.Errorf("inhibitor fd still open after Close: n=%d err=%v", n, err)
+	}
+	if !bus.closed {
+		t.Error("Close() did not close the bus")
+	}
+}
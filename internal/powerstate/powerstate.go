@@ -0,0 +1,271 @@
+// powerstate.go - Battery, AC and power-profile detection for policy overrides
+package powerstate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// DefaultSysfsRoot is where the kernel exposes power supplies
+const DefaultSysfsRoot = "/sys/class/power_supply"
+
+// pollInterval is how often the monitor re-reads sysfs without UPower
+const pollInterval = 5 * time.Second
+
+const (
+	upowerName        = "org.freedesktop.UPower"
+	upowerPath        = dbus.ObjectPath("/org/freedesktop/UPower")
+	upowerInterface   = "org.freedesktop.UPower"
+	displayDevicePath = dbus.ObjectPath("/org/freedesktop/UPower/devices/DisplayDevice")
+	profilesName      = "net.hadess.PowerProfiles"
+	profilesPath      = dbus.ObjectPath("/net/hadess/PowerProfiles")
+	profilesInterface = "net.hadess.PowerProfiles"
+	propsInterface    = "org.freedesktop.DBus.Properties"
+)
+
+// State describes the current power situation of the machine
+type State struct {
+	OnBattery bool   // Running from battery with no AC/USB supply online
+	Capacity  int    // Battery charge in percent, -1 if unknown
+	Profile   string // power-profiles-daemon profile, empty if unavailable
+}
+
+// PolicyNames returns the policy sections that apply to this state, from
+// least to most specific. Later entries override earlier ones.
+func (s State) PolicyNames(lowBattery int) []string {
+	names := []string{"ac"}
+	if s.OnBattery {
+		names = []string{"battery"}
+		if s.Capacity >= 0 && s.Capacity <= lowBattery {
+			names = append(names, "low-battery")
+		}
+	}
+	if s.Profile != "" {
+		names = append(names, s.Profile)
+	}
+	return names
+}
+
+// Read reads the power state from a sysfs power_supply directory
+func Read(root string) State {
+	state := State{Capacity: -1}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return state
+	}
+
+	hasBattery := false
+	externalOnline := false
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+
+		// Peripheral batteries (mice, keyboards) report scope "Device"
+		if readAttr(dir, "scope") == "Device" {
+			continue
+		}
+
+		switch readAttr(dir, "type") {
+		case "Mains", "USB", "USB_C", "USB_PD":
+			if readAttr(dir, "online") == "1" {
+				externalOnline = true
+			}
+		case "Battery":
+			if readAttr(dir, "present") == "0" {
+				continue
+			}
+			hasBattery = true
+			if capacity, err := strconv.Atoi(readAttr(dir, "capacity")); err == nil {
+				if state.Capacity < 0 || capacity < state.Capacity {
+					state.Capacity = capacity
+				}
+			}
+			if readAttr(dir, "status") == "Discharging" {
+				state.OnBattery = true
+			}
+		}
+	}
+
+	// Some laptops don't expose a Mains supply; trust the battery status then
+	if hasBattery && externalOnline {
+		state.OnBattery = false
+	}
+
+	return state
+}
+
+// readAttr reads a single sysfs attribute, returning "" on error
+func readAttr(dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// Monitor follows the power state and reports changes. It reads UPower and
+// power-profiles-daemon on the system bus and waits for their
+// PropertiesChanged signals. Without UPower it polls sysfs instead.
+type Monitor struct {
+	root        string
+	useProfiles bool
+	conn        Conn // nil without a system bus
+	signals     chan *dbus.Signal
+	changes     chan State
+	current     State
+}
+
+// Conn is the part of a bus connection the monitor needs.
+// *dbus.Conn satisfies it; tests substitute a fake bus.
+type Conn interface {
+	Object(dest string, path dbus.ObjectPath) dbus.BusObject
+	AddMatchSignal(options ...dbus.MatchOption) error
+	Signal(ch chan<- *dbus.Signal)
+	Close() error
+}
+
+// NewMonitor creates a monitor for the default sysfs location and the
+// system bus
+func NewMonitor(useProfiles bool) *Monitor {
+	var conn Conn
+	if bus, err := dbus.ConnectSystemBus(); err == nil {
+		conn = bus
+	}
+	return newMonitor(conn, DefaultSysfsRoot, useProfiles)
+}
+
+// newMonitor creates a monitor on the given bus, which may be nil
+func newMonitor(conn Conn, root string, useProfiles bool) *Monitor {
+	return &Monitor{
+		root:        root,
+		useProfiles: useProfiles,
+		conn:        conn,
+		signals:     make(chan *dbus.Signal, 16),
+		changes:     make(chan State, 1),
+	}
+}
+
+// Current reads and returns the current state
+func (m *Monitor) Current() State {
+	m.current = m.read()
+	return m.current
+}
+
+// Changes returns the channel that receives state changes
+func (m *Monitor) Changes() <-chan State {
+	return m.changes
+}
+
+// Start follows the power state until the context is cancelled
+func (m *Monitor) Start(ctx context.Context) {
+	if m.subscribe() != nil {
+		go m.poll(ctx)
+		return
+	}
+
+	go func() {
+		defer m.conn.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig, ok := <-m.signals:
+				if !ok {
+					return
+				}
+				if sig.Name == propsInterface+".PropertiesChanged" {
+					m.update()
+				}
+			}
+		}
+	}()
+}
+
+// subscribe asks for PropertiesChanged from UPower, its display device and
+// power-profiles-daemon. It fails without a bus or without UPower.
+func (m *Monitor) subscribe() error {
+	if m.conn == nil {
+		return fmt.Errorf("no system bus")
+	}
+	if _, err := m.conn.Object(upowerName, upowerPath).GetProperty(upowerInterface + ".OnBattery"); err != nil {
+		return fmt.Errorf("UPower is not running: %w", err)
+	}
+
+	paths := []dbus.ObjectPath{upowerPath, displayDevicePath}
+	if m.useProfiles {
+		paths = append(paths, profilesPath)
+	}
+	for _, path := range paths {
+		if err := m.conn.AddMatchSignal(
+			dbus.WithMatchObjectPath(path),
+			dbus.WithMatchInterface(propsInterface),
+			dbus.WithMatchMember("PropertiesChanged"),
+		); err != nil {
+			return fmt.Errorf("failed to subscribe to power signals: %w", err)
+		}
+	}
+	m.conn.Signal(m.signals)
+	return nil
+}
+
+// poll re-reads the power state every pollInterval until the context is
+// cancelled
+func (m *Monitor) poll(ctx context.Context) {
+	if m.conn != nil {
+		defer m.conn.Close()
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.update()
+		}
+	}
+}
+
+// update re-reads the power state and reports it if it changed
+func (m *Monitor) update() {
+	state := m.read()
+	if state == m.current {
+		return
+	}
+	m.current = state
+
+	// Drop a stale pending change in favour of the newest one
+	select {
+	case <-m.changes:
+	default:
+	}
+	m.changes <- state
+}
+
+// read gathers sysfs state, with UPower's view of the supply and the
+// power-profiles-daemon profile when they're on the bus
+func (m *Monitor) read() State {
+	state := Read(m.root)
+	if m.conn == nil {
+		return state
+	}
+	if value, err := m.conn.Object(upowerName, upowerPath).GetProperty(upowerInterface + ".OnBattery"); err == nil {
+		if onBattery, ok := value.Value().(bool); ok {
+			state.OnBattery = onBattery
+		}
+	}
+	if m.useProfiles {
+		if value, err := m.conn.Object(profilesName, profilesPath).GetProperty(profilesInterface + ".ActiveProfile"); err == nil {
+			state.Profile, _ = value.Value().(string)
+		}
+	}
+	return state
+}
@@ -0,0 +1,234 @@
+package powerstate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// writeSupply creates a fake sysfs power supply directory
+func writeSupply(t *testing.T, root, name string, attrs map[string]string) {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for attr, value := range attrs {
+		if err := os.WriteFile(filepath.Join(dir, attr), []byte(value+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestRead tests sysfs power supply parsing
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		supplies map[string]map[string]string
+		expected State
+	}{
+		{
+			name:     "Desktop without battery",
+			supplies: map[string]map[string]string{},
+			expected: State{OnBattery: false, Capacity: -1},
+		},
+		{
+			name: "Laptop on AC",
+			supplies: map[string]map[string]string{
+				"AC":   {"type": "Mains", "online": "1"},
+				"BAT0": {"type": "Battery", "status": "Charging", "capacity": "80"},
+			},
+			expected: State{OnBattery: false, Capacity: 80},
+		},
+		{
+			name: "Laptop on battery",
+			supplies: map[string]map[string]string{
+				"AC":   {"type": "Mains", "online": "0"},
+				"BAT0": {"type": "Battery", "status": "Discharging", "capacity": "42"},
+			},
+			expected: State{OnBattery: true, Capacity: 42},
+		},
+		{
+			name: "Mouse battery is ignored",
+			supplies: map[string]map[string]string{
+				"AC":              {"type": "Mains", "online": "1"},
+				"hidpp_battery_0": {"type": "Battery", "scope": "Device", "status": "Discharging", "capacity": "5"},
+			},
+			expected: State{OnBattery: false, Capacity: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, attrs := range tt.supplies {
+				writeSupply(t, root, name, attrs)
+			}
+
+			state := Read(root)
+			if state != tt.expected {
+				t.Errorf("Read() = %+v, want %+v", state, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPolicyNames tests policy section resolution order
+func TestPolicyNames(t *testing.T) {
+	tests := []struct {
+		state    State
+		expected []string
+	}{
+		{State{OnBattery: false, Capacity: 90}, []string{"ac"}},
+		{State{OnBattery: true, Capacity: 50}, []string{"battery"}},
+		{State{OnBattery: true, Capacity: 15}, []string{"battery", "low-battery"}},
+		{State{OnBattery: true, Capacity: 15, Profile: "power-saver"}, []string{"battery", "low-battery", "power-saver"}},
+		{State{OnBattery: true, Capacity: -1}, []string{"battery"}},
+	}
+
+	for _, tt := range tests {
+		names := tt.state.PolicyNames(20)
+		if !reflect.DeepEqual(names, tt.expected) {
+			t.Errorf("PolicyNames(%+v) = %v, want %v", tt.state, names, tt.expected)
+		}
+	}
+}
+
+// fakeObject answers property reads for one bus object
+type fakeObject struct {
+	dbus.BusObject // Unused methods panic
+	path           dbus.ObjectPath
+	bus            *fakeBus
+}
+
+func (o *fakeObject) GetProperty(p string) (dbus.Variant, error) {
+	o.bus.mu.Lock()
+	defer o.bus.mu.Unlock()
+	if value, ok := o.bus.props[string(o.path)+" "+p]; ok {
+		return dbus.MakeVariant(value), nil
+	}
+	return dbus.Variant{}, fmt.Errorf("no property %s on %s", p, o.path)
+}
+
+// fakeBus is an in-memory stand-in for the system bus
+type fakeBus struct {
+	mu      sync.Mutex
+	props   map[string]interface{}
+	matches int
+	signals chan<- *dbus.Signal
+	closed  bool
+}
+
+func newFakeBus(onBattery bool, profile string) *fakeBus {
+	return &fakeBus{props: map[string]interface{}{
+		string(upowerPath) + " " + upowerInterface + ".OnBattery":         onBattery,
+		string(profilesPath) + " " + profilesInterface + ".ActiveProfile": profile,
+	}}
+}
+
+// set changes a property and announces it like the real services
+func (b *fakeBus) set(path dbus.ObjectPath, iface, name string, value interface{}) {
+	b.mu.Lock()
+	b.props[string(path)+" "+iface+"."+name] = value
+	b.mu.Unlock()
+	b.signals <- &dbus.Signal{Path: path, Name: propsInterface + ".PropertiesChanged", Body: []interface{}{
+		iface, map[string]dbus.Variant{name: dbus.MakeVariant(value)}, []string{},
+	}}
+}
+
+func (b *fakeBus) Object(dest string, path dbus.ObjectPath) dbus.BusObject {
+	return &fakeObject{path: path, bus: b}
+}
+
+func (b *fakeBus) AddMatchSignal(options ...dbus.MatchOption) error {
+	b.matches++
+	return nil
+}
+
+func (b *fakeBus) Signal(ch chan<- *dbus.Signal) {
+	b.signals = ch
+}
+
+func (b *fakeBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return nil
+}
+
+// TestMonitorSignals tests that the monitor reads UPower and
+// power-profiles-daemon and follows their PropertiesChanged signals
+func TestMonitorSignals(t *testing.T) {
+	root := t.TempDir()
+	writeSupply(t, root, "BAT0", map[string]string{"type": "Battery", "status": "Charging", "capacity": "55"})
+
+	bus := newFakeBus(false, "balanced")
+	m := newMonitor(bus, root, true)
+	if got, want := m.Current(), (State{Capacity: 55, Profile: "balanced"}); got != want {
+		t.Errorf("Current() = %+v, want %+v", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.Start(ctx)
+	if bus.matches != 3 || bus.signals == nil {
+		t.Fatalf("Start() added %d matches, signal channel set: %v", bus.matches, bus.signals != nil)
+	}
+
+	next := func() State {
+		t.Helper()
+		select {
+		case state := <-m.Changes():
+			return state
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a change")
+		}
+		return State{}
+	}
+
+	bus.set(upowerPath, upowerInterface, "OnBattery", true)
+	if got, want := next(), (State{OnBattery: true, Capacity: 55, Profile: "balanced"}); got != want {
+		t.Errorf("after unplugging = %+v, want %+v", got, want)
+	}
+	bus.set(profilesPath, profilesInterface, "ActiveProfile", "power-saver")
+	if got := next(); got.Profile != "power-saver" {
+		t.Errorf("after a profile change = %+v", got)
+	}
+
+	cancel()
+	for i := 0; i < 100; i++ {
+		bus.mu.Lock()
+		closed := bus.closed
+		bus.mu.Unlock()
+		if closed {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("bus not closed after the context ended")
+}
+
+// TestMonitorWithoutUPower tests that the monitor falls back to sysfs
+func TestMonitorWithoutUPower(t *testing.T) {
+	root := t.TempDir()
+	writeSupply(t, root, "BAT0", map[string]string{"type": "Battery", "status": "Discharging", "capacity": "30"})
+
+	if got, want := newMonitor(nil, root, true).Current(), (State{OnBattery: true, Capacity: 30}); got != want {
+		t.Errorf("Current() without a bus = %+v, want %+v", got, want)
+	}
+
+	bus := &fakeBus{props: map[string]interface{}{}}
+	m := newMonitor(bus, root, false)
+	if err := m.subscribe(); err == nil {
+		t.Error("subscribe() without UPower should fail")
+	}
+	if got := m.Current(); !got.OnBattery || got.Capacity != 30 {
+		t.Errorf("Current() without UPower = %+v", got)
+	}
+}
@@ -0,0 +1,150 @@
+// apng.go - Animated PNG encoding
+package record
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"time"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// EncodeAPNG writes frames as an animated PNG that loops forever. Each frame
+// is shown for delay. All frames must have the same size.
+//
+// Frames are encoded with image/png and their image data re-wrapped in the
+// APNG frame chunks: the first frame stays in IDAT so viewers without APNG
+// support show it as a still image.
+func EncodeAPNG(w io.Writer, frames []image.Image, delay time.Duration) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to encode")
+	}
+
+	enc := &apngEncoder{w: w}
+	enc.write(pngSignature)
+
+	var header []byte
+	for i, frame := range frames {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, frame); err != nil {
+			return err
+		}
+		chunks, err := readChunks(buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		ihdr := chunks["IHDR"]
+		if len(ihdr) != 1 {
+			return fmt.Errorf("frame %d: missing IHDR", i)
+		}
+		if i == 0 {
+			header = ihdr[0]
+			enc.chunk("IHDR", header)
+
+			actl := make([]byte, 8)
+			binary.BigEndian.PutUint32(actl[0:], uint32(len(frames)))
+			binary.BigEndian.PutUint32(actl[4:], 0) // Loop forever
+			enc.chunk("acTL", actl)
+		} else if !bytes.Equal(ihdr[0], header) {
+			return fmt.Errorf("frame %d: size or color type differs from the first frame", i)
+		}
+
+		enc.frameControl(frame.Bounds(), delay)
+		for _, data := range chunks["IDAT"] {
+			if i == 0 {
+				enc.chunk("IDAT", data)
+				continue
+			}
+			fdat := make([]byte, 4, 4+len(data))
+			binary.BigEndian.PutUint32(fdat, enc.next())
+			enc.chunk("fdAT", append(fdat, data...))
+		}
+	}
+
+	enc.chunk("IEND", nil)
+	return enc.err
+}
+
+// apngEncoder writes chunks and numbers the frame chunks
+type apngEncoder struct {
+	w   io.Writer
+	seq uint32
+	err error
+}
+
+// next returns the next sequence number for fcTL and fdAT chunks
+func (e *apngEncoder) next() uint32 {
+	n := e.seq
+	e.seq++
+	return n
+}
+
+// frameControl writes the fcTL chunk for a full-size frame
+func (e *apngEncoder) frameControl(bounds image.Rectangle, delay time.Duration) {
+	fctl := make([]byte, 26)
+	binary.BigEndian.PutUint32(fctl[0:], e.next())
+	binary.BigEndian.PutUint32(fctl[4:], uint32(bounds.Dx()))
+	binary.BigEndian.PutUint32(fctl[8:], uint32(bounds.Dy()))
+	binary.BigEndian.PutUint32(fctl[12:], 0) // x offset
+	binary.BigEndian.PutUint32(fctl[16:], 0) // y offset
+	binary.BigEndian.PutUint16(fctl[20:], uint16(delay.Milliseconds()))
+	binary.BigEndian.PutUint16(fctl[22:], 1000)
+	fctl[24] = 0 // APNG_DISPOSE_OP_NONE
+	fctl[25] = 0 // APNG_BLEND_OP_SOURCE
+	e.chunk("fcTL", fctl)
+}
+
+// chunk writes one PNG chunk: length, type, data and CRC
+func (e *apngEncoder) chunk(name string, data []byte) {
+	if e.err != nil {
+		return
+	}
+	var head [8]byte
+	binary.BigEndian.PutUint32(head[:4], uint32(len(data)))
+	copy(head[4:], name)
+
+	crc := crc32.NewIEEE()
+	crc.Write(head[4:])
+	crc.Write(data)
+	var tail [4]byte
+	binary.BigEndian.PutUint32(tail[:], crc.Sum32())
+
+	e.write(head[:])
+	e.write(data)
+	e.write(tail[:])
+}
+
+// write writes b unless an earlier write failed
+func (e *apngEncoder) write(b []byte) {
+	if e.err == nil {
+		_, e.err = e.w.Write(b)
+	}
+}
+
+// readChunks splits an encoded PNG into its chunks' data, by chunk type
+func readChunks(data []byte) (map[string][][]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, fmt.Errorf("not a PNG")
+	}
+	chunks := map[string][][]byte{}
+	for pos := len(pngSignature); pos < len(data); {
+		if pos+8 > len(data) {
+			return nil, fmt.Errorf("truncated PNG chunk")
+		}
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		name := string(data[pos+4 : pos+8])
+		end := pos + 8 + length + 4
+		if length < 0 || end > len(data) {
+			return nil, fmt.Errorf("truncated %s chunk", name)
+		}
+		chunks[name] = append(chunks[name], data[pos+8:pos+8+length])
+		pos = end
+	}
+	return chunks, nil
+}
@@ -0,0 +1,89 @@
+// cast.go - asciicast v2 recording
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// CastHeader is the first line of an asciicast v2 file
+type CastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"` // Unix time the recording started
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Cast writes terminal output as asciicast v2 events. It is an io.Writer,
+// so it can sit next to the terminal behind an io.MultiWriter; every Write
+// becomes one output event stamped with the recording clock.
+type Cast struct {
+	w     io.Writer
+	clock func() time.Duration
+	last  time.Duration
+	buf   []byte
+}
+
+// NewCast writes the header and returns a recording that stamps events with
+// the time since it was created
+func NewCast(w io.Writer, header CastHeader) (*Cast, error) {
+	header.Version = 2
+	line, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	return &Cast{w: w, clock: func() time.Duration { return time.Since(start) }}, nil
+}
+
+// SetClock replaces the recording clock, e.g. with frame times for
+// recordings rendered faster than real time
+func (c *Cast) SetClock(clock func() time.Duration) {
+	c.clock = clock
+}
+
+// Write records p as an output event
+func (c *Cast) Write(p []byte) (int, error) {
+	if err := c.event("o", string(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Resize records a terminal size change
+func (c *Cast) Resize(width, height int) error {
+	return c.event("r", fmt.Sprintf("%dx%d", width, height))
+}
+
+// event writes one event line. Times never go backwards.
+func (c *Cast) event(code, data string) error {
+	t := c.clock()
+	if t < c.last {
+		t = c.last
+	}
+	c.last = t
+
+	quoted, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	c.buf = append(c.buf[:0], '[')
+	c.buf = strconv.AppendFloat(c.buf, t.Seconds(), 'f', 6, 64)
+	c.buf = append(c.buf, ", \""...)
+	c.buf = append(c.buf, code...)
+	c.buf = append(c.buf, "\", "...)
+	c.buf = append(c.buf, quoted...)
+	c.buf = append(c.buf, "]\n"...)
+	_, err = c.w.Write(c.buf)
+	return err
+}
@@ -0,0 +1,85 @@
+// gif.go - Animated GIF encoding
+package record
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+)
+
+// EncodeGIF writes frames as a GIF that loops forever. Each frame is shown
+// for delay, rounded to the 10ms steps GIF supports.
+//
+// Rasterized frames only contain the colors of their cells, so most effects
+// fit in one exact 256-color palette. Otherwise colors are mapped to the
+// xterm 256-color palette.
+func EncodeGIF(w io.Writer, frames []*image.RGBA, delay time.Duration) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to encode")
+	}
+
+	pal, exact := framePalette(frames)
+	index := make(map[color.RGBA]uint8, len(pal))
+	for i, c := range pal {
+		index[c.(color.RGBA)] = uint8(i)
+	}
+
+	centis := int(delay / (10 * time.Millisecond))
+	if centis < 1 {
+		centis = 1
+	}
+
+	anim := &gif.GIF{LoopCount: 0}
+	for _, frame := range frames {
+		paletted := image.NewPaletted(frame.Bounds(), pal)
+		for i := 0; i < len(frame.Pix); i += 4 {
+			c := color.RGBA{frame.Pix[i], frame.Pix[i+1], frame.Pix[i+2], 255}
+			n, ok := index[c]
+			if !ok && !exact {
+				n = uint8(pal.Index(c))
+				index[c] = n
+			}
+			paletted.Pix[i/4] = n
+		}
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, centis)
+	}
+
+	return gif.EncodeAll(w, anim)
+}
+
+// framePalette returns the colors used across all frames if there are at
+// most 256 of them, otherwise the xterm palette
+func framePalette(frames []*image.RGBA) (color.Palette, bool) {
+	seen := map[color.RGBA]bool{}
+	var pal color.Palette
+	for _, frame := range frames {
+		for i := 0; i < len(frame.Pix); i += 4 {
+			c := color.RGBA{frame.Pix[i], frame.Pix[i+1], frame.Pix[i+2], 255}
+			if seen[c] {
+				continue
+			}
+			if len(pal) == 256 {
+				return xtermPalette(), false
+			}
+			seen[c] = true
+			pal = append(pal, c)
+		}
+	}
+	return pal, true
+}
+
+// xtermPalette returns the 256-color terminal palette
+func xtermPalette() color.Palette {
+	pal := make(color.Palette, 256)
+	for i := range pal {
+		r, g, b := render.PaletteRGB(uint8(i))
+		pal[i] = color.RGBA{r, g, b, 255}
+	}
+	return pal
+}
@@ -0,0 +1,235 @@
+// glyphs.go - Built-in bitmap font for rasterizing cells
+package record
+
+import (
+	"image"
+	"image/color"
+
+	"golang.org/x/image/font/basicfont"
+)
+
+// Cell size in pixels. The font is the 7x13 X11 misc-fixed face.
+const (
+	CellWidth  = 7
+	CellHeight = 13
+)
+
+// face covers printable ASCII. Block elements, box drawing, braille and a
+// few geometric shapes that effects draw with are generated instead, and
+// anything else gets a stand-in pattern so the cell isn't left empty.
+var face = basicfont.Face7x13
+
+// drawGlyph draws r in fg into the cell rectangle at
+func drawGlyph(img *image.RGBA, at image.Rectangle, r rune, fg color.RGBA) {
+	switch {
+	case r >= 0x2580 && r <= 0x259f:
+		drawBlock(img, at, r, fg)
+	case r >= 0x2800 && r <= 0x28ff:
+		drawBraille(img, at, r, fg)
+	case boxArms[r] != 0:
+		drawBox(img, at, boxArms[r], fg)
+	case drawShape(img, at, r, fg):
+	case !drawFontGlyph(img, at, r, fg):
+		drawStandIn(img, at, r, fg)
+	}
+}
+
+// drawFontGlyph draws r from the bitmap font and reports whether it has one
+func drawFontGlyph(img *image.RGBA, at image.Rectangle, r rune, fg color.RGBA) bool {
+	for _, rng := range face.Ranges {
+		if r < rng.Low || r >= rng.High {
+			continue
+		}
+		top := (int(r-rng.Low) + rng.Offset) * (face.Ascent + face.Descent)
+		mask := face.Mask.(*image.Alpha)
+		for y := 0; y < face.Ascent+face.Descent; y++ {
+			for x := 0; x < face.Width; x++ {
+				if mask.AlphaAt(x, top+y).A != 0 {
+					img.SetRGBA(at.Min.X+face.Left+x, at.Min.Y+y, fg)
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// fill paints the part of rect inside the cell
+func fill(img *image.RGBA, at, rect image.Rectangle, fg color.RGBA) {
+	rect = rect.Add(at.Min).Intersect(at)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetRGBA(x, y, fg)
+		}
+	}
+}
+
+// drawBlock draws the block elements U+2580-U+259F
+func drawBlock(img *image.RGBA, at image.Rectangle, r rune, fg color.RGBA) {
+	w, h := at.Dx(), at.Dy()
+	eighthsW := func(n int) int { return (w*n + 4) / 8 }
+	eighthsH := func(n int) int { return (h*n + 4) / 8 }
+
+	switch {
+	case r == 0x2580: // Upper half
+		fill(img, at, image.Rect(0, 0, w, h/2), fg)
+	case r >= 0x2581 && r <= 0x2588: // Lower eighths up to full block
+		fill(img, at, image.Rect(0, h-eighthsH(int(r-0x2580)), w, h), fg)
+	case r >= 0x2589 && r <= 0x258f: // Left seven eighths down to one
+		fill(img, at, image.Rect(0, 0, eighthsW(8-int(r-0x2588)), h), fg)
+	case r == 0x2590: // Right half
+		fill(img, at, image.Rect(w/2, 0, w, h), fg)
+	case r >= 0x2591 && r <= 0x2593: // Light, medium and dark shade
+		drawShade(img, at, int(r-0x2590), fg)
+	case r == 0x2594: // Upper eighth
+		fill(img, at, image.Rect(0, 0, w, eighthsH(1)), fg)
+	case r == 0x2595: // Right eighth
+		fill(img, at, image.Rect(w-eighthsW(1), 0, w, h), fg)
+	default: // Quadrants
+		quads := quadrants[r-0x2596]
+		for i, q := range [4]image.Rectangle{
+			image.Rect(0, 0, w/2, h/2), image.Rect(w/2, 0, w, h/2),
+			image.Rect(0, h/2, w/2, h), image.Rect(w/2, h/2, w, h),
+		} {
+			if quads&(1<<i) != 0 {
+				fill(img, at, q, fg)
+			}
+		}
+	}
+}
+
+// quadrants holds the filled quadrants of U+2596-U+259F as bits:
+// 1 upper left, 2 upper right, 4 lower left, 8 lower right
+var quadrants = [10]uint8{4, 8, 1, 1 | 4 | 8, 1 | 8, 1 | 2 | 4, 1 | 2 | 8, 2, 2 | 4, 2 | 4 | 8}
+
+// drawShade draws ░ ▒ ▓ as ordered dither patterns. Solid pixels keep GIF
+// palettes small and look like the terminal rendering at a distance.
+func drawShade(img *image.RGBA, at image.Rectangle, level int, fg color.RGBA) {
+	for y := 0; y < at.Dy(); y++ {
+		for x := 0; x < at.Dx(); x++ {
+			var on bool
+			switch level {
+			case 1:
+				on = x%2 == 0 && y%2 == 0
+			case 2:
+				on = (x+y)%2 == 0
+			case 3:
+				on = x%2 == 0 || y%2 == 0
+			}
+			if on {
+				img.SetRGBA(at.Min.X+x, at.Min.Y+y, fg)
+			}
+		}
+	}
+}
+
+// drawBraille draws the braille patterns U+2800-U+28FF as 2x4 dots
+func drawBraille(img *image.RGBA, at image.Rectangle, r rune, fg color.RGBA) {
+	// Dot numbering: bits 0-2 left column, 3-5 right column, 6 and 7 bottom row
+	dots := [8][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}, {0, 3}, {1, 3}}
+	bits := int(r - 0x2800)
+	for i, d := range dots {
+		if bits&(1<<i) == 0 {
+			continue
+		}
+		x := 1 + d[0]*3
+		y := 1 + d[1]*3
+		fill(img, at, image.Rect(x, y, x+2, y+2), fg)
+	}
+}
+
+// Box drawing arms
+const (
+	armUp = 1 << iota
+	armDown
+	armLeft
+	armRight
+	armDouble // Draw lines two pixels apart
+)
+
+// boxArms maps the box drawing characters effects use to the lines they draw.
+// Dashed variants are drawn solid.
+var boxArms = map[rune]uint8{
+	'─': armLeft | armRight, '━': armLeft | armRight, '┄': armLeft | armRight, '┈': armLeft | armRight,
+	'│': armUp | armDown, '┃': armUp | armDown, '┆': armUp | armDown, '┊': armUp | armDown,
+	'╎': armUp | armDown, '╏': armUp | armDown, '╌': armLeft | armRight, '╍': armLeft | armRight,
+	'┌': armDown | armRight, '┐': armDown | armLeft, '└': armUp | armRight, '┘': armUp | armLeft,
+	'├': armUp | armDown | armRight, '┤': armUp | armDown | armLeft,
+	'┬': armDown | armLeft | armRight, '┴': armUp | armLeft | armRight,
+	'┼': armUp | armDown | armLeft | armRight,
+	'═': armLeft | armRight | armDouble, '║': armUp | armDown | armDouble,
+	'╔': armDown | armRight | armDouble, '╗': armDown | armLeft | armDouble,
+	'╚': armUp | armRight | armDouble, '╝': armUp | armLeft | armDouble,
+}
+
+// drawBox draws box drawing lines from the cell center to its edges
+func drawBox(img *image.RGBA, at image.Rectangle, arms uint8, fg color.RGBA) {
+	w, h := at.Dx(), at.Dy()
+	cx, cy := w/2, h/2
+	offsets := []int{0}
+	if arms&armDouble != 0 {
+		offsets = []int{-1, 1}
+	}
+	for _, o := range offsets {
+		if arms&armUp != 0 {
+			fill(img, at, image.Rect(cx+o, 0, cx+o+1, cy+1), fg)
+		}
+		if arms&armDown != 0 {
+			fill(img, at, image.Rect(cx+o, cy, cx+o+1, h), fg)
+		}
+		if arms&armLeft != 0 {
+			fill(img, at, image.Rect(0, cy+o, cx+1, cy+o+1), fg)
+		}
+		if arms&armRight != 0 {
+			fill(img, at, image.Rect(cx, cy+o, w, cy+o+1), fg)
+		}
+	}
+}
+
+// drawShape draws dots, circles and squares and reports whether r is one
+func drawShape(img *image.RGBA, at image.Rectangle, r rune, fg color.RGBA) bool {
+	w, h := at.Dx(), at.Dy()
+	cx, cy := w/2, h/2
+	switch r {
+	case '·', '•', '∙':
+		fill(img, at, image.Rect(cx-1, cy-1, cx+1, cy+1), fg)
+	case '●', '◉', '■', '▪':
+		size := 2
+		if r == '●' || r == '■' {
+			size = 3
+		}
+		fill(img, at, image.Rect(cx-size, cy-size, cx+size, cy+size), fg)
+	case '○', '◦', '◎', '◌', '◍', '□', '▫':
+		size := 3
+		if r == '◦' || r == '▫' {
+			size = 2
+		}
+		box := image.Rect(cx-size, cy-size, cx+size, cy+size)
+		fill(img, at, image.Rect(box.Min.X, box.Min.Y, box.Max.X, box.Min.Y+1), fg)
+		fill(img, at, image.Rect(box.Min.X, box.Max.Y-1, box.Max.X, box.Max.Y), fg)
+		fill(img, at, image.Rect(box.Min.X, box.Min.Y, box.Min.X+1, box.Max.Y), fg)
+		fill(img, at, image.Rect(box.Max.X-1, box.Min.Y, box.Max.X, box.Max.Y), fg)
+	default:
+		return false
+	}
+	return true
+}
+
+// drawStandIn draws a pattern derived from r for characters the font lacks,
+// so that e.g. the katakana rain still reads as varied glyphs
+func drawStandIn(img *image.RGBA, at image.Rectangle, r rune, fg color.RGBA) {
+	h := uint32(r) * 2654435761
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 3; x++ {
+			// The right column mirrors the left so the pattern looks like a symbol
+			bit := (h >> uint(y*2+x%2)) & 1
+			if x == 1 {
+				bit = (h >> uint(10+y)) & 1
+			}
+			if bit != 0 {
+				px, py := 1+x*2, 2+y*2
+				fill(img, at, image.Rect(px, py, px+2, py+1), fg)
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// raster.go - Cell grids to images
+package record
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+)
+
+// Default colors for cells without an explicit color, matching the dark
+// background most terminals use
+var (
+	DefaultForeground = color.RGBA{229, 229, 229, 255}
+	DefaultBackground = color.RGBA{0, 0, 0, 255}
+)
+
+// Rasterize draws a cell grid with the built-in bitmap font. The image is
+// CellWidth x CellHeight pixels per cell.
+func Rasterize(g *render.Grid) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, g.Width*CellWidth, g.Height*CellHeight))
+
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			cell := g.At(x, y)
+			if cell.Continuation() {
+				continue
+			}
+
+			fg, bg := cellColors(cell.Style)
+			at := image.Rect(x*CellWidth, y*CellHeight, (x+cell.Width())*CellWidth, (y+1)*CellHeight)
+			fill(img, at, image.Rect(0, 0, at.Dx(), at.Dy()), bg)
+
+			if cell.Rune != ' ' {
+				drawGlyph(img, at, cell.Rune, fg)
+			}
+			if cell.Style.Attrs&render.AttrUnderline != 0 {
+				fill(img, at, image.Rect(0, CellHeight-1, at.Dx(), CellHeight), fg)
+			}
+			if cell.Style.Attrs&render.AttrStrike != 0 {
+				fill(img, at, image.Rect(0, CellHeight/2, at.Dx(), CellHeight/2+1), fg)
+			}
+		}
+	}
+
+	return img
+}
+
+// cellColors resolves the pixel colors of a cell style
+func cellColors(s render.Style) (fg, bg color.RGBA) {
+	fg = resolveColor(s.Fg, DefaultForeground)
+	bg = resolveColor(s.Bg, DefaultBackground)
+	if s.Attrs&render.AttrDim != 0 {
+		fg = color.RGBA{fg.R / 2, fg.G / 2, fg.B / 2, 255}
+	}
+	if s.Attrs&render.AttrReverse != 0 {
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
+
+// resolveColor returns the RGB value of a terminal color
+func resolveColor(c render.Color, def color.RGBA) color.RGBA {
+	if r, g, b, ok := c.RGB(); ok {
+		return color.RGBA{r, g, b, 255}
+	}
+	if index, ok := c.Index(); ok {
+		r, g, b := render.PaletteRGB(index)
+		return color.RGBA{r, g, b, 255}
+	}
+	return def
+}
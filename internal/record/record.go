@@ -0,0 +1,176 @@
+// record.go - Headless rendering of effects to files
+package record
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/animations"
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+)
+
+// Format is an output file format
+type Format int
+
+const (
+	FormatCast Format = iota // asciicast v2
+	FormatGIF                // Animated GIF
+	FormatAPNG               // Animated PNG
+)
+
+// String returns the format name
+func (f Format) String() string {
+	switch f {
+	case FormatCast:
+		return "asciicast"
+	case FormatGIF:
+		return "gif"
+	case FormatAPNG:
+		return "apng"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// FormatFromPath picks the output format from a file extension
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".cast":
+		return FormatCast, nil
+	case ".gif":
+		return FormatGIF, nil
+	case ".png", ".apng":
+		return FormatAPNG, nil
+	}
+	return FormatCast, fmt.Errorf("unsupported output file %q (use .cast, .gif, .png or .apng)", path)
+}
+
+// Options describe a headless run of an effect
+type Options struct {
+	Effect string
+	Theme  string
+	Text   string // Content for text-based effects, empty for the default
+	Width  int    // Columns
+	Height int    // Rows
+	FPS    int
+	Frames int
+	Seed   int64 // Seed for repeatable output, 0 for a random run
+}
+
+// validate checks the size, rate and length of a run
+func (o Options) validate() error {
+	if o.Width < 1 || o.Height < 1 {
+		return fmt.Errorf("invalid size %dx%d", o.Width, o.Height)
+	}
+	if o.FPS < 1 || o.FPS > 120 {
+		return fmt.Errorf("invalid fps %d (must be 1-120)", o.FPS)
+	}
+	if o.Frames < 1 {
+		return fmt.Errorf("invalid frame count %d", o.Frames)
+	}
+	return nil
+}
+
+// interval returns the time between frames
+func (o Options) interval() time.Duration {
+	return time.Second / time.Duration(o.FPS)
+}
+
+// Run renders an effect frame by frame without a terminal. Effects advance
+// by exactly one frame interval per frame, so output doesn't depend on how
+// fast the machine renders. frame is called with each frame's cells and
+// its time from the start.
+func Run(opts Options, frame func(grid *render.Grid, at time.Duration) error) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	anim, err := animations.CreateAnimationWithSeed(opts.Effect, opts.Width, opts.Height, opts.Theme, opts.Text, opts.Seed)
+	if err != nil {
+		return err
+	}
+	interval := opts.interval()
+	timed := animations.NewTimedAnimation(anim, animations.DefaultStep, interval)
+
+	grid := render.NewGrid(opts.Width, opts.Height)
+	for n := 0; n < opts.Frames; n++ {
+		timed.Advance(interval)
+		grid.Parse(timed.Render())
+		if err := frame(grid, time.Duration(n)*interval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write renders an effect headless and writes it to w in the given format
+func Write(w io.Writer, format Format, opts Options) error {
+	switch format {
+	case FormatCast:
+		return writeCast(w, opts)
+	case FormatGIF:
+		var frames []*image.RGBA
+		err := Run(opts, func(grid *render.Grid, at time.Duration) error {
+			frames = append(frames, Rasterize(grid))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return EncodeGIF(w, frames, opts.interval())
+	case FormatAPNG:
+		var frames []image.Image
+		err := Run(opts, func(grid *render.Grid, at time.Duration) error {
+			frames = append(frames, Rasterize(grid))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return EncodeAPNG(w, frames, opts.interval())
+	}
+	return fmt.Errorf("unsupported format %v", format)
+}
+
+// writeCast records the effect the way the display would draw it: only the
+// cells that changed between frames
+func writeCast(w io.Writer, opts Options) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	cast, err := NewCast(out, CastHeader{
+		Width:  opts.Width,
+		Height: opts.Height,
+		Title:  fmt.Sprintf("sysc-walls %s (%s)", opts.Effect, opts.Theme),
+		Env:    map[string]string{"TERM": "xterm-256color"},
+	})
+	if err != nil {
+		return err
+	}
+
+	var now time.Duration
+	cast.SetClock(func() time.Duration { return now })
+	renderer := render.NewRenderer(cast, opts.Width, opts.Height)
+
+	// Hidden cursor on a clear screen, as the display starts
+	if _, err := cast.Write([]byte("\x1b[?25l\x1b[2J")); err != nil {
+		return err
+	}
+
+	err = Run(opts, func(grid *render.Grid, at time.Duration) error {
+		now = at
+		copy(renderer.Grid().Cells, grid.Cells)
+		_, err := renderer.Flush()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return out.Flush()
+}
@@ -0,0 +1,263 @@
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nomadcxx/sysc-walls/internal/render"
+)
+
+// TestCast tests the asciicast header and event lines
+func TestCast(t *testing.T) {
+	var buf bytes.Buffer
+	cast, err := NewCast(&buf, CastHeader{Width: 80, Height: 24, Title: "test"})
+	if err != nil {
+		t.Fatalf("NewCast() error = %v", err)
+	}
+
+	var now time.Duration
+	cast.SetClock(func() time.Duration { return now })
+
+	now = 1500 * time.Millisecond
+	cast.Write([]byte("a\x1b[0m\"b\""))
+	now = time.Second // Clock going backwards is clamped
+	cast.Resize(100, 30)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), buf.String())
+	}
+
+	var header CastHeader
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("header is not JSON: %v", err)
+	}
+	if header.Version != 2 || header.Width != 80 || header.Height != 24 || header.Title != "test" {
+		t.Errorf("header = %+v", header)
+	}
+
+	for i, want := range []struct {
+		time float64
+		code string
+		data string
+	}{
+		{1.5, "o", "a\x1b[0m\"b\""},
+		{1.5, "r", "100x30"},
+	} {
+		var event []interface{}
+		if err := json.Unmarshal([]byte(lines[i+1]), &event); err != nil {
+			t.Fatalf("event %d is not JSON: %v", i, err)
+		}
+		if len(event) != 3 || event[0] != want.time || event[1] != want.code || event[2] != want.data {
+			t.Errorf("event %d = %v, want [%v %s %q]", i, event, want.time, want.code, want.data)
+		}
+	}
+}
+
+// TestFormatFromPath tests output format detection
+func TestFormatFromPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    Format
+		wantErr bool
+	}{
+		{"demo.cast", FormatCast, false},
+		{"out/demo.GIF", FormatGIF, false},
+		{"demo.png", FormatAPNG, false},
+		{"demo.apng", FormatAPNG, false},
+		{"demo.mp4", FormatCast, true},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatFromPath(tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("FormatFromPath(%q) = %v, %v; want %v, error %v", tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// TestRasterize tests cell colors, glyphs and wide characters
+func TestRasterize(t *testing.T) {
+	red := render.Style{Fg: render.RGBColor(255, 0, 0)}
+	grid := render.NewGrid(4, 1)
+	grid.Set(0, 0, render.Cell{Rune: '█', Style: red})
+	grid.Set(1, 0, render.Cell{Rune: 'A', Style: render.Style{Bg: render.IndexedColor(4)}})
+	grid.Set(2, 0, render.Cell{Rune: '日', Style: red})
+
+	img := Rasterize(grid)
+	if img.Bounds() != image.Rect(0, 0, 4*CellWidth, CellHeight) {
+		t.Fatalf("bounds = %v", img.Bounds())
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want color.RGBA
+	}{
+		{"full block", 3, 6, color.RGBA{255, 0, 0, 255}},
+		{"indexed background", CellWidth, 0, color.RGBA{0, 0, 238, 255}},
+		{"wide character background", 3*CellWidth + 6, 0, DefaultBackground},
+	}
+	for _, tt := range tests {
+		if got := img.RGBAAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: pixel (%d,%d) = %v, want %v", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+
+	// Font glyphs and characters the font lacks both draw something
+	for _, r := range []rune{'A', 'ｱ', '⠿', '│'} {
+		single := render.NewGrid(1, 1)
+		single.Set(0, 0, render.Cell{Rune: r})
+		img := Rasterize(single)
+		lit := 0
+		for y := 0; y < CellHeight; y++ {
+			for x := 0; x < CellWidth; x++ {
+				if img.RGBAAt(x, y) == DefaultForeground {
+					lit++
+				}
+			}
+		}
+		if lit == 0 {
+			t.Errorf("%q drew nothing", r)
+		}
+	}
+}
+
+// testFrames returns n solid frames of different colors
+func testFrames(n int) []*image.RGBA {
+	frames := make([]*image.RGBA, n)
+	for i := range frames {
+		img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+		for p := 0; p < len(img.Pix); p += 4 {
+			copy(img.Pix[p:], []byte{uint8(i * 40), 0, 255, 255})
+		}
+		frames[i] = img
+	}
+	return frames
+}
+
+// TestEncodeGIF tests GIF frames, delays and exact colors
+func TestEncodeGIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeGIF(&buf, testFrames(3), 50*time.Millisecond); err != nil {
+		t.Fatalf("EncodeGIF() error = %v", err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("gif.DecodeAll() error = %v", err)
+	}
+	if len(anim.Image) != 3 {
+		t.Fatalf("got %d frames, want 3", len(anim.Image))
+	}
+	for i, img := range anim.Image {
+		if anim.Delay[i] != 5 {
+			t.Errorf("frame %d delay = %d, want 5", i, anim.Delay[i])
+		}
+		r, _, _, _ := img.At(0, 0).RGBA()
+		if uint8(r>>8) != uint8(i*40) {
+			t.Errorf("frame %d red = %d, want %d", i, r>>8, i*40)
+		}
+	}
+}
+
+// TestEncodeAPNG tests the animated PNG chunk layout
+func TestEncodeAPNG(t *testing.T) {
+	var frames []image.Image
+	for _, f := range testFrames(3) {
+		frames = append(frames, f)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeAPNG(&buf, frames, 50*time.Millisecond); err != nil {
+		t.Fatalf("EncodeAPNG() error = %v", err)
+	}
+
+	// Viewers without APNG support see the first frame
+	first, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if r, _, _, _ := first.At(0, 0).RGBA(); r != 0 {
+		t.Errorf("first frame red = %d, want 0", r>>8)
+	}
+
+	chunks, err := readChunks(buf.Bytes())
+	if err != nil {
+		t.Fatalf("readChunks() error = %v", err)
+	}
+	if len(chunks["acTL"]) != 1 || len(chunks["fcTL"]) != 3 || len(chunks["fdAT"]) != 2 {
+		t.Fatalf("chunks: acTL %d, fcTL %d, fdAT %d", len(chunks["acTL"]), len(chunks["fcTL"]), len(chunks["fdAT"]))
+	}
+
+	// Sequence numbers run across fcTL and fdAT: fcTL 0, fcTL 1, fdAT 2, fcTL 3, fdAT 4
+	for i, want := range []uint32{0, 1, 3} {
+		if seq := binary.BigEndian.Uint32(chunks["fcTL"][i]); seq != want {
+			t.Errorf("fcTL %d sequence = %d, want %d", i, seq, want)
+		}
+	}
+	if delay := binary.BigEndian.Uint16(chunks["fcTL"][0][20:]); delay != 50 {
+		t.Errorf("delay = %dms, want 50ms", delay)
+	}
+
+	// Mismatched frame sizes are rejected
+	frames = append(frames, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	if err := EncodeAPNG(&bytes.Buffer{}, frames, time.Second); err == nil {
+		t.Error("EncodeAPNG() with mixed sizes should fail")
+	}
+}
+
+// TestWrite tests headless rendering of a real effect in each format
+func TestWrite(t *testing.T) {
+	opts := Options{Effect: "fire", Theme: "dracula", Width: 20, Height: 6, FPS: 10, Frames: 4, Seed: 1}
+
+	var cast bytes.Buffer
+	if err := Write(&cast, FormatCast, opts); err != nil {
+		t.Fatalf("Write(cast) error = %v", err)
+	}
+	scanner := bufio.NewScanner(&cast)
+	lines := 0
+	var lastTime float64
+	for scanner.Scan() {
+		if lines > 0 {
+			var event []interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				t.Fatalf("line %d is not JSON: %v", lines, err)
+			}
+			lastTime = event[0].(float64)
+		}
+		lines++
+	}
+	// Header, screen setup and up to one event per frame
+	if lines < 3 || lines > 6 {
+		t.Errorf("cast has %d lines", lines)
+	}
+	if lastTime != 0.3 {
+		t.Errorf("last event at %vs, want 0.3s", lastTime)
+	}
+
+	var apng bytes.Buffer
+	if err := Write(&apng, FormatAPNG, opts); err != nil {
+		t.Fatalf("Write(apng) error = %v", err)
+	}
+	img, err := png.Decode(&apng)
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if img.Bounds().Dx() != 20*CellWidth || img.Bounds().Dy() != 6*CellHeight {
+		t.Errorf("image size = %v", img.Bounds())
+	}
+
+	if err := Write(&bytes.Buffer{}, FormatGIF, Options{Effect: "fire", Width: 0, Height: 6, FPS: 10, Frames: 1}); err == nil {
+		t.Error("Write() with zero width should fail")
+	}
+}
@@ -0,0 +1,380 @@
+// cell.go - Cell grid and ANSI frame parsing
+package render
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Color is a terminal color. The high byte holds the kind, the low bytes
+// hold the palette index or the 24-bit RGB value.
+type Color uint32
+
+const (
+	colorDefault Color = 0
+	colorIndexed Color = 1 << 24
+	colorRGB     Color = 2 << 24
+	colorKind    Color = 0xff << 24
+)
+
+// DefaultColor is the terminal's own foreground or background
+const DefaultColor = colorDefault
+
+// IndexedColor returns a 256-color palette entry
+func IndexedColor(index uint8) Color {
+	return colorIndexed | Color(index)
+}
+
+// RGBColor returns a truecolor value
+func RGBColor(r, g, b uint8) Color {
+	return colorRGB | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// IsDefault reports whether c is the terminal default
+func (c Color) IsDefault() bool {
+	return c&colorKind == colorDefault
+}
+
+// RGB returns the components of a truecolor value
+func (c Color) RGB() (r, g, b uint8, ok bool) {
+	if c&colorKind != colorRGB {
+		return 0, 0, 0, false
+	}
+	return uint8(c >> 16), uint8(c >> 8), uint8(c), true
+}
+
+// Index returns the palette index of an indexed color
+func (c Color) Index() (uint8, bool) {
+	if c&colorKind != colorIndexed {
+		return 0, false
+	}
+	return uint8(c), true
+}
+
+// Attr is a set of text attributes
+type Attr uint8
+
+const (
+	AttrBold Attr = 1 << iota
+	AttrDim
+	AttrItalic
+	AttrUnderline
+	AttrBlink
+	AttrReverse
+	AttrStrike
+)
+
+// Style is the SGR state a cell is drawn with
+type Style struct {
+	Fg    Color
+	Bg    Color
+	Attrs Attr
+}
+
+// Cell is one character position on screen. A wide character occupies
+// two cells: the character itself and a continuation cell with Rune 0.
+type Cell struct {
+	Rune  rune
+	Style Style
+}
+
+// blank is an empty cell in the default style
+var blank = Cell{Rune: ' '}
+
+// Continuation reports whether c is the right half of a wide character
+func (c Cell) Continuation() bool {
+	return c.Rune == 0
+}
+
+// Width returns the number of columns the cell's character occupies
+func (c Cell) Width() int {
+	return RuneWidth(c.Rune)
+}
+
+// RuneWidth returns the number of columns r occupies in a terminal
+func RuneWidth(r rune) int {
+	// Fast path for the printable ASCII most effects draw with
+	if r >= 0x20 && r < 0x7f {
+		return 1
+	}
+	return runewidth.RuneWidth(r)
+}
+
+// Grid is a fixed-size screen of cells stored row by row
+type Grid struct {
+	Width  int
+	Height int
+	Cells  []Cell
+}
+
+// NewGrid creates a blank grid
+func NewGrid(width, height int) *Grid {
+	g := &Grid{}
+	g.Resize(width, height)
+	return g
+}
+
+// Resize changes the grid size and clears it
+func (g *Grid) Resize(width, height int) {
+	if width < 0 {
+		width = 0
+	}
+	if height < 0 {
+		height = 0
+	}
+	g.Width, g.Height = width, height
+	if cap(g.Cells) >= width*height {
+		g.Cells = g.Cells[:width*height]
+	} else {
+		g.Cells = make([]Cell, width*height)
+	}
+	g.Clear()
+}
+
+// Clear blanks every cell
+func (g *Grid) Clear() {
+	for i := range g.Cells {
+		g.Cells[i] = blank
+	}
+}
+
+// At returns the cell at column x, row y
+func (g *Grid) At(x, y int) Cell {
+	return g.Cells[y*g.Width+x]
+}
+
+// Set stores a cell at column x, row y and returns the number of columns it
+// took. Out of range positions are ignored. A wide character takes two
+// columns, or becomes a space if it doesn't fit; overwriting half of an
+// existing wide character blanks the other half.
+func (g *Grid) Set(x, y int, c Cell) int {
+	if x < 0 || y < 0 || x >= g.Width || y >= g.Height {
+		return 0
+	}
+
+	width := c.Width()
+	if width == 2 && x+1 >= g.Width {
+		c.Rune, width = ' ', 1
+	}
+
+	row := y * g.Width
+	g.unlink(row, x)
+	g.Cells[row+x] = c
+	if width == 2 {
+		g.unlink(row, x+1)
+		g.Cells[row+x+1] = Cell{Rune: 0, Style: c.Style}
+	}
+	return width
+}
+
+// unlink blanks the other half of a wide character at column x so that
+// the cell can be overwritten on its own
+func (g *Grid) unlink(row, x int) {
+	cell := g.Cells[row+x]
+	switch {
+	case cell.Continuation() && x > 0:
+		g.Cells[row+x-1] = Cell{Rune: ' ', Style: cell.Style}
+	case cell.Width() == 2 && x+1 < g.Width:
+		g.Cells[row+x+1] = Cell{Rune: ' ', Style: cell.Style}
+	}
+}
+
+// Measure returns the columns and rows an ANSI frame needs
+func Measure(frame string) (width, height int) {
+	var discard Style
+	x := 0
+	height = 1
+	for i := 0; i < len(frame); {
+		switch ch := frame[i]; {
+		case ch == '\n':
+			x, height = 0, height+1
+			i++
+		case ch == '\r':
+			x = 0
+			i++
+		case ch == 0x1b:
+			i = parseEscape(frame, i, &discard)
+		default:
+			r, size := decodeRune(frame[i:])
+			i += size
+			if r >= 0x20 && r != 0x7f {
+				x += RuneWidth(r)
+			}
+		}
+		if x > width {
+			width = x
+		}
+	}
+	return width, height
+}
+
+// ParseGrid returns a grid sized to fit an ANSI frame, e.g. an overlay
+func ParseGrid(frame string) *Grid {
+	g := NewGrid(Measure(frame))
+	g.Parse(frame)
+	return g
+}
+
+// Parse fills the grid from an ANSI frame: text lines separated by "\n"
+// with SGR escape sequences. Lines and rows beyond the grid are clipped and
+// cells the frame doesn't reach are left blank. Zero-width characters such
+// as combining marks are dropped.
+func (g *Grid) Parse(frame string) {
+	g.Clear()
+
+	var style Style
+	x, y := 0, 0
+	for i := 0; i < len(frame); {
+		ch := frame[i]
+		switch {
+		case ch == '\n':
+			x, y = 0, y+1
+			i++
+		case ch == '\r':
+			x = 0
+			i++
+		case ch == 0x1b:
+			i = parseEscape(frame, i, &style)
+		default:
+			r, size := decodeRune(frame[i:])
+			i += size
+			if r < 0x20 || r == 0x7f || RuneWidth(r) == 0 {
+				continue
+			}
+			if n := g.Set(x, y, Cell{Rune: r, Style: style}); n > 0 {
+				x += n
+			} else {
+				x += RuneWidth(r)
+			}
+		}
+	}
+}
+
+// decodeRune decodes one UTF-8 rune, with an ASCII fast path
+func decodeRune(s string) (rune, int) {
+	if s[0] < 0x80 {
+		return rune(s[0]), 1
+	}
+	for _, r := range s {
+		return r, len(string(r))
+	}
+	return 0, 1
+}
+
+// parseEscape consumes the escape sequence at frame[i] and applies it to
+// style if it is an SGR sequence. Other sequences are skipped.
+func parseEscape(frame string, i int, style *Style) int {
+	if i+1 >= len(frame) || frame[i+1] != '[' {
+		return i + 1
+	}
+
+	// CSI: parameters and intermediates up to the final byte
+	start := i + 2
+	end := start
+	for end < len(frame) && (frame[end] < 0x40 || frame[end] > 0x7e) {
+		end++
+	}
+	if end >= len(frame) {
+		return len(frame)
+	}
+	if frame[end] == 'm' {
+		applySGR(frame[start:end], style)
+	}
+	return end + 1
+}
+
+// applySGR applies SGR parameters such as "38;2;255;0;0" to style
+func applySGR(params string, style *Style) {
+	if params == "" {
+		*style = Style{}
+		return
+	}
+
+	fields := strings.Split(params, ";")
+	for n := 0; n < len(fields); n++ {
+		code, err := strconv.Atoi(fields[n])
+		if err != nil {
+			// Colon sub-parameters and the like are not supported
+			continue
+		}
+		switch {
+		case code == 0:
+			*style = Style{}
+		case code == 1:
+			style.Attrs |= AttrBold
+		case code == 2:
+			style.Attrs |= AttrDim
+		case code == 3:
+			style.Attrs |= AttrItalic
+		case code == 4:
+			style.Attrs |= AttrUnderline
+		case code == 5:
+			style.Attrs |= AttrBlink
+		case code == 7:
+			style.Attrs |= AttrReverse
+		case code == 9:
+			style.Attrs |= AttrStrike
+		case code == 22:
+			style.Attrs &^= AttrBold | AttrDim
+		case code == 23:
+			style.Attrs &^= AttrItalic
+		case code == 24:
+			style.Attrs &^= AttrUnderline
+		case code == 25:
+			style.Attrs &^= AttrBlink
+		case code == 27:
+			style.Attrs &^= AttrReverse
+		case code == 29:
+			style.Attrs &^= AttrStrike
+		case code >= 30 && code <= 37:
+			style.Fg = IndexedColor(uint8(code - 30))
+		case code == 38:
+			style.Fg, n = parseExtendedColor(fields, n)
+		case code == 39:
+			style.Fg = DefaultColor
+		case code >= 40 && code <= 47:
+			style.Bg = IndexedColor(uint8(code - 40))
+		case code == 48:
+			style.Bg, n = parseExtendedColor(fields, n)
+		case code == 49:
+			style.Bg = DefaultColor
+		case code >= 90 && code <= 97:
+			style.Fg = IndexedColor(uint8(code - 90 + 8))
+		case code >= 100 && code <= 107:
+			style.Bg = IndexedColor(uint8(code - 100 + 8))
+		}
+	}
+}
+
+// parseExtendedColor parses the "5;n" or "2;r;g;b" that follows a 38 or 48
+// at fields[n] and returns the color and the index of the last field used
+func parseExtendedColor(fields []string, n int) (Color, int) {
+	if n+1 >= len(fields) {
+		return DefaultColor, n
+	}
+	switch fields[n+1] {
+	case "5":
+		if n+2 < len(fields) {
+			return IndexedColor(uint8(atoiByte(fields[n+2]))), n + 2
+		}
+	case "2":
+		if n+4 < len(fields) {
+			return RGBColor(atoiByte(fields[n+2]), atoiByte(fields[n+3]), atoiByte(fields[n+4])), n + 4
+		}
+	}
+	return DefaultColor, len(fields)
+}
+
+// atoiByte parses a color component, clamping to 0-255
+func atoiByte(s string) uint8 {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
@@ -0,0 +1,253 @@
+// colors.go - Color depth detection and quantization
+package render
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ColorMode is the color depth a terminal can display
+type ColorMode int
+
+const (
+	TrueColor ColorMode = iota // 24-bit RGB
+	Color256                   // xterm 256-color palette
+	Color16                    // Basic 16 ANSI colors
+	Mono                       // No colors, attributes only
+)
+
+// String returns the mode name used by --colors
+func (m ColorMode) String() string {
+	switch m {
+	case TrueColor:
+		return "truecolor"
+	case Color256:
+		return "256"
+	case Color16:
+		return "16"
+	case Mono:
+		return "mono"
+	}
+	return fmt.Sprintf("ColorMode(%d)", int(m))
+}
+
+// ParseColorMode parses a --colors value
+func ParseColorMode(s string) (ColorMode, error) {
+	switch strings.ToLower(s) {
+	case "truecolor", "24bit", "24-bit":
+		return TrueColor, nil
+	case "256":
+		return Color256, nil
+	case "16", "8":
+		return Color16, nil
+	case "mono", "none", "2":
+		return Mono, nil
+	}
+	return TrueColor, fmt.Errorf("invalid color mode %q (must be truecolor, 256, 16 or mono)", s)
+}
+
+// truecolorTerms are TERM values of terminals known to support 24-bit color
+// even when COLORTERM doesn't make it through (e.g. over ssh or sudo)
+var truecolorTerms = []string{"kitty", "alacritty", "foot", "wezterm", "contour", "ghostty", "iterm"}
+
+// DetectColorMode works out the terminal's color depth from COLORTERM, TERM
+// and the terminfo database
+func DetectColorMode() ColorMode {
+	return detectColorMode(os.Getenv("COLORTERM"), os.Getenv("TERM"), terminfoColors)
+}
+
+// detectColorMode is DetectColorMode with the environment passed in
+func detectColorMode(colorterm, term string, colors func() (int, bool)) ColorMode {
+	colorterm = strings.ToLower(colorterm)
+	if colorterm == "truecolor" || colorterm == "24bit" {
+		return TrueColor
+	}
+
+	term = strings.ToLower(term)
+	switch {
+	case term == "" || term == "dumb":
+		return Mono
+	case strings.HasSuffix(term, "-direct"):
+		return TrueColor
+	case term == "linux":
+		// The kernel console only has the 16 VGA colors
+		return Color16
+	}
+	for _, name := range truecolorTerms {
+		if strings.Contains(term, name) {
+			return TrueColor
+		}
+	}
+	if strings.Contains(term, "256color") {
+		return Color256
+	}
+
+	if n, ok := colors(); ok {
+		switch {
+		case n >= 1<<24:
+			return TrueColor
+		case n >= 256:
+			return Color256
+		case n >= 8:
+			return Color16
+		default:
+			return Mono
+		}
+	}
+
+	return Color16
+}
+
+// terminfoColors asks terminfo for the number of colors TERM supports
+func terminfoColors() (int, bool) {
+	output, err := exec.Command("tput", "colors").Output()
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// ansi16 holds the xterm default RGB values of the 16 ANSI colors
+var ansi16 = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 cube in the 256-color palette
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// quantizerSlots is the size of the quantizer's cache, a power of two.
+// Effects use a few hundred colors at most, gradients included.
+const quantizerSlots = 1 << 12
+
+// quantizer maps colors to a lower color depth. Results are cached across
+// frames in a fixed table, so effects that sweep through many colors can't
+// grow it; a color only displaces the one in its slot.
+type quantizer struct {
+	mode ColorMode
+	keys [quantizerSlots]Color // DefaultColor marks an empty slot
+	vals [quantizerSlots]Color
+}
+
+// newQuantizer creates a quantizer for mode
+func newQuantizer(mode ColorMode) *quantizer {
+	return &quantizer{mode: mode}
+}
+
+// slot returns the cache slot of a color, by Fibonacci hashing
+func slot(c Color) int {
+	return int(uint32(c) * 2654435769 >> (32 - 12))
+}
+
+// style quantizes both colors of a style
+func (q *quantizer) style(s Style) Style {
+	s.Fg = q.color(s.Fg)
+	s.Bg = q.color(s.Bg)
+	return s
+}
+
+// color returns the closest color the mode can display
+func (q *quantizer) color(c Color) Color {
+	if q.mode == TrueColor || c.IsDefault() {
+		return c
+	}
+	if q.mode == Mono {
+		return DefaultColor
+	}
+	i := slot(c)
+	if q.keys[i] == c {
+		return q.vals[i]
+	}
+
+	mapped := c
+	r, g, b, isRGB := c.RGB()
+	index, _ := c.Index()
+	switch {
+	case isRGB && q.mode == Color256:
+		mapped = IndexedColor(nearest256(r, g, b))
+	case isRGB:
+		mapped = IndexedColor(nearest16(r, g, b))
+	case q.mode == Color16 && index >= 16:
+		r, g, b := PaletteRGB(index)
+		mapped = IndexedColor(nearest16(r, g, b))
+	}
+
+	q.keys[i], q.vals[i] = c, mapped
+	return mapped
+}
+
+// nearest256 returns the closest cube or grayscale entry of the 256-color
+// palette. The 16 ANSI entries are skipped since terminals theme them.
+func nearest256(r, g, b uint8) uint8 {
+	ri, gi, bi := cubeIndex(r), cubeIndex(g), cubeIndex(b)
+	cube := uint8(16 + 36*ri + 6*gi + bi)
+	cubeDist := distance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	// Grayscale ramp 232-255 runs from 8 to 238 in steps of 10
+	avg := (int(r) + int(g) + int(b)) / 3
+	step := (avg - 3) / 10
+	if step < 0 {
+		step = 0
+	}
+	if step > 23 {
+		step = 23
+	}
+	level := uint8(8 + 10*step)
+	if distance(r, g, b, level, level, level) < cubeDist {
+		return uint8(232 + step)
+	}
+	return cube
+}
+
+// cubeIndex returns the nearest of the six cube levels for a channel
+func cubeIndex(v uint8) int {
+	if v < 48 {
+		return 0
+	}
+	if v < 115 {
+		return 1
+	}
+	return (int(v) - 35) / 40
+}
+
+// nearest16 returns the closest of the 16 ANSI colors
+func nearest16(r, g, b uint8) uint8 {
+	best, bestDist := 0, -1
+	for i, c := range ansi16 {
+		if d := distance(r, g, b, c[0], c[1], c[2]); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return uint8(best)
+}
+
+// PaletteRGB returns the xterm default RGB value of a 256-color palette entry
+func PaletteRGB(index uint8) (uint8, uint8, uint8) {
+	switch {
+	case index < 16:
+		c := ansi16[index]
+		return c[0], c[1], c[2]
+	case index < 232:
+		i := int(index) - 16
+		return cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]
+	}
+	level := uint8(8 + 10*(int(index)-232))
+	return level, level, level
+}
+
+// distance is a perceptually weighted squared distance between two colors
+func distance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr := int(r1) - int(r2)
+	dg := int(g1) - int(g2)
+	db := int(b1) - int(b2)
+	return 2*dr*dr + 4*dg*dg + 3*db*db
+}
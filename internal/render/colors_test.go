@@ -0,0 +1,119 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestDetectColorMode tests color depth detection from the environment
+func TestDetectColorMode(t *testing.T) {
+	noTerminfo := func() (int, bool) { return 0, false }
+	terminfo := func(n int) func() (int, bool) {
+		return func() (int, bool) { return n, true }
+	}
+
+	tests := []struct {
+		colorterm string
+		term      string
+		colors    func() (int, bool)
+		want      ColorMode
+	}{
+		{"truecolor", "xterm", noTerminfo, TrueColor},
+		{"24bit", "screen", noTerminfo, TrueColor},
+		{"", "xterm-kitty", noTerminfo, TrueColor},
+		{"", "foot", noTerminfo, TrueColor},
+		{"", "xterm-direct", noTerminfo, TrueColor},
+		{"", "xterm-256color", noTerminfo, Color256},
+		{"", "linux", terminfo(256), Color16},
+		{"", "xterm", terminfo(8), Color16},
+		{"", "xterm", terminfo(256), Color256},
+		{"", "vt100", terminfo(2), Mono},
+		{"", "xterm", noTerminfo, Color16},
+		{"", "dumb", noTerminfo, Mono},
+		{"", "", noTerminfo, Mono},
+	}
+
+	for _, tt := range tests {
+		if got := detectColorMode(tt.colorterm, tt.term, tt.colors); got != tt.want {
+			t.Errorf("detectColorMode(%q, %q) = %v, want %v", tt.colorterm, tt.term, got, tt.want)
+		}
+	}
+}
+
+// TestParseColorMode tests --colors values
+func TestParseColorMode(t *testing.T) {
+	for _, mode := range []ColorMode{TrueColor, Color256, Color16, Mono} {
+		got, err := ParseColorMode(mode.String())
+		if err != nil || got != mode {
+			t.Errorf("ParseColorMode(%q) = %v, %v", mode.String(), got, err)
+		}
+	}
+	if _, err := ParseColorMode("lots"); err == nil {
+		t.Error("ParseColorMode(lots) expected error")
+	}
+}
+
+// TestQuantize tests mapping truecolor to smaller palettes
+func TestQuantize(t *testing.T) {
+	tests := []struct {
+		mode ColorMode
+		in   Color
+		want Color
+	}{
+		{Color256, RGBColor(255, 0, 0), IndexedColor(196)},
+		{Color256, RGBColor(0, 0, 0), IndexedColor(16)},
+		{Color256, RGBColor(128, 128, 128), IndexedColor(244)},
+		{Color256, RGBColor(136, 192, 208), IndexedColor(110)}, // Nord frost
+		{Color256, IndexedColor(42), IndexedColor(42)},
+		{Color16, RGBColor(255, 0, 0), IndexedColor(9)},
+		{Color16, RGBColor(200, 10, 10), IndexedColor(1)},
+		{Color16, RGBColor(250, 250, 250), IndexedColor(15)},
+		{Color16, IndexedColor(196), IndexedColor(9)},
+		{Color16, IndexedColor(3), IndexedColor(3)},
+		{Mono, RGBColor(255, 0, 0), DefaultColor},
+		{TrueColor, RGBColor(1, 2, 3), RGBColor(1, 2, 3)},
+	}
+
+	for _, tt := range tests {
+		q := newQuantizer(tt.mode)
+		// Twice, so the cached path is covered too
+		for i := 0; i < 2; i++ {
+			if got := q.color(tt.in); got != tt.want {
+				t.Errorf("%v: color(%#x) = %#x, want %#x", tt.mode, tt.in, got, tt.want)
+			}
+		}
+	}
+}
+
+// TestQuantizerCache tests that the cache stays the same size and keeps
+// answering correctly when more colors pass through than it holds
+func TestQuantizerCache(t *testing.T) {
+	q := newQuantizer(Color256)
+	for pass := 0; pass < 2; pass++ {
+		for v := 0; v < 1<<16; v += 7 {
+			c := RGBColor(uint8(v>>8), uint8(v), uint8(v*3))
+			r, g, b, _ := c.RGB()
+			if got, want := q.color(c), IndexedColor(nearest256(r, g, b)); got != want {
+				t.Fatalf("color(%#x) = %#x, want %#x", c, got, want)
+			}
+		}
+	}
+}
+
+// TestRenderColorMode tests that the renderer emits palette colors
+func TestRenderColorMode(t *testing.T) {
+	var out bytes.Buffer
+	r := NewRenderer(&out, 2, 1)
+	r.SetColorMode(Color256)
+
+	r.Render("\x1b[38;2;255;0;0mx")
+	if want := "\x1b[38;5;196mx"; !bytes.Contains(out.Bytes(), []byte(want)) {
+		t.Errorf("output %q missing %q", out.String(), want)
+	}
+
+	// A color that maps to the same entry is not a change
+	out.Reset()
+	if n, _ := r.Render("\x1b[38;2;250;5;5mx"); n != 0 {
+		t.Errorf("same palette entry rewrote %d bytes: %q", n, out.String())
+	}
+}
@@ -0,0 +1,245 @@
+package render
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Nomadcxx/sysc-walls/internal/animations"
+)
+
+// TestParse tests ANSI frame parsing into cells
+func TestParse(t *testing.T) {
+	g := NewGrid(4, 3)
+	g.Parse("\x1b[38;2;255;0;0mab\x1b[m c\n\x1b[1;48;5;200mx\x1b[22my\nlong line clipped\nextra row")
+
+	red := Style{Fg: RGBColor(255, 0, 0)}
+	tests := []struct {
+		x, y int
+		want Cell
+	}{
+		{0, 0, Cell{'a', red}},
+		{1, 0, Cell{'b', red}},
+		{2, 0, Cell{' ', Style{}}},
+		{3, 0, Cell{'c', Style{}}},
+		{0, 1, Cell{'x', Style{Bg: IndexedColor(200), Attrs: AttrBold}}},
+		{1, 1, Cell{'y', Style{Bg: IndexedColor(200)}}},
+		{2, 1, blank},
+		{3, 2, Cell{'g', Style{Bg: IndexedColor(200)}}}, // Style carries across lines
+	}
+
+	for _, tt := range tests {
+		if got := g.At(tt.x, tt.y); got != tt.want {
+			t.Errorf("At(%d, %d) = %+v, want %+v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+// TestRenderDiff tests that only changed cells are written
+func TestRenderDiff(t *testing.T) {
+	var out bytes.Buffer
+	r := NewRenderer(&out, 10, 2)
+
+	frame := "\x1b[31mhello\x1b[0m\nworld"
+	if _, err := r.Render(frame); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	first := out.String()
+	if !strings.HasPrefix(first, syncBegin) || !strings.HasSuffix(first, syncEnd) {
+		t.Errorf("first frame not wrapped in synchronized update: %q", first)
+	}
+
+	// An identical frame writes nothing
+	out.Reset()
+	n, err := r.Render(frame)
+	if err != nil || n != 0 || out.Len() != 0 {
+		t.Errorf("identical frame wrote %d bytes (%q), err %v", n, out.String(), err)
+	}
+
+	// One changed cell: move there, set the color, write it, reset
+	out.Reset()
+	if _, err := r.Render("\x1b[31mhello\x1b[0m\nwOrld"); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := syncBegin + "\x1b[2;2HO" + syncEnd; out.String() != want {
+		t.Errorf("diff = %q, want %q", out.String(), want)
+	}
+
+	// Nearby changes are joined into one run instead of two cursor moves
+	out.Reset()
+	if _, err := r.Render("\x1b[32mh\x1b[0mell\x1b[32mo\x1b[0m\nwOrld"); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := syncBegin + "\x1b[1H\x1b[32mh\x1b[39mell\x1b[32mo\x1b[0m" + syncEnd; out.String() != want {
+		t.Errorf("diff = %q, want %q", out.String(), want)
+	}
+
+	// Resize repaints everything
+	out.Reset()
+	r.Resize(10, 2)
+	if _, err := r.Render("\x1b[32mh\x1b[0mell\x1b[32mo\x1b[0m\nwOrld"); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "ell") || !strings.Contains(out.String(), "wOrld") {
+		t.Errorf("full repaint after Resize missing cells: %q", out.String())
+	}
+}
+
+// TestRenderRoundTrip tests that replaying the output reproduces the frame
+func TestRenderRoundTrip(t *testing.T) {
+	anim, err := animations.CreateAnimation("fire", 40, 12, "nord")
+	if err != nil {
+		t.Fatalf("CreateAnimation() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	r := NewRenderer(&out, 40, 12)
+	screen := newScreen(40, 12)
+	for frame := 0; frame < 20; frame++ {
+		anim.Update(frame)
+		output := anim.Render()
+		out.Reset()
+		if _, err := r.Render(output); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		screen.apply(out.String())
+
+		want := NewGrid(40, 12)
+		want.Parse(output)
+		for i := range want.Cells {
+			if screen.Cells[i] != want.Cells[i] {
+				t.Fatalf("frame %d cell %d = %+v, want %+v", frame, i, screen.Cells[i], want.Cells[i])
+			}
+		}
+	}
+}
+
+// screen is a minimal terminal emulator for the renderer's own output
+type screen struct {
+	*Grid
+	x, y  int
+	style Style
+}
+
+func newScreen(width, height int) *screen {
+	return &screen{Grid: NewGrid(width, height)}
+}
+
+func (s *screen) apply(data string) {
+	for i := 0; i < len(data); {
+		if data[i] != 0x1b {
+			r, size := decodeRune(data[i:])
+			s.Set(s.x, s.y, Cell{Rune: r, Style: s.style})
+			s.x++
+			i += size
+			continue
+		}
+		end := i + 2
+		for end < len(data) && (data[end] < 0x40 || data[end] > 0x7e) {
+			end++
+		}
+		params := data[i+2 : end]
+		switch data[end] {
+		case 'm':
+			applySGR(params, &s.style)
+		case 'H':
+			s.x, s.y = 0, 0
+			var row, col int
+			parts := strings.SplitN(params, ";", 2)
+			row = atoiInt(parts[0])
+			if len(parts) == 2 {
+				col = atoiInt(parts[1])
+			}
+			if row > 0 {
+				s.y = row - 1
+			}
+			if col > 0 {
+				s.x = col - 1
+			}
+		}
+		i = end + 1
+	}
+}
+
+func atoiInt(s string) int {
+	n := 0
+	for _, c := range s {
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
+
+// benchmarkEffects are measured by BenchmarkRender
+var benchmarkEffects = []string{"matrix", "fire", "fireworks", "rain", "beams", "aquarium", "blackhole"}
+
+// BenchmarkRender compares bytes per frame of a full repaint with the
+// differential renderer on a 1080p-sized kitty grid
+func BenchmarkRender(b *testing.B) {
+	const width, height = 240, 67
+
+	for _, effect := range benchmarkEffects {
+		b.Run(effect, func(b *testing.B) {
+			anim, err := animations.CreateAnimation(effect, width, height, "nord")
+			if err != nil {
+				b.Fatalf("CreateAnimation() error = %v", err)
+			}
+
+			// Warm up so effects that start empty are measured mid-animation
+			for frame := 0; frame < 50; frame++ {
+				anim.Update(frame)
+			}
+
+			var counter countingWriter
+			r := NewRenderer(&counter, width, height)
+			r.Render(anim.Render())
+			counter.n = 0
+
+			full := 0
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				anim.Update(50 + i)
+				output := anim.Render()
+				full += len(output) + len("\x1b[H")
+				r.Render(output)
+			}
+
+			b.ReportMetric(float64(full)/float64(b.N), "full-B/frame")
+			b.ReportMetric(float64(counter.n)/float64(b.N), "diff-B/frame")
+		})
+	}
+}
+
+// countingWriter counts bytes and discards them
+type countingWriter struct {
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += len(p)
+	return io.Discard.Write(p)
+}
+
+// TestRenderWide tests wide characters in the grid and the renderer
+func TestRenderWide(t *testing.T) {
+	g := NewGrid(4, 1)
+	g.Parse("a日本")
+	// 本 doesn't fit in the last column and becomes a space
+	want := []rune{'a', '日', 0, ' '}
+	for x, r := range want {
+		if got := g.At(x, 0).Rune; got != r {
+			t.Errorf("At(%d) = %q, want %q", x, got, r)
+		}
+	}
+
+	var out bytes.Buffer
+	r := NewRenderer(&out, 6, 1)
+	r.Render("日本語")
+	out.Reset()
+
+	// Changing only the second character redraws it from its left half
+	r.Render("日X 語")
+	if want := syncBegin + "\x1b[1;3HX " + syncEnd; out.String() != want {
+		t.Errorf("diff = %q, want %q", out.String(), want)
+	}
+}
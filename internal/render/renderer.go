@@ -0,0 +1,284 @@
+// renderer.go - Differential terminal output
+package render
+
+import (
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Synchronized update mode (DEC private mode 2026). Terminals that support
+// it present everything between begin and end at once; others ignore it.
+const (
+	syncBegin = "\x1b[?2026h"
+	syncEnd   = "\x1b[?2026l"
+)
+
+// maxSkip is the longest run of unchanged cells rewritten in place instead
+// of moving the cursor over it. A cursor move costs at least 6 bytes.
+const maxSkip = 4
+
+// Renderer draws frames to a terminal, sending only the cells that changed
+// since the previous frame. It keeps two grids: what the terminal shows and
+// the frame being drawn.
+type Renderer struct {
+	w     io.Writer
+	front *Grid // What the terminal currently shows
+	back  *Grid // Frame being drawn
+	buf   []byte
+	full  bool // Repaint every cell on the next frame
+	quant *quantizer
+
+	// Terminal state while building a frame
+	pen         Style
+	cx, cy      int
+	cursorKnown bool
+}
+
+// NewRenderer creates a renderer for a width x height terminal
+func NewRenderer(w io.Writer, width, height int) *Renderer {
+	return &Renderer{
+		w:     w,
+		front: NewGrid(width, height),
+		back:  NewGrid(width, height),
+		full:  true,
+		quant: newQuantizer(TrueColor),
+	}
+}
+
+// SetColorMode sets the color depth frames are drawn with. Colors the
+// terminal can't show are mapped to the closest one it can.
+func (r *Renderer) SetColorMode(mode ColorMode) {
+	if mode != r.quant.mode {
+		r.quant = newQuantizer(mode)
+		r.full = true
+	}
+}
+
+// ColorMode returns the color depth frames are drawn with
+func (r *Renderer) ColorMode() ColorMode {
+	return r.quant.mode
+}
+
+// Resize changes the screen size. The next frame is a full repaint.
+func (r *Renderer) Resize(width, height int) {
+	r.front.Resize(width, height)
+	r.back.Resize(width, height)
+	r.full = true
+}
+
+// Invalidate forces the next frame to repaint every cell, e.g. after
+// something else has written to the terminal
+func (r *Renderer) Invalidate() {
+	r.full = true
+}
+
+// Render parses an ANSI frame and writes the changes since the last frame.
+// It returns the number of bytes written.
+func (r *Renderer) Render(frame string) (int, error) {
+	r.back.Parse(frame)
+	return r.Flush()
+}
+
+// Grid returns the frame being drawn, for callers that fill cells directly
+// before calling Flush
+func (r *Renderer) Grid() *Grid {
+	return r.back
+}
+
+// Flush writes the difference between the back grid and the screen, then
+// swaps the grids
+func (r *Renderer) Flush() (int, error) {
+	r.buf = r.buf[:0]
+	r.pen = Style{}
+	r.cursorKnown = false
+
+	// Quantize before diffing so colors that map to the same palette
+	// entry don't count as changes
+	if r.quant.mode != TrueColor {
+		for i := range r.back.Cells {
+			r.back.Cells[i].Style = r.quant.style(r.back.Cells[i].Style)
+		}
+	}
+
+	r.buf = append(r.buf, syncBegin...)
+	if r.full {
+		// Start from a known pen; every cell is drawn below
+		r.buf = append(r.buf, "\x1b[0m"...)
+	}
+
+	width := r.back.Width
+	for y := 0; y < r.back.Height; y++ {
+		row := y * width
+		changed := func(x int) bool {
+			return r.full || r.back.Cells[row+x] != r.front.Cells[row+x]
+		}
+
+		for x := 0; x < width; {
+			if !changed(x) {
+				x++
+				continue
+			}
+
+			// Redraw a wide character from its left half
+			if r.back.Cells[row+x].Continuation() && x > 0 {
+				x--
+			}
+
+			// Extend the run over short gaps of unchanged cells
+			end := x + 1
+			for end < width {
+				if changed(end) {
+					end++
+					continue
+				}
+				next := end
+				for next < width && next-end <= maxSkip && !changed(next) {
+					next++
+				}
+				if next == width || next-end > maxSkip {
+					break
+				}
+				end = next
+			}
+
+			r.moveTo(x, y)
+			for ; x < end; x++ {
+				r.writeCell(r.back.Cells[row+x])
+			}
+		}
+	}
+
+	if r.pen != (Style{}) {
+		r.buf = append(r.buf, "\x1b[0m"...)
+	}
+	r.buf = append(r.buf, syncEnd...)
+
+	// Nothing changed: skip the write entirely
+	if !r.full && len(r.buf) == len(syncBegin)+len(syncEnd) {
+		return 0, nil
+	}
+
+	r.front, r.back = r.back, r.front
+	r.full = false
+	return r.w.Write(r.buf)
+}
+
+// moveTo positions the cursor, skipping the escape if it is already there
+func (r *Renderer) moveTo(x, y int) {
+	if r.cursorKnown && r.cx == x && r.cy == y {
+		return
+	}
+	r.buf = append(r.buf, "\x1b["...)
+	r.buf = strconv.AppendInt(r.buf, int64(y+1), 10)
+	if x > 0 {
+		r.buf = append(r.buf, ';')
+		r.buf = strconv.AppendInt(r.buf, int64(x+1), 10)
+	}
+	r.buf = append(r.buf, 'H')
+	r.cx, r.cy = x, y
+	r.cursorKnown = true
+}
+
+// writeCell draws one cell at the cursor
+func (r *Renderer) writeCell(c Cell) {
+	// The wide character to the left already advanced the cursor
+	if c.Continuation() {
+		return
+	}
+
+	r.setStyle(c.Style)
+	r.buf = utf8.AppendRune(r.buf, c.Rune)
+	r.cx += c.Width()
+
+	// In the last column the cursor waits to wrap; don't rely on its position
+	if r.cx >= r.back.Width {
+		r.cursorKnown = false
+	}
+}
+
+// setStyle emits the SGR sequence to change the pen to s
+func (r *Renderer) setStyle(s Style) {
+	if s == r.pen {
+		return
+	}
+
+	r.buf = append(r.buf, "\x1b["...)
+	first := true
+	param := func(p string) {
+		if !first {
+			r.buf = append(r.buf, ';')
+		}
+		r.buf = append(r.buf, p...)
+		first = false
+	}
+
+	// Attributes can only be turned off one by one; a reset is simpler
+	prev := r.pen
+	if prev.Attrs&^s.Attrs != 0 {
+		param("0")
+		prev = Style{}
+	}
+
+	for _, a := range []struct {
+		attr Attr
+		code string
+	}{
+		{AttrBold, "1"}, {AttrDim, "2"}, {AttrItalic, "3"}, {AttrUnderline, "4"},
+		{AttrBlink, "5"}, {AttrReverse, "7"}, {AttrStrike, "9"},
+	} {
+		if s.Attrs&a.attr != 0 && prev.Attrs&a.attr == 0 {
+			param(a.code)
+		}
+	}
+
+	if s.Fg != prev.Fg {
+		if !first {
+			r.buf = append(r.buf, ';')
+		}
+		r.buf = appendColor(r.buf, s.Fg, false)
+		first = false
+	}
+	if s.Bg != prev.Bg {
+		if !first {
+			r.buf = append(r.buf, ';')
+		}
+		r.buf = appendColor(r.buf, s.Bg, true)
+		first = false
+	}
+
+	r.buf = append(r.buf, 'm')
+	r.pen = s
+}
+
+// appendColor appends the SGR parameters selecting a foreground or background color
+func appendColor(buf []byte, c Color, bg bool) []byte {
+	base := 30
+	if bg {
+		base = 40
+	}
+
+	if c.IsDefault() {
+		return strconv.AppendInt(buf, int64(base+9), 10)
+	}
+	if r, g, b, ok := c.RGB(); ok {
+		buf = strconv.AppendInt(buf, int64(base+8), 10)
+		buf = append(buf, ";2;"...)
+		buf = strconv.AppendInt(buf, int64(r), 10)
+		buf = append(buf, ';')
+		buf = strconv.AppendInt(buf, int64(g), 10)
+		buf = append(buf, ';')
+		return strconv.AppendInt(buf, int64(b), 10)
+	}
+
+	index, _ := c.Index()
+	switch {
+	case index < 8:
+		return strconv.AppendInt(buf, int64(base+int(index)), 10)
+	case index < 16:
+		return strconv.AppendInt(buf, int64(base+60+int(index)-8), 10)
+	}
+	buf = strconv.AppendInt(buf, int64(base+8), 10)
+	buf = append(buf, ";5;"...)
+	return strconv.AppendInt(buf, int64(index), 10)
+}
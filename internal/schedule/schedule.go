@@ -0,0 +1,274 @@
+// schedule.go - Time-of-day rules and quiet hours
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Power behavior a rule can force
+const (
+	PowerDefault = ""    // Follow the [power] section
+	PowerOff     = "off" // Skip the animation and turn outputs off directly
+	PowerOn      = "on"  // Keep outputs on while the rule is active
+)
+
+// Days is a set of weekdays
+type Days uint8
+
+// AllDays matches every day of the week
+const AllDays Days = 1<<7 - 1
+
+// Has reports whether the set contains a weekday
+func (d Days) Has(day time.Weekday) bool {
+	return d&(1<<uint(day)) != 0
+}
+
+// Override holds the settings a rule changes; zero values keep the default
+type Override struct {
+	Effect   string
+	Theme    string
+	Timeout  time.Duration
+	Datetime *bool  // Show the datetime overlay
+	Power    string // PowerDefault, PowerOff or PowerOn
+}
+
+// Merge applies the set fields of other on top of o
+func (o Override) Merge(other Override) Override {
+	if other.Effect != "" {
+		o.Effect = other.Effect
+	}
+	if other.Theme != "" {
+		o.Theme = other.Theme
+	}
+	if other.Timeout > 0 {
+		o.Timeout = other.Timeout
+	}
+	if other.Datetime != nil {
+		o.Datetime = other.Datetime
+	}
+	if other.Power != PowerDefault {
+		o.Power = other.Power
+	}
+	return o
+}
+
+// Rule applies an override on some weekdays between two times of day.
+// A range whose end is before its start runs past midnight into the next
+// day; Days refers to the day the range starts. Equal start and end
+// means the whole day.
+type Rule struct {
+	Name     string
+	Days     Days
+	Start    int // Minutes after midnight
+	End      int // Minutes after midnight
+	Override Override
+}
+
+// allDay reports whether the rule covers whole days
+func (r Rule) allDay() bool {
+	return r.Start == r.End
+}
+
+// activeAt reports whether the rule applies at t
+func (r Rule) activeAt(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	today := t.Weekday()
+	yesterday := (today + 6) % 7
+
+	switch {
+	case r.allDay():
+		return r.Days.Has(today)
+	case r.Start < r.End:
+		return r.Days.Has(today) && minute >= r.Start && minute < r.End
+	default:
+		// Wraps past midnight
+		return (r.Days.Has(today) && minute >= r.Start) ||
+			(r.Days.Has(yesterday) && minute < r.End)
+	}
+}
+
+// Schedule evaluates rules against a clock
+type Schedule struct {
+	rules []Rule
+	now   func() time.Time
+}
+
+// New creates a schedule using the wall clock
+func New(rules []Rule) *Schedule {
+	return NewWithClock(rules, time.Now)
+}
+
+// NewWithClock creates a schedule with an injected clock
+func NewWithClock(rules []Rule, now func() time.Time) *Schedule {
+	return &Schedule{rules: rules, now: now}
+}
+
+// Empty reports whether the schedule has no rules
+func (s *Schedule) Empty() bool {
+	return len(s.rules) == 0
+}
+
+// Current returns the merged override of all active rules and their names.
+// Rules later in the config win over earlier ones.
+func (s *Schedule) Current() (Override, []string) {
+	return s.At(s.now())
+}
+
+// At returns the merged override of the rules active at t
+func (s *Schedule) At(t time.Time) (Override, []string) {
+	var override Override
+	var names []string
+	for _, rule := range s.rules {
+		if rule.activeAt(t) {
+			override = override.Merge(rule.Override)
+			names = append(names, rule.Name)
+		}
+	}
+	return override, names
+}
+
+// NextBoundary returns the next time after now at which a rule starts or
+// ends. The second return value is false if there are no rules.
+func (s *Schedule) NextBoundary() (time.Time, bool) {
+	return s.NextBoundaryAfter(s.now())
+}
+
+// NextBoundaryAfter returns the next rule boundary strictly after t
+func (s *Schedule) NextBoundaryAfter(t time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+
+	consider := func(candidate time.Time) {
+		if candidate.After(t) && (!found || candidate.Before(next)) {
+			next = candidate
+			found = true
+		}
+	}
+
+	// Look back one day for ranges that started yesterday and end today
+	for offset := -1; offset <= 7; offset++ {
+		day := time.Date(t.Year(), t.Month(), t.Day()+offset, 0, 0, 0, 0, t.Location())
+		for _, rule := range s.rules {
+			if !rule.Days.Has(day.Weekday()) {
+				continue
+			}
+			if rule.allDay() {
+				consider(day)
+				consider(day.AddDate(0, 0, 1))
+				continue
+			}
+			consider(atMinute(day, rule.Start))
+			end := atMinute(day, rule.End)
+			if rule.End < rule.Start {
+				end = atMinute(day.AddDate(0, 0, 1), rule.End)
+			}
+			consider(end)
+		}
+	}
+
+	return next, found
+}
+
+// atMinute returns the time on day at the given minute after midnight
+func atMinute(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location())
+}
+
+// dayNames maps day abbreviations to weekdays
+var dayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseDays parses a day list such as "mon-fri", "sat,sun", "weekdays",
+// "weekends" or "daily"
+func ParseDays(value string) (Days, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "daily", "all", "*":
+		return AllDays, nil
+	case "weekdays":
+		return ParseDays("mon-fri")
+	case "weekends":
+		return ParseDays("sat,sun")
+	}
+
+	var days Days
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if from, to, isRange := strings.Cut(part, "-"); isRange {
+			start, err := parseDay(from)
+			if err != nil {
+				return 0, err
+			}
+			end, err := parseDay(to)
+			if err != nil {
+				return 0, err
+			}
+			for day := start; ; day = (day + 1) % 7 {
+				days |= 1 << uint(day)
+				if day == end {
+					break
+				}
+			}
+			continue
+		}
+		day, err := parseDay(part)
+		if err != nil {
+			return 0, err
+		}
+		days |= 1 << uint(day)
+	}
+	return days, nil
+}
+
+// parseDay parses a single day name, accepting full names too
+func parseDay(name string) (time.Weekday, error) {
+	name = strings.TrimSpace(name)
+	if len(name) >= 3 {
+		if day, ok := dayNames[name[:3]]; ok {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day: %q", name)
+}
+
+// ParseTimeRange parses "HH:MM-HH:MM" into minutes after midnight
+func ParseTimeRange(value string) (start, end int, err error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(value), "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid time range: %q (expected HH:MM-HH:MM)", value)
+	}
+	if start, err = parseClock(from); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseClock(to); err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// parseClock parses "HH:MM" into minutes after midnight; "24:00" is midnight
+func parseClock(value string) (int, error) {
+	hourStr, minuteStr, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid time: %q (expected HH:MM)", value)
+	}
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || hour < 0 || hour > 24 {
+		return 0, fmt.Errorf("invalid hour in %q", value)
+	}
+	minute, err := strconv.Atoi(minuteStr)
+	if err != nil || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid minute in %q", value)
+	}
+	return (hour*60 + minute) % (24 * 60), nil
+}
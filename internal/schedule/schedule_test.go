@@ -0,0 +1,170 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a clock fixed at the given local time
+func fakeClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+// at builds a time in UTC; 2025-01-06 is a Monday
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, time.January, 6+day, hour, minute, 0, 0, time.UTC)
+}
+
+func testRules(t *testing.T) []Rule {
+	t.Helper()
+	weekdays, err := ParseDays("mon-fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	workStart, workEnd, _ := ParseTimeRange("09:00-17:00")
+	nightStart, nightEnd, _ := ParseTimeRange("22:00-07:00")
+	quietStart, quietEnd, _ := ParseTimeRange("01:00-05:00")
+
+	return []Rule{
+		{Name: "work", Days: weekdays, Start: workStart, End: workEnd, Override: Override{Timeout: 15 * time.Minute}},
+		{Name: "night", Days: AllDays, Start: nightStart, End: nightEnd, Override: Override{Effect: "rain", Theme: "nord"}},
+		{Name: "quiet", Days: AllDays, Start: quietStart, End: quietEnd, Override: Override{Power: PowerOff}},
+	}
+}
+
+// TestScheduleCurrent tests rule evaluation with an injected clock
+func TestScheduleCurrent(t *testing.T) {
+	rules := testRules(t)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		names    []string
+		override Override
+	}{
+		{"Monday morning before work", at(0, 8, 59), nil, Override{}},
+		{"Monday work hours", at(0, 9, 0), []string{"work"}, Override{Timeout: 15 * time.Minute}},
+		{"Monday work ends", at(0, 17, 0), nil, Override{}},
+		{"Saturday midday", at(5, 12, 0), nil, Override{}},
+		{"Monday night", at(0, 23, 30), []string{"night"}, Override{Effect: "rain", Theme: "nord"}},
+		{"Night continues after midnight", at(1, 0, 30), []string{"night"}, Override{Effect: "rain", Theme: "nord"}},
+		{"Quiet hours merge over night", at(1, 2, 0), []string{"night", "quiet"}, Override{Effect: "rain", Theme: "nord", Power: PowerOff}},
+		{"Night ends at 07:00", at(1, 7, 0), nil, Override{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewWithClock(rules, fakeClock(tt.now))
+			override, names := s.Current()
+			if !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("Current() names = %v, want %v", names, tt.names)
+			}
+			if !reflect.DeepEqual(override, tt.override) {
+				t.Errorf("Current() override = %+v, want %+v", override, tt.override)
+			}
+		})
+	}
+}
+
+// TestScheduleNextBoundary tests boundary calculation for timer re-arming
+func TestScheduleNextBoundary(t *testing.T) {
+	s := NewWithClock(testRules(t), fakeClock(at(0, 8, 0)))
+
+	tests := []struct {
+		now      time.Time
+		expected time.Time
+	}{
+		{at(0, 8, 0), at(0, 9, 0)},
+		{at(0, 9, 0), at(0, 17, 0)},
+		{at(0, 17, 0), at(0, 22, 0)},
+		{at(0, 22, 0), at(1, 1, 0)},
+		{at(1, 1, 0), at(1, 5, 0)},
+		{at(1, 5, 0), at(1, 7, 0)},
+		{at(4, 17, 0), at(4, 22, 0)}, // Friday evening
+		{at(5, 7, 0), at(5, 22, 0)},  // Saturday skips work hours
+	}
+
+	for _, tt := range tests {
+		next, ok := s.NextBoundaryAfter(tt.now)
+		if !ok {
+			t.Fatalf("NextBoundaryAfter(%v) found nothing", tt.now)
+		}
+		if !next.Equal(tt.expected) {
+			t.Errorf("NextBoundaryAfter(%v) = %v, want %v", tt.now, next, tt.expected)
+		}
+	}
+
+	if _, ok := NewWithClock(nil, time.Now).NextBoundary(); ok {
+		t.Error("NextBoundary() on empty schedule should report no boundary")
+	}
+}
+
+// TestParseDays tests weekday list parsing
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []time.Weekday
+		hasError bool
+	}{
+		{"mon-fri", []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, false},
+		{"weekends", []time.Weekday{time.Sunday, time.Saturday}, false},
+		{"fri-mon", []time.Weekday{time.Sunday, time.Monday, time.Friday, time.Saturday}, false},
+		{"Monday, wed", []time.Weekday{time.Monday, time.Wednesday}, false},
+		{"daily", []time.Weekday{0, 1, 2, 3, 4, 5, 6}, false},
+		{"funday", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			days, err := ParseDays(tt.input)
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("ParseDays(%q) expected error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDays(%q) unexpected error: %v", tt.input, err)
+			}
+			var got []time.Weekday
+			for day := time.Sunday; day <= time.Saturday; day++ {
+				if days.Has(day) {
+					got = append(got, day)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ParseDays(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseTimeRange tests HH:MM-HH:MM parsing
+func TestParseTimeRange(t *testing.T) {
+	tests := []struct {
+		input      string
+		start, end int
+		hasError   bool
+	}{
+		{"09:00-17:30", 9 * 60, 17*60 + 30, false},
+		{"22:00-07:00", 22 * 60, 7 * 60, false},
+		{"00:00-24:00", 0, 0, false},
+		{"9-17", 0, 0, true},
+		{"25:00-26:00", 0, 0, true},
+		{"10:60-11:00", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		start, end, err := ParseTimeRange(tt.input)
+		if tt.hasError {
+			if err == nil {
+				t.Errorf("ParseTimeRange(%q) expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil || start != tt.start || end != tt.end {
+			t.Errorf("ParseTimeRange(%q) = %d, %d, %v; want %d, %d", tt.input, start, end, err, tt.start, tt.end)
+		}
+	}
+}
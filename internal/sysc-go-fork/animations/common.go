@@ -0,0 +1,66 @@
+// Package animations provides terminal animation effects for Go applications.
+//
+// sysc-Go includes multiple animation effects with customizable color themes:
+//   - Fire: DOOM PSX-style fire effect
+//   - Matrix: Digital rain with falling character streaks
+//   - Fireworks: Physics-based particle fireworks
+//   - Rain: ASCII character rain effect
+//
+// Basic usage:
+//
+//	palette := animations.GetFirePalette("dracula")
+//	fire := animations.NewFireEffect(80, 24, palette)
+//
+//	for frame := 0; frame < 200; frame++ {
+//	    fire.Update(frame)
+//	    output := fire.Render()
+//	    fmt.Print(output)
+//	}
+//
+// See GUIDE.md for detailed usage examples and integration patterns.
+package animations
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Animation interface that all effects implement
+type Animation interface {
+	// Update advances the animation by one frame
+	Update()
+
+	// Render returns the current frame as a string
+	Render() string
+
+	// Reset restarts the animation from the beginning
+	Reset()
+}
+
+// Config holds common animation settings
+type Config struct {
+	Width  int    // Terminal width in characters
+	Height int    // Terminal height in characters
+	Theme  string // Color theme name
+}
+
+// newRand returns the random source of an effect. A seed of 0 seeds it
+// from the clock; any other seed makes the effect repeat the same run.
+func newRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// sortedKeys returns the keys of a grouping in order, so groups are built
+// the same way on every run
+func sortedKeys(groups map[int][]int) []int {
+	keys := make([]int, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -2,11 +2,16 @@
 package systemd
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
+	"syscall"
 
 	"github.com/Nomadcxx/sysc-walls/internal/config"
 )
@@ -61,6 +66,38 @@ func (s *SystemD) LaunchScreensaver(terminal string, args []string, outputName s
 	return nil
 }
 
+// LaunchOnConsole starts the display directly on a virtual console. The
+// VT becomes the process's stdio and controlling terminal, in a session of
+// its own so console signals don't reach the daemon.
+func (s *SystemD) LaunchOnConsole(binary string, args []string, tty *os.File, outputName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cmd := exec.Command(binary, args...)
+	cmd.Stdin = tty
+	cmd.Stdout = tty
+	cmd.Stderr = tty
+	cmd.Env = append(os.Environ(), "TERM=linux")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start screensaver on %s: %w", outputName, err)
+	}
+
+	process := ScreensaverProcess{
+		PID:    cmd.Process.Pid,
+		Cmd:    cmd,
+		Output: outputName,
+	}
+	s.processes = append(s.processes, process)
+
+	if s.config.IsDebug() {
+		log.Printf("Launched screensaver on %s with PID: %d", outputName, process.PID)
+	}
+
+	return nil
+}
+
 // StopScreensaver stops all screensaver processes
 func (s *SystemD) StopScreensaver() error {
 	s.mu.Lock()
@@ -116,6 +153,75 @@ func (s *SystemD) StopScreensaver() error {
 	return lastError
 }
 
+// PauseScreensaver suspends all screensaver processes, with the displays
+// they run, with SIGSTOP
+// Used while outputs are powered off so nothing renders to a dark screen
+func (s *SystemD) PauseScreensaver() error {
+	return s.signalAll(syscall.SIGSTOP)
+}
+
+// ResumeScreensaver continues screensaver processes suspended by PauseScreensaver
+func (s *SystemD) ResumeScreensaver() error {
+	return s.signalAll(syscall.SIGCONT)
+}
+
+// signalAll sends a signal to every tracked screensaver process and its
+// descendants. Terminals start the display in a session of its own, so
+// signalling the terminal's process group would miss it.
+func (s *SystemD) signalAll(sig syscall.Signal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var lastError error
+	for _, process := range s.processes {
+		for _, pid := range append([]int{process.PID}, descendants(process.PID)...) {
+			if err := syscall.Kill(pid, sig); err != nil {
+				log.Printf("Failed to send %v to PID %d: %v", sig, pid, err)
+				lastError = err
+			}
+		}
+	}
+
+	return lastError
+}
+
+// descendants returns the children of a process and theirs, read from /proc
+func descendants(pid int) []int {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	children := make(map[int][]int)
+	for _, entry := range entries {
+		child, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+		// The parent follows the state, after the command in parentheses
+		fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+		if len(fields) < 2 {
+			continue
+		}
+		if parent, err := strconv.Atoi(fields[1]); err == nil {
+			children[parent] = append(children[parent], child)
+		}
+	}
+
+	var found []int
+	queue := children[pid]
+	for len(queue) > 0 {
+		child := queue[0]
+		queue = queue[1:]
+		found = append(found, child)
+		queue = append(queue, children[child]...)
+	}
+	return found
+}
+
 // IsRunning checks if any screensaver processes are running
 func (s *SystemD) IsRunning() bool {
 	s.mu.Lock()
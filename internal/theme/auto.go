@@ -0,0 +1,193 @@
+// auto.go - The auto theme, following the desktop's color scheme
+package theme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Auto is the theme derived from the desktop's colors
+const Auto = "auto"
+
+// AutoInterval is how often a watched auto theme is derived again
+const AutoInterval = 2 * time.Second
+
+// autoFallback is the built-in theme used until a color scheme appears
+const autoFallback = "rama"
+
+// defaultAccent is the accent of desktops that don't set one, GNOME's blue
+const defaultAccent = "#3584e4"
+
+// xdg-desktop-portal appearance settings on the session bus
+const (
+	portalName       = "org.freedesktop.portal.Desktop"
+	portalPath       = dbus.ObjectPath("/org/freedesktop/portal/desktop")
+	portalSettings   = "org.freedesktop.portal.Settings"
+	portalAppearance = "org.freedesktop.appearance"
+	portalTimeout    = time.Second
+)
+
+// WalPath returns the colors pywal writes, ~/.cache/wal/colors.json
+func WalPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "wal", "colors.json")
+}
+
+// FindAuto derives the auto theme: from a color scheme file (a kitty
+// .conf, base16 .yaml or pywal colors.json, WalPath when source is ""),
+// then from the desktop portal's color scheme and accent color. Without
+// either it has the colors of rama.
+func FindAuto(source string) (*Theme, error) {
+	if source == "" {
+		source = WalPath()
+	}
+	if source != "" {
+		if _, err := os.Stat(source); err == nil {
+			t, err := Import(source, Auto)
+			if err != nil {
+				return nil, err
+			}
+			t.Path = source
+			return t, nil
+		}
+	}
+
+	if light, accent, err := readPortal(); err == nil {
+		return portalTheme(light, accent), nil
+	}
+	return &Theme{Name: Auto, Palette: builtins[autoFallback]}, nil
+}
+
+// WatchAuto derives the auto theme every interval until ctx is done, and
+// sends it each time its colors change. A scheme file caught half written
+// is skipped until it is whole again.
+func WatchAuto(ctx context.Context, source string, interval time.Duration) <-chan *Theme {
+	updates := make(chan *Theme, 1)
+	go func() {
+		last := ""
+		if t, err := FindAuto(source); err == nil {
+			last = Format(t)
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			t, err := FindAuto(source)
+			if err != nil || Format(t) == last {
+				continue
+			}
+			last = Format(t)
+			select {
+			case <-updates: // Replace colors that weren't taken yet
+			default:
+			}
+			updates <- t
+		}
+	}()
+	return updates
+}
+
+// readPortal reads whether the desktop prefers light colors and its
+// accent color. It is a variable so tests can stand in for the portal.
+var readPortal = func() (light bool, accent string, err error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return false, "", err
+	}
+	portal := conn.Object(portalName, portalPath)
+	read := func(key string) (dbus.Variant, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), portalTimeout)
+		defer cancel()
+		var value dbus.Variant
+		if err := portal.CallWithContext(ctx, portalSettings+".ReadOne", 0, portalAppearance, key).Store(&value); err == nil {
+			return value, nil
+		}
+		// Read, from before ReadOne, wraps the value in a second variant
+		err := portal.CallWithContext(ctx, portalSettings+".Read", 0, portalAppearance, key).Store(&value)
+		if inner, ok := value.Value().(dbus.Variant); ok {
+			value = inner
+		}
+		return value, err
+	}
+
+	// 0 is no preference, 1 dark and 2 light
+	scheme, err := read("color-scheme")
+	if err != nil {
+		return false, "", err
+	}
+	if n, ok := scheme.Value().(uint32); ok {
+		light = n == 2
+	}
+
+	// Red, green and blue from 0 to 1, out of range when unset
+	if value, err := read("accent-color"); err == nil {
+		if rgb, ok := value.Value().([]interface{}); ok && len(rgb) == 3 {
+			accent, _ = portalColor(rgb)
+		}
+	}
+	return light, accent, nil
+}
+
+// portalColor converts the portal's red, green and blue to #rrggbb
+func portalColor(rgb []interface{}) (string, error) {
+	var c [3]uint8
+	for i, v := range rgb {
+		f, ok := v.(float64)
+		if !ok || f < 0 || f > 1 {
+			return "", errors.New("accent color unset")
+		}
+		c[i] = uint8(f*255 + 0.5)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", c[0], c[1], c[2]), nil
+}
+
+// portalTheme builds a theme from a light or dark preference and an accent
+// color. The palette runs from the background through the accent to the
+// foreground.
+func portalTheme(light bool, accent string) *Theme {
+	if accent == "" {
+		accent = defaultAccent
+	}
+	bg, fg := "#101014", "#eeeeec"
+	if light {
+		bg, fg = "#f6f5f4", "#241f31"
+	}
+
+	t := &Theme{Name: Auto, Roles: map[string][]string{
+		RoleBackground: {bg},
+		RoleForeground: {fg},
+		RoleAccent:     {accent, mix(accent, fg, 0.5)},
+	}}
+	t.Palette = []string{bg}
+	for _, f := range []float64{0.2, 0.4, 0.6, 0.8} {
+		t.Palette = append(t.Palette, mix(bg, accent, f))
+	}
+	t.Palette = append(t.Palette, accent, mix(accent, fg, 0.33), mix(accent, fg, 0.66), fg)
+	t.Gradient = []string{mix(bg, accent, 0.4), accent, mix(accent, fg, 0.5)}
+	return t
+}
+
+// mix blends two #rrggbb colors, f of the way from a to b
+func mix(a, b string, f float64) string {
+	var ca, cb [3]uint8
+	fmt.Sscanf(a, "#%02x%02x%02x", &ca[0], &ca[1], &ca[2])
+	fmt.Sscanf(b, "#%02x%02x%02x", &cb[0], &cb[1], &cb[2])
+	var out [3]uint8
+	for i := range out {
+		out[i] = uint8(float64(ca[i]) + (float64(cb[i])-float64(ca[i]))*f + 0.5)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", out[0], out[1], out[2])
+}
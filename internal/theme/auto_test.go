@@ -0,0 +1,95 @@
+package theme
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// stubPortal stands in for the desktop portal during a test
+func stubPortal(t *testing.T, light bool, accent string, err error) {
+	saved := readPortal
+	readPortal = func() (bool, string, error) { return light, accent, err }
+	t.Cleanup(func() { readPortal = saved })
+}
+
+// TestFindAuto tests the sources of the auto theme in order
+func TestFindAuto(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+
+	// Nothing to follow yet
+	stubPortal(t, false, "", errors.New("no portal"))
+	th, err := Find(Auto)
+	if err != nil || th.Palette[0] != builtins[autoFallback][0] {
+		t.Errorf("fallback = %+v, %v", th, err)
+	}
+
+	// The portal's preference and accent
+	stubPortal(t, true, "#ff0000", nil)
+	th, _ = FindAuto("")
+	if th.Color(RoleBackground) != "#f6f5f4" || th.Color(RoleAccent) != "#ff0000" || th.Palette[5] != "#ff0000" {
+		t.Errorf("portal theme = %+v", th)
+	}
+	if got := mix("#000000", "#ff8040", 0.5); got != "#804020" {
+		t.Errorf("mix = %s", got)
+	}
+	if got, err := portalColor([]interface{}{1.0, 0.5, 0.0}); err != nil || got != "#ff8000" {
+		t.Errorf("portalColor = %s, %v", got, err)
+	}
+	if _, err := portalColor([]interface{}{-1.0, -1.0, -1.0}); err == nil {
+		t.Error("unset accent converted")
+	}
+
+	// pywal's colors win over the portal
+	wal := WalPath()
+	os.MkdirAll(filepath.Dir(wal), 0755)
+	os.WriteFile(wal, []byte(`{"special": {"background": "#0a0b0c", "foreground": "#fafafa"}, "colors": {"color1": "#aa0000"}}`), 0644)
+	th, err = Find(Auto)
+	if err != nil || th.Name != Auto || th.Path != wal || th.Palette[0] != "#0a0b0c" {
+		t.Errorf("pywal theme = %+v, %v", th, err)
+	}
+
+	// As does a scheme file given as the source
+	kitty := filepath.Join(home, "kitty.conf")
+	os.WriteFile(kitty, []byte("background #123456\ncolor1 #654321\n"), 0644)
+	if th, err := FindAuto(kitty); err != nil || th.Palette[0] != "#123456" {
+		t.Errorf("kitty theme = %+v, %v", th, err)
+	}
+}
+
+// TestWatchAuto tests that changed colors are sent to a running display
+func TestWatchAuto(t *testing.T) {
+	stubPortal(t, false, "", errors.New("no portal"))
+	source := filepath.Join(t.TempDir(), "colors.json")
+	write := func(bg string) {
+		os.WriteFile(source, []byte(`{"special": {"background": "`+bg+`", "foreground": "#ffffff"}}`), 0644)
+	}
+	write("#000000")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := WatchAuto(ctx, source, 10*time.Millisecond)
+	select {
+	case th := <-updates:
+		t.Fatalf("unchanged colors sent: %v", th.Palette)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// A half written file is skipped
+	os.WriteFile(source, []byte(`{"special": {"backgr`), 0644)
+	time.Sleep(50 * time.Millisecond)
+	write("#222222")
+	select {
+	case th := <-updates:
+		if th.Palette[0] != "#222222" {
+			t.Errorf("palette = %v", th.Palette)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("changed colors weren't sent")
+	}
+}
@@ -0,0 +1,187 @@
+// import.go - Themes from kitty, base16 and pywal color schemes
+package theme
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ansiRoles are the terminal colors each role is taken from, by number
+var ansiRoles = map[string][]int{
+	RoleAccent:  {4, 5, 6},
+	RoleFish:    {1, 3, 5},
+	RoleWater:   {4, 6, 12},
+	RoleSeaweed: {2, 10},
+	RoleBubble:  {14},
+	RoleDiver:   {3},
+	RoleBoat:    {1},
+	RoleMermaid: {13},
+	RoleAnchor:  {4},
+}
+
+// base16ANSI are the base16 colors of the 16 terminal colors, as
+// base16-shell sets them
+var base16ANSI = [16]string{
+	"base00", "base08", "base0B", "base0A", "base0D", "base0E", "base0C", "base05",
+	"base03", "base08", "base0B", "base0A", "base0D", "base0E", "base0C", "base07",
+}
+
+// Import reads a kitty .conf, a base16 .yaml or a pywal colors.json as a
+// theme, named after the file unless a name is given. Roles the scheme
+// has no colors for are left unset, for Missing to report.
+func Import(path, name string) (*Theme, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	var (
+		t   *Theme
+		err error
+	)
+	switch ext {
+	case ".json":
+		t, err = importPywal(name, path)
+	case ".yaml", ".yml":
+		t, err = importBase16(name, path)
+	case ".conf":
+		t, err = importKitty(name, path)
+	default:
+		return nil, fmt.Errorf("%s: unknown scheme format (use a kitty .conf, base16 .yaml or pywal .json)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// importKitty reads the color settings of a kitty config
+func importKitty(name, path string) (*Theme, error) {
+	settings, err := readPairs(path, " \t")
+	if err != nil {
+		return nil, err
+	}
+	var ansi [16]string
+	for i := range ansi {
+		ansi[i] = settings["color"+strconv.Itoa(i)]
+	}
+	return ansiTheme(name, settings["background"], settings["foreground"], ansi)
+}
+
+// importBase16 reads the baseXX colors of a base16 scheme, at the top
+// level or under palette:
+func importBase16(name, path string) (*Theme, error) {
+	settings, err := readPairs(path, ":")
+	if err != nil {
+		return nil, err
+	}
+	var ansi [16]string
+	for i, base := range base16ANSI {
+		ansi[i] = settings[base]
+	}
+	return ansiTheme(name, settings["base00"], settings["base05"], ansi)
+}
+
+// importPywal reads the colors.json pywal writes to ~/.cache/wal
+func importPywal(name, path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var scheme struct {
+		Special map[string]string `json:"special"`
+		Colors  map[string]string `json:"colors"`
+	}
+	if err := json.Unmarshal(data, &scheme); err != nil {
+		return nil, err
+	}
+	var ansi [16]string
+	for i := range ansi {
+		ansi[i] = scheme.Colors["color"+strconv.Itoa(i)]
+	}
+	return ansiTheme(name, scheme.Special["background"], scheme.Special["foreground"], ansi)
+}
+
+// readPairs reads the key and first value of each line of a scheme,
+// splitting at sep. Values are unquoted and keys are kept as written,
+// whatever their indentation.
+func readPairs(path, sep string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pairs := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.IndexAny(line, sep)
+		if i < 0 {
+			continue
+		}
+		fields := strings.Fields(line[i+1:])
+		if len(fields) == 0 {
+			continue
+		}
+		pairs[strings.TrimSpace(line[:i])] = strings.Trim(fields[0], `"'`)
+	}
+	return pairs, scanner.Err()
+}
+
+// ansiTheme builds a theme from a terminal's background, foreground and 16
+// colors. The palette runs from the background through the colors to the
+// foreground, skipping repeats.
+func ansiTheme(name, background, foreground string, ansi [16]string) (*Theme, error) {
+	var err error
+	color := func(s string) string {
+		if s == "" || err != nil {
+			return ""
+		}
+		var c string
+		c, err = ParseColor(s)
+		return c
+	}
+	bg, fg := color(background), color(foreground)
+	var colors [16]string
+	for i, s := range ansi {
+		colors[i] = color(s)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Theme{Name: name, Roles: make(map[string][]string)}
+	seen := make(map[string]bool)
+	for _, c := range append(append([]string{bg}, colors[1:7]...), append(colors[9:15], fg)...) {
+		if c != "" && !seen[c] {
+			t.Palette = append(t.Palette, c)
+			seen[c] = true
+		}
+	}
+	if bg != "" {
+		t.Roles[RoleBackground] = []string{bg}
+	}
+	if fg != "" {
+		t.Roles[RoleForeground] = []string{fg}
+	}
+	for role, numbers := range ansiRoles {
+		for _, n := range numbers {
+			if colors[n] != "" {
+				t.Roles[role] = append(t.Roles[role], colors[n])
+			}
+		}
+	}
+	return t, t.Validate()
+}
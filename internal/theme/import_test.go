@@ -0,0 +1,109 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestImport tests importing kitty, base16 and pywal schemes
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	kitty := write("dracula.conf", `# Dracula
+foreground #f8f8f2
+background #282a36
+cursor     #bbbbbb
+color0  #21222c
+color1  #ff5555
+color2  #50fa7b
+color3  #f1fa8c
+color4  #bd93f9
+color5  #ff79c6
+color6  #8be9fd
+color7  #f8f8f2
+color8  #6272a4
+color9  #ff6e6e
+color10 #69ff94
+color11 #ffffa5
+color12 #d6acff
+color13 #ff92df
+color14 #a4ffff
+color15 #ffffff
+`)
+	base16 := write("ocean.yaml", `scheme: "Ocean"
+author: "Someone"
+palette:
+  base00: "2b303b" # Background
+  base01: "343d46"
+  base02: "4f5b66"
+  base03: "65737e"
+  base04: "a7adba"
+  base05: "c0c5ce"
+  base06: "dfe1e8"
+  base07: "eff1f5"
+  base08: "bf616a"
+  base09: "d08770"
+  base0A: "ebcb8b"
+  base0B: "a3be8c"
+  base0C: "96b5b4"
+  base0D: "8fa1b3"
+  base0E: "b48ead"
+  base0F: "ab7967"
+`)
+	pywal := write("colors.json", `{
+  "wallpaper": "/home/me/wall.png",
+  "special": {"background": "#1d1f21", "foreground": "#c5c8c6", "cursor": "#c5c8c6"},
+  "colors": {"color0": "#1d1f21", "color1": "#cc6666", "color2": "#b5bd68", "color3": "#f0c674"}
+}`)
+
+	th, err := Import(kitty, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Name != "dracula" || len(th.Missing()) != 0 {
+		t.Errorf("kitty theme %q missing %v", th.Name, th.Missing())
+	}
+	if th.Palette[0] != "#282a36" || th.Palette[len(th.Palette)-1] != "#f8f8f2" {
+		t.Errorf("kitty palette = %v", th.Palette)
+	}
+	if got := th.Role(RoleFish); !reflect.DeepEqual(got, []string{"#ff5555", "#f1fa8c", "#ff79c6"}) {
+		t.Errorf("kitty fish = %v", got)
+	}
+
+	th, err = Import(base16, "sea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Name != "sea" || th.Color(RoleBackground) != "#2b303b" || th.Color(RoleBubble) != "#96b5b4" || len(th.Missing()) != 0 {
+		t.Errorf("base16 theme = %+v", th)
+	}
+
+	// Schemes without all 16 colors leave roles unset
+	th, err = Import(pywal, "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := th.Missing(); !reflect.DeepEqual(got, []string{RoleAccent, RoleWater, RoleBubble, RoleMermaid, RoleAnchor}) {
+		t.Errorf("pywal missing = %v", got)
+	}
+	if got := th.Role(RoleWater); !reflect.DeepEqual(got, []string{"#f0c674", "#c5c8c6"}) {
+		t.Errorf("pywal water from the palette = %v", got)
+	}
+
+	bad := write("bad.conf", "background nope\ncolor1 #ff0000\n")
+	if _, err := Import(bad, ""); err == nil {
+		t.Error("importing an invalid color succeeded")
+	}
+	if _, err := Import(write("scheme.txt", ""), ""); err == nil {
+		t.Error("importing an unknown format succeeded")
+	}
+}
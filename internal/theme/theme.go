@@ -0,0 +1,375 @@
+// theme.go - Color themes: the built-in palettes and theme files
+package theme
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	syscGo "github.com/Nomadcxx/sysc-walls/internal/sysc-go-fork/animations"
+)
+
+// Roles name the colors effects draw particular things in
+const (
+	RoleBackground = "background" // The color the theme is drawn over
+	RoleForeground = "foreground" // Plain text, and the blackhole's core
+	RoleAccent     = "accent"     // The rings of ring-text
+	RoleFish       = "fish"       // Aquarium fish
+	RoleWater      = "water"      // Aquarium water
+	RoleSeaweed    = "seaweed"    // Aquarium seaweed
+	RoleBubble     = "bubble"     // Aquarium bubbles
+	RoleDiver      = "diver"      // The aquarium's diver
+	RoleBoat       = "boat"       // The aquarium's boat
+	RoleMermaid    = "mermaid"    // The aquarium's mermaid
+	RoleAnchor     = "anchor"     // The aquarium's anchor
+)
+
+// Roles lists the roles a theme can set, in the order theme files list them
+var Roles = []string{
+	RoleBackground, RoleForeground, RoleAccent,
+	RoleFish, RoleWater, RoleSeaweed, RoleBubble, RoleDiver, RoleBoat, RoleMermaid, RoleAnchor,
+}
+
+// Extension is the file extension of theme files
+const Extension = ".theme"
+
+// Theme is a palette for effects, the stops of their gradients and the
+// colors of named roles
+type Theme struct {
+	Name     string
+	Palette  []string            // Colors as #rrggbb, for effects that take a palette
+	Gradient []string            // Gradient stops, empty to use the start of the palette
+	Roles    map[string][]string // Roles the theme sets
+	Path     string              // File the theme was read from, "" when built in
+}
+
+// Role returns the colors of a role. Roles the theme doesn't set are taken
+// from the palette, or are the colors the aquarium has always used.
+func (t *Theme) Role(role string) []string {
+	if colors := t.Roles[role]; len(colors) > 0 {
+		return colors
+	}
+
+	p := t.Palette
+	switch role {
+	case RoleBackground:
+		return p[:min(len(p), 1)]
+	case RoleForeground:
+		return []string{"#ffffff"}
+	case RoleAccent, RoleFish:
+		return p[:min(len(p), 3)]
+	case RoleWater:
+		if len(p) > 3 {
+			return p[3:min(len(p), 6)]
+		}
+		return []string{"#2e3440", "#3b4252", "#434c5e"}
+	case RoleSeaweed:
+		if len(p) > 6 {
+			return p[6:min(len(p), 8)]
+		}
+		return []string{"#a3be8c", "#8fbcbb"}
+	case RoleBubble:
+		return []string{"#88c0d0"}
+	case RoleDiver:
+		return []string{"#d08770"}
+	case RoleBoat:
+		return []string{"#bf616a"}
+	case RoleMermaid:
+		return []string{"#b48ead"}
+	case RoleAnchor:
+		return []string{"#5e81ac"}
+	}
+	return nil
+}
+
+// Color returns the first color of a role
+func (t *Theme) Color(role string) string {
+	if colors := t.Role(role); len(colors) > 0 {
+		return colors[0]
+	}
+	return "#ffffff"
+}
+
+// Stops returns the theme's gradient, or the first n colors of its palette
+// when it has none
+func (t *Theme) Stops(n int) []string {
+	if len(t.Gradient) > 0 {
+		return t.Gradient
+	}
+	return t.Palette[:min(len(t.Palette), n)]
+}
+
+// Missing returns the roles the theme doesn't set, in the order of Roles
+func (t *Theme) Missing() []string {
+	var missing []string
+	for _, role := range Roles {
+		if len(t.Roles[role]) == 0 {
+			missing = append(missing, role)
+		}
+	}
+	return missing
+}
+
+// Validate checks that the theme has a palette and that its colors and
+// roles are valid
+func (t *Theme) Validate() error {
+	if len(t.Palette) == 0 {
+		return fmt.Errorf("theme %q has no palette", t.Name)
+	}
+	for _, list := range [][]string{t.Palette, t.Gradient} {
+		for _, c := range list {
+			if _, err := ParseColor(c); err != nil {
+				return fmt.Errorf("theme %q: %v", t.Name, err)
+			}
+		}
+	}
+	for role, colors := range t.Roles {
+		if !isRole(role) {
+			return fmt.Errorf("theme %q: unknown role %q (roles: %s)", t.Name, role, strings.Join(Roles, ", "))
+		}
+		for _, c := range colors {
+			if _, err := ParseColor(c); err != nil {
+				return fmt.Errorf("theme %q: role %s: %v", t.Name, role, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isRole reports whether role is one of Roles
+func isRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseColor normalizes a #rrggbb or rrggbb color to lower case #rrggbb
+func ParseColor(s string) (string, error) {
+	hex := strings.ToLower(strings.TrimPrefix(s, "#"))
+	if len(hex) != 6 || strings.Trim(hex, "0123456789abcdef") != "" {
+		return "", fmt.Errorf("invalid color %q (use #rrggbb)", s)
+	}
+	return "#" + hex, nil
+}
+
+// Parse reads a theme file. Colors are separated by spaces or commas:
+//
+//	[theme]
+//	palette = #0b1d2a #12324a #f4a261
+//	gradient = #12324a #2a9d8f
+//
+//	[roles]
+//	fish = #f4a261 #e76f51
+func Parse(name string, r io.Reader) (*Theme, error) {
+	t := &Theme{Name: name, Roles: make(map[string][]string)}
+	section := ""
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section != "theme" && section != "roles" {
+				return nil, fmt.Errorf("line %d: unknown section [%s] (use [theme] or [roles])", lineNum, section)
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNum)
+		}
+		key = strings.TrimSpace(key)
+		var colors []string
+		for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			c, err := ParseColor(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNum, err)
+			}
+			colors = append(colors, c)
+		}
+
+		switch {
+		case section == "theme" && key == "palette":
+			t.Palette = colors
+		case section == "theme" && key == "gradient":
+			t.Gradient = colors
+		case section == "roles" && isRole(key):
+			t.Roles[key] = colors
+		case section == "roles":
+			return nil, fmt.Errorf("line %d: unknown role %q (roles: %s)", lineNum, key, strings.Join(Roles, ", "))
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q (use palette or gradient under [theme])", lineNum, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, t.Validate()
+}
+
+// Format writes a theme as a theme file, listing every role and leaving
+// the ones the theme doesn't set commented out
+func Format(t *Theme) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# sysc-walls theme %s\n", t.Name)
+	b.WriteString("[theme]\n")
+	fmt.Fprintf(&b, "palette = %s\n", strings.Join(t.Palette, " "))
+	if len(t.Gradient) > 0 {
+		fmt.Fprintf(&b, "gradient = %s\n", strings.Join(t.Gradient, " "))
+	} else {
+		b.WriteString("# gradient = (the start of the palette)\n")
+	}
+	b.WriteString("\n[roles]\n")
+	for _, role := range Roles {
+		if colors := t.Roles[role]; len(colors) > 0 {
+			fmt.Fprintf(&b, "%s = %s\n", role, strings.Join(colors, " "))
+		} else {
+			fmt.Fprintf(&b, "# %s = %s\n", role, strings.Join(t.Role(role), " "))
+		}
+	}
+	return b.String()
+}
+
+// LoadFile reads a theme file, named after the file
+func LoadFile(path string) (*Theme, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t, err := Parse(strings.TrimSuffix(filepath.Base(path), Extension), f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	t.Path = path
+	return t, nil
+}
+
+// UserDir returns the user's theme directory, ~/.config/sysc-walls/themes
+func UserDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "sysc-walls", "themes")
+}
+
+// Find returns a theme by name: the auto theme, a theme file in the
+// user's theme directory, which may replace a built-in theme, then a
+// built-in theme by its sysc-Go name or alias
+func Find(name string) (*Theme, error) {
+	if name == Auto {
+		return FindAuto("")
+	}
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	if dir := UserDir(); dir != "" {
+		path := filepath.Join(dir, name+Extension)
+		if _, err := os.Stat(path); err == nil {
+			return LoadFile(path)
+		}
+	}
+
+	palette, ok := builtins[name]
+	if !ok {
+		if meta := syscGo.GetThemeMetadata(name); meta != nil {
+			palette, ok = builtins[meta.Name]
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q (themes: %s)", name, strings.Join(Names(), ", "))
+	}
+	return &Theme{Name: name, Palette: palette}, nil
+}
+
+// Files returns the theme files in the user's theme directory, in name order
+func Files() ([]string, error) {
+	dir := UserDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && filepath.Ext(name) == Extension && checkName(strings.TrimSuffix(name, Extension)) == nil {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Names returns the built-in theme names and aliases, auto, then the names
+// of theme files that don't replace a built-in theme
+func Names() []string {
+	names := append(syscGo.GetThemeNames(), Auto)
+	seen := make(map[string]bool)
+	for _, name := range names {
+		seen[name] = true
+	}
+	files, _ := Files()
+	for _, path := range files {
+		if name := strings.TrimSuffix(filepath.Base(path), Extension); !seen[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Install writes a theme to the user's theme directory. Existing themes
+// are not replaced.
+func Install(t *Theme) (string, error) {
+	if err := checkName(t.Name); err != nil {
+		return "", err
+	}
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+	dir := UserDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	dst := filepath.Join(dir, t.Name+Extension)
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return "", fmt.Errorf("theme %q already exists, remove it first", t.Name)
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(Format(t)); err != nil {
+		f.Close()
+		os.Remove(dst)
+		return "", err
+	}
+	return dst, f.Close()
+}
+
+// checkName allows names of letters, digits, hyphens and underscores, so
+// theme names stay safe to pass to the display
+func checkName(name string) error {
+	if name == "" {
+		return fmt.Errorf("invalid theme name %q", name)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("invalid theme name %q (use letters, digits, - and _)", name)
+		}
+	}
+	return nil
+}
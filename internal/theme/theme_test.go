@@ -0,0 +1,160 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	syscGo "github.com/Nomadcxx/sysc-walls/internal/sysc-go-fork/animations"
+)
+
+// TestFindBuiltin tests that every theme sysc-Go lists has a palette
+func TestFindBuiltin(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, name := range syscGo.GetThemeNames() {
+		th, err := Find(name)
+		if err != nil {
+			t.Errorf("Find(%q) error = %v", name, err)
+			continue
+		}
+		if len(th.Palette) == 0 || th.Path != "" {
+			t.Errorf("Find(%q) = %+v", name, th)
+		}
+	}
+
+	// Aliases share the palette of their theme
+	tokyo, _ := Find("tokyo-night")
+	alias, _ := Find("tokyonight")
+	if !reflect.DeepEqual(tokyo.Palette, alias.Palette) {
+		t.Error("tokyonight has a different palette from tokyo-night")
+	}
+
+	for _, name := range []string{"invalid-theme", "../nord", ""} {
+		if _, err := Find(name); err == nil {
+			t.Errorf("Find(%q) succeeded", name)
+		}
+	}
+}
+
+// TestRoles tests roles taken from the palette and set by the theme
+func TestRoles(t *testing.T) {
+	th := &Theme{Name: "nord", Palette: builtins["nord"]}
+	if got := th.Role(RoleFish); !reflect.DeepEqual(got, []string{"#2e3440", "#3b4252", "#434c5e"}) {
+		t.Errorf("fish = %v", got)
+	}
+	if got := th.Role(RoleSeaweed); !reflect.DeepEqual(got, []string{"#eceff4", "#8fbcbb"}) {
+		t.Errorf("seaweed = %v", got)
+	}
+	if got := th.Color(RoleBubble); got != "#88c0d0" {
+		t.Errorf("bubble = %s", got)
+	}
+	if got := th.Stops(5); len(got) != 5 {
+		t.Errorf("stops = %v", got)
+	}
+	if len(th.Missing()) != len(Roles) {
+		t.Errorf("missing = %v", th.Missing())
+	}
+
+	short := &Theme{Name: "short", Palette: []string{"#000000", "#ffffff"}, Gradient: []string{"#ff0000", "#00ff00"},
+		Roles: map[string][]string{RoleWater: {"#0000ff"}}}
+	if got := short.Role(RoleWater); !reflect.DeepEqual(got, []string{"#0000ff"}) {
+		t.Errorf("water = %v", got)
+	}
+	if got := short.Role(RoleSeaweed); !reflect.DeepEqual(got, []string{"#a3be8c", "#8fbcbb"}) {
+		t.Errorf("seaweed of a short palette = %v", got)
+	}
+	if got := short.Stops(5); !reflect.DeepEqual(got, short.Gradient) {
+		t.Errorf("stops = %v", got)
+	}
+}
+
+// TestParse tests reading theme files and their errors
+func TestParse(t *testing.T) {
+	th, err := Parse("ocean", strings.NewReader(`# Deep water
+[theme]
+palette = #0B1D2A, #12324a 2a9d8f
+gradient = #12324a #2a9d8f
+
+[roles]
+fish = #f4a261 #e76f51
+water = #0b1d2a
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(th.Palette, []string{"#0b1d2a", "#12324a", "#2a9d8f"}) {
+		t.Errorf("palette = %v", th.Palette)
+	}
+	if !reflect.DeepEqual(th.Role(RoleFish), []string{"#f4a261", "#e76f51"}) {
+		t.Errorf("fish = %v", th.Role(RoleFish))
+	}
+	if missing := th.Missing(); len(missing) != len(Roles)-2 || missing[0] != RoleBackground {
+		t.Errorf("missing = %v", missing)
+	}
+
+	// Formatting and parsing again keeps the theme
+	again, err := Parse("ocean", strings.NewReader(Format(th)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again, th) {
+		t.Errorf("formatted theme parsed as %+v", again)
+	}
+
+	for _, bad := range []string{
+		"[theme]\npalette = #12345",
+		"[theme]\ncolors = #123456",
+		"[roles]\nshark = #123456",
+		"[colors]",
+		"[theme]\npalette",
+		"[roles]\nfish = #123456",
+	} {
+		if _, err := Parse("bad", strings.NewReader(bad)); err == nil {
+			t.Errorf("Parse(%q) succeeded", bad)
+		}
+	}
+}
+
+// TestUserThemes tests finding, listing and installing theme files
+func TestUserThemes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", "sysc-walls", "themes")
+
+	ocean := &Theme{Name: "ocean", Palette: []string{"#0b1d2a", "#2a9d8f"}, Roles: map[string][]string{}}
+	path, err := Install(ocean)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, "ocean.theme") {
+		t.Errorf("installed at %s", path)
+	}
+	if _, err := Install(ocean); err == nil {
+		t.Error("Install replaced an existing theme")
+	}
+
+	th, err := Find("ocean")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Path != path || !reflect.DeepEqual(th.Palette, ocean.Palette) {
+		t.Errorf("Find(ocean) = %+v", th)
+	}
+	if names := Names(); names[len(names)-1] != "ocean" {
+		t.Errorf("Names() = %v", names)
+	}
+
+	// A theme file replaces the built-in theme of its name
+	os.WriteFile(filepath.Join(dir, "nord.theme"), []byte("[theme]\npalette = #ff0000\n"), 0644)
+	if th, err := Find("nord"); err != nil || th.Palette[0] != "#ff0000" {
+		t.Errorf("Find(nord) = %+v, %v", th, err)
+	}
+
+	// Broken files are errors, not the fallback theme
+	os.WriteFile(filepath.Join(dir, "broken.theme"), []byte("[theme]\npalette = red\n"), 0644)
+	if _, err := Find("broken"); err == nil || !strings.Contains(err.Error(), "broken.theme") {
+		t.Errorf("Find(broken) error = %v", err)
+	}
+}
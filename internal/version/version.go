@@ -8,11 +8,11 @@ const (
 	// Name is the project name
 	Name = "sysc-walls"
 
-	// SyscGoVersion is the minimum required sysc-Go version
-	SyscGoVersion = "v1.0.1"
+	// SyscGoVersion is the sysc-Go release the bundled fork is based on
+	SyscGoVersion = "v1.0.2"
 )
 
 // GetFullVersion returns version information for sysc-walls
 func GetFullVersion() string {
-	return Name + " " + Version + " (requires sysc-Go " + SyscGoVersion + "+)"
+	return Name + " " + Version + " (bundles a fork of sysc-Go " + SyscGoVersion + ")"
 }
@@ -0,0 +1,235 @@
+// vt.go - Linux virtual console switching
+package vt
+
+import (
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Console ioctls from linux/vt.h
+const (
+	vtOpenQry   = 0x5600 // First free VT
+	vtGetState  = 0x5603 // Active VT
+	vtActivate  = 0x5606 // Switch to a VT
+	vtWaitActiv = 0x5607 // Wait until a VT is active
+)
+
+// vtStat mirrors struct vt_stat
+type vtStat struct {
+	active uint16
+	signal uint16
+	state  uint16
+}
+
+// device performs the VT ioctls, so tests can run without a console
+type device interface {
+	Active() (int, error)
+	Free() (int, error)
+	Activate(n int) error
+	Close() error
+}
+
+// ttyDevice issues VT ioctls on a console file descriptor
+type ttyDevice struct {
+	f *os.File
+}
+
+// Active returns the number of the VT on screen
+func (t ttyDevice) Active() (int, error) {
+	var st vtStat
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, t.f.Fd(), vtGetState, uintptr(unsafe.Pointer(&st)))
+	if errno != 0 {
+		return 0, fmt.Errorf("VT_GETSTATE: %w", errno)
+	}
+	return int(st.active), nil
+}
+
+// Free returns the first VT nobody has open
+func (t ttyDevice) Free() (int, error) {
+	n, err := unix.IoctlGetInt(int(t.f.Fd()), vtOpenQry)
+	if err != nil {
+		return 0, fmt.Errorf("VT_OPENQRY: %w", err)
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("no free virtual console")
+	}
+	return n, nil
+}
+
+// Activate switches to VT n and waits for the switch to complete
+func (t ttyDevice) Activate(n int) error {
+	fd := int(t.f.Fd())
+	if err := unix.IoctlSetInt(fd, vtActivate, n); err != nil {
+		return fmt.Errorf("VT_ACTIVATE %d: %w", n, err)
+	}
+	if err := unix.IoctlSetInt(fd, vtWaitActiv, n); err != nil {
+		return fmt.Errorf("VT_WAITACTIVE %d: %w", n, err)
+	}
+	return nil
+}
+
+// Close closes the console file descriptor
+func (t ttyDevice) Close() error {
+	return t.f.Close()
+}
+
+// Console runs the screensaver on its own virtual console. Switch brings
+// that VT to the front and Restore returns to the one the user was on.
+type Console struct {
+	dev   device
+	vt    int // Configured VT, 0 to pick a free one on each switch
+	prev  int // VT to return to, 0 while not switched
+	tty   *os.File
+	saved *unix.Termios // Terminal settings to restore, nil if unchanged
+
+	// openTTY opens /dev/ttyN in raw mode and returns its previous settings
+	openTTY func(n int) (*os.File, *unix.Termios, error)
+}
+
+// Open opens the console for VT switching. n is the VT to draw on, or 0
+// to use the first free one. Switching needs either root, membership of
+// the tty group, or a session that owns the active console.
+func Open(n int) (*Console, error) {
+	if n < 0 || n > 63 {
+		return nil, fmt.Errorf("invalid virtual console %d (must be 1-63, or 0 for the first free one)", n)
+	}
+
+	var f *os.File
+	var err error
+	for _, path := range []string{"/dev/tty0", "/dev/console"} {
+		if f, err = os.OpenFile(path, os.O_WRONLY|unix.O_NOCTTY, 0); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open console: %w", err)
+	}
+
+	dev := ttyDevice{f: f}
+	if _, err := dev.Active(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("not a virtual console: %w", err)
+	}
+
+	return newConsole(dev, n), nil
+}
+
+// newConsole creates a console on top of dev
+func newConsole(dev device, n int) *Console {
+	return &Console{dev: dev, vt: n, openTTY: openRaw}
+}
+
+// Path returns the device path of VT n
+func Path(n int) string {
+	return fmt.Sprintf("/dev/tty%d", n)
+}
+
+// Switch opens the screensaver VT in raw mode and brings it to the front.
+// The returned file is the VT's terminal, for the display's stdio. It stays
+// valid until Restore.
+func (c *Console) Switch() (*os.File, error) {
+	if c.tty != nil {
+		return c.tty, nil
+	}
+
+	prev, err := c.dev.Active()
+	if err != nil {
+		return nil, err
+	}
+
+	n := c.vt
+	if n == 0 {
+		if n, err = c.dev.Free(); err != nil {
+			return nil, err
+		}
+	}
+
+	tty, saved, err := c.openTTY(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if n != prev {
+		if err := c.dev.Activate(n); err != nil {
+			restoreTTY(tty, saved)
+			tty.Close()
+			return nil, err
+		}
+	}
+
+	c.tty, c.saved, c.prev = tty, saved, prev
+	return tty, nil
+}
+
+// Restore clears the screensaver VT, restores its terminal settings and
+// switches back to the VT that was active before Switch
+func (c *Console) Restore() error {
+	if c.tty == nil {
+		return nil
+	}
+
+	// Leave the VT as we found it in case someone switches to it by hand
+	c.tty.WriteString("\x1b[0m\x1b[2J\x1b[H\x1b[?25h")
+	restoreTTY(c.tty, c.saved)
+	c.tty.Close()
+	c.tty, c.saved = nil, nil
+
+	prev := c.prev
+	c.prev = 0
+	if active, err := c.dev.Active(); err == nil && active == prev {
+		return nil
+	}
+	return c.dev.Activate(prev)
+}
+
+// Close switches back if needed and releases the console
+func (c *Console) Close() error {
+	err := c.Restore()
+	if cerr := c.dev.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// openRaw opens VT n and puts it in raw mode
+func openRaw(n int) (*os.File, *unix.Termios, error) {
+	tty, err := os.OpenFile(Path(n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open virtual console: %w", err)
+	}
+
+	saved, err := unix.IoctlGetTermios(int(tty.Fd()), unix.TCGETS)
+	if err != nil {
+		tty.Close()
+		return nil, nil, fmt.Errorf("failed to read terminal settings of %s: %w", Path(n), err)
+	}
+	raw := *saved
+	makeRaw(&raw)
+	if err := unix.IoctlSetTermios(int(tty.Fd()), unix.TCSETS, &raw); err != nil {
+		tty.Close()
+		return nil, nil, fmt.Errorf("failed to set raw mode on %s: %w", Path(n), err)
+	}
+	return tty, saved, nil
+}
+
+// makeRaw changes terminal settings the way cfmakeraw(3) does: no echo,
+// no line editing, no signal keys and no output processing
+func makeRaw(t *unix.Termios) {
+	t.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	t.Oflag &^= unix.OPOST
+	t.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	t.Cflag &^= unix.CSIZE | unix.PARENB
+	t.Cflag |= unix.CS8
+	t.Cc[unix.VMIN] = 1
+	t.Cc[unix.VTIME] = 0
+}
+
+// restoreTTY puts back terminal settings saved by openRaw
+func restoreTTY(tty *os.File, saved *unix.Termios) {
+	if saved != nil {
+		unix.IoctlSetTermios(int(tty.Fd()), unix.TCSETS, saved)
+	}
+}
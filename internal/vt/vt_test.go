@@ -0,0 +1,149 @@
+package vt
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// fakeDevice records VT switches instead of issuing ioctls
+type fakeDevice struct {
+	active   int
+	free     int
+	switches []int
+	closed   bool
+}
+
+func (f *fakeDevice) Active() (int, error) { return f.active, nil }
+
+func (f *fakeDevice) Free() (int, error) {
+	if f.free == 0 {
+		return 0, fmt.Errorf("no free virtual console")
+	}
+	return f.free, nil
+}
+
+func (f *fakeDevice) Activate(n int) error {
+	f.switches = append(f.switches, n)
+	f.active = n
+	return nil
+}
+
+func (f *fakeDevice) Close() error {
+	f.closed = true
+	return nil
+}
+
+// newTestConsole returns a console whose VTs are pipes
+func newTestConsole(t *testing.T, dev *fakeDevice, n int) (*Console, *[]int) {
+	opened := &[]int{}
+	c := newConsole(dev, n)
+	c.openTTY = func(n int) (*os.File, *unix.Termios, error) {
+		*opened = append(*opened, n)
+		r, w, err := os.Pipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		t.Cleanup(func() { r.Close() })
+		return w, nil, nil
+	}
+	return c, opened
+}
+
+// TestSwitch tests switching to the screensaver VT and back
+func TestSwitch(t *testing.T) {
+	tests := []struct {
+		name         string
+		vt           int
+		active       int
+		free         int
+		wantOpened   []int
+		wantSwitches []int
+	}{
+		{"configured VT", 8, 2, 0, []int{8}, []int{8, 2}},
+		{"first free VT", 0, 1, 7, []int{7}, []int{7, 1}},
+		{"already on the VT", 8, 8, 0, []int{8}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &fakeDevice{active: tt.active, free: tt.free}
+			c, opened := newTestConsole(t, dev, tt.vt)
+
+			tty, err := c.Switch()
+			if err != nil {
+				t.Fatalf("Switch() error = %v", err)
+			}
+			if again, err := c.Switch(); err != nil || again != tty {
+				t.Errorf("second Switch() = %v, %v; want the same tty", again, err)
+			}
+			if err := c.Restore(); err != nil {
+				t.Fatalf("Restore() error = %v", err)
+			}
+			if err := c.Restore(); err != nil {
+				t.Errorf("second Restore() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(*opened, tt.wantOpened) {
+				t.Errorf("opened VTs = %v, want %v", *opened, tt.wantOpened)
+			}
+			if !reflect.DeepEqual(dev.switches, tt.wantSwitches) {
+				t.Errorf("switches = %v, want %v", dev.switches, tt.wantSwitches)
+			}
+		})
+	}
+}
+
+// TestSwitchNoFreeVT tests that a failed switch leaves nothing open
+func TestSwitchNoFreeVT(t *testing.T) {
+	dev := &fakeDevice{active: 1}
+	c, opened := newTestConsole(t, dev, 0)
+
+	if _, err := c.Switch(); err == nil {
+		t.Fatal("Switch() without a free VT should fail")
+	}
+	if len(*opened) != 0 || len(dev.switches) != 0 {
+		t.Errorf("opened %v, switched %v; want nothing", *opened, dev.switches)
+	}
+
+	if err := c.Close(); err != nil || !dev.closed {
+		t.Errorf("Close() = %v, device closed %v", err, dev.closed)
+	}
+}
+
+// TestMakeRaw tests the raw mode terminal flags
+func TestMakeRaw(t *testing.T) {
+	termios := unix.Termios{
+		Iflag: unix.ICRNL | unix.IXON | unix.IUTF8,
+		Oflag: unix.OPOST | unix.ONLCR,
+		Lflag: unix.ECHO | unix.ICANON | unix.ISIG,
+		Cflag: unix.CS7 | unix.PARENB | unix.CREAD,
+	}
+	makeRaw(&termios)
+
+	if termios.Iflag != unix.IUTF8 {
+		t.Errorf("Iflag = %#o, want only IUTF8 left", termios.Iflag)
+	}
+	if termios.Oflag&unix.OPOST != 0 {
+		t.Error("output processing still enabled")
+	}
+	if termios.Lflag&(unix.ECHO|unix.ICANON|unix.ISIG) != 0 {
+		t.Errorf("Lflag = %#o, want echo, canonical mode and signals off", termios.Lflag)
+	}
+	if termios.Cflag != unix.CS8|unix.CREAD {
+		t.Errorf("Cflag = %#o, want CS8|CREAD", termios.Cflag)
+	}
+	if termios.Cc[unix.VMIN] != 1 || termios.Cc[unix.VTIME] != 0 {
+		t.Errorf("VMIN, VTIME = %d, %d; want 1, 0", termios.Cc[unix.VMIN], termios.Cc[unix.VTIME])
+	}
+}
+
+// TestPath tests VT device paths
+func TestPath(t *testing.T) {
+	if got := Path(8); got != "/dev/tty8" {
+		t.Errorf("Path(8) = %q, want /dev/tty8", got)
+	}
+}
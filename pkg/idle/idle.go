@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	evdev "github.com/gvalkov/golang-evdev"
@@ -23,6 +24,8 @@ type IdleDetector struct {
 	idleTimeout time.Duration
 	idleChan    chan struct{}
 	resumeChan  chan struct{}
+	wayland     *WaylandCGODetector
+	mu          sync.Mutex // Protects idleTimeout and wayland
 }
 
 // Events provides channels for idle and resume events
@@ -50,16 +53,45 @@ func (d *IdleDetector) Events() *Events {
 	}
 }
 
+// SetTimeout changes the idle timeout while the detector is running
+func (d *IdleDetector) SetTimeout(timeout time.Duration) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if timeout == d.idleTimeout {
+		return nil
+	}
+	d.idleTimeout = timeout
+
+	if d.wayland != nil {
+		return d.wayland.SetTimeout(timeout)
+	}
+	return nil
+}
+
+// getTimeout returns the current idle timeout
+func (d *IdleDetector) getTimeout() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.idleTimeout
+}
+
 // Start starts the idle detector
 func (d *IdleDetector) Start(ctx context.Context) error {
 	// Initialize last active time
 	d.lastActive = time.Now()
 
-	log.Printf("Starting idle detector with timeout: %v", d.idleTimeout)
+	log.Printf("Starting idle detector with timeout: %v", d.getTimeout())
 
 	// Detect display server and start appropriate monitor
 	displayServer := detectDisplayServer()
 
+	// On a virtual console input devices are the only activity source
+	if d.config.UseConsole(displayServer) {
+		d.startConsoleMonitor(ctx)
+		return nil
+	}
+
 	// Start monitoring for display server specific idle detection
 	switch displayServer {
 	case "wayland":
@@ -75,6 +107,12 @@ func (d *IdleDetector) Start(ctx context.Context) error {
 	return nil
 }
 
+// DisplayServer returns the display server the detector uses:
+// "wayland", "x11" or "none"
+func DisplayServer() string {
+	return detectDisplayServer()
+}
+
 // detectDisplayServer determines if we're running on Wayland or X11
 func detectDisplayServer() string {
 	if os.Getenv("WAYLAND_DISPLAY") != "" {
@@ -125,7 +163,7 @@ func (d *IdleDetector) startWaylandIdleDetection(ctx context.Context) error {
 		}
 	}
 
-	waylandDetector, err := NewWaylandCGODetector(d.idleTimeout, onIdle, onResume)
+	waylandDetector, err := NewWaylandCGODetector(d.getTimeout(), onIdle, onResume)
 	if err != nil {
 		log.Printf("Failed to create Wayland CGO detector: %v", err)
 		log.Println("Falling back to X11 detection if available")
@@ -139,6 +177,10 @@ func (d *IdleDetector) startWaylandIdleDetection(ctx context.Context) error {
 		return err
 	}
 
+	d.mu.Lock()
+	d.wayland = waylandDetector
+	d.mu.Unlock()
+
 	// Also start direct input device monitoring as a backup
 	// This catches cases where compositor's idle detection has issues (e.g., niri multi-monitor)
 	log.Println("Starting input device monitoring as backup for Wayland")
@@ -153,6 +195,20 @@ func (d *IdleDetector) startWaylandIdleDetection(ctx context.Context) error {
 	return nil
 }
 
+// startConsoleMonitor watches evdev input devices directly. There is no
+// compositor to report idle time, so the daemon's idle timer does the
+// counting and input events reset it.
+func (d *IdleDetector) startConsoleMonitor(ctx context.Context) {
+	devices, err := discoverInputDevices()
+	if err != nil || len(devices) == 0 {
+		log.Println("No readable input devices in /dev/input, console idle detection not available (is the user in the input group?)")
+		return
+	}
+
+	log.Printf("Starting console idle detection on %d input devices", len(devices))
+	go d.startInputDeviceMonitor(ctx)
+}
+
 // startX11Monitor starts X11 idle detection using xprintidle
 func (d *IdleDetector) startX11Monitor(ctx context.Context) {
 	// Check if xprintidle is available
@@ -186,7 +242,7 @@ func (d *IdleDetector) startX11Monitor(ctx context.Context) {
 				idleTime := time.Duration(idleMs) * time.Millisecond
 
 				// Check if we've exceeded the idle threshold
-				if idleTime >= d.idleTimeout {
+				if idleTime >= d.getTimeout() {
 					// Fire idle event
 					select {
 					case d.idleChan <- struct{}{}:
@@ -9,6 +9,7 @@ package idle
 // External C functions defined in wayland_idle.c
 int wayland_cgo_init();
 int wayland_cgo_register_timeout(uint32_t timeout_ms);
+int wayland_cgo_set_timeout(uint32_t timeout_ms);
 int wayland_cgo_dispatch();
 int wayland_cgo_get_fd();
 void wayland_cgo_cleanup();
@@ -133,8 +134,15 @@ func (w *WaylandCGODetector) Start() error {
 				}
 				
 				if n > 0 && (pollFds[0].Revents&unix.POLLIN) != 0 {
-					// Dispatch pending events
-					dispatchRet := C.wayland_cgo_dispatch()
+					// Dispatch pending events. SetTimeout and Stop free
+					// the notification under the same lock, so no event
+					// reaches it once it is gone.
+					w.mu.Lock()
+					dispatchRet := C.int(-1)
+					if w.initialized {
+						dispatchRet = C.wayland_cgo_dispatch()
+					}
+					w.mu.Unlock()
 					if dispatchRet < 0 {
 						log.Printf("Wayland dispatch error: %d", dispatchRet)
 						return
@@ -155,6 +163,24 @@ func (w *WaylandCGODetector) Start() error {
 	return nil
 }
 
+// SetTimeout re-registers the idle notification with a new timeout. It
+// holds the lock the event loop dispatches under.
+func (w *WaylandCGODetector) SetTimeout(timeout time.Duration) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.initialized {
+		return fmt.Errorf("detector not initialized")
+	}
+
+	ret := C.wayland_cgo_set_timeout(C.uint32_t(timeout.Milliseconds()))
+	if ret != 0 {
+		return fmt.Errorf("failed to set timeout: error code %d", ret)
+	}
+	w.timeout = timeout
+	return nil
+}
+
 func (w *WaylandCGODetector) Stop() {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -105,6 +105,33 @@ wl_display_roundtrip(display);
 return 0;
 }
 
+// Replace the idle notification with one using a new timeout
+// No roundtrip here: the Go event loop owns dispatching, and holds off
+// while the old notification is destroyed
+int wayland_cgo_set_timeout(uint32_t timeout_ms) {
+if (!idle_notifier || !seat) {
+return -1;
+}
+
+if (notification) {
+ext_idle_notification_v1_destroy(notification);
+notification = NULL;
+}
+
+notification = ext_idle_notifier_v1_get_idle_notification(
+idle_notifier, timeout_ms, seat);
+
+if (!notification) {
+return -2;
+}
+
+ext_idle_notification_v1_add_listener(notification,
+&idle_notification_listener, NULL);
+
+wl_display_flush(display);
+return 0;
+}
+
 int wayland_cgo_dispatch() {
 	if (!display) {
 		return -1;
@@ -0,0 +1,63 @@
+// power.go - Output power management (DPMS) for long-running screensavers
+package power
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Nomadcxx/sysc-walls/internal/compositor"
+)
+
+// Controller turns outputs on and off
+type Controller interface {
+	// SetPower turns all outputs on or off
+	SetPower(on bool) error
+
+	// Name returns the backend name for logging
+	Name() string
+}
+
+// NewController returns a power controller for the requested method.
+// Method is one of "auto", "wlr" or "ipc". Auto prefers the
+// zwlr_output_power_manager_v1 protocol and falls back to compositor IPC.
+func NewController(method string) (Controller, error) {
+	switch method {
+	case "wlr":
+		return NewWlrController()
+	case "ipc":
+		return newIPCController()
+	case "auto", "":
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			if ctrl, err := NewWlrController(); err == nil {
+				return ctrl, nil
+			}
+		}
+		return newIPCController()
+	default:
+		return nil, fmt.Errorf("unknown power method: %s", method)
+	}
+}
+
+// ipcController uses the compositor's IPC to toggle outputs
+type ipcController struct {
+	comp compositor.Compositor
+}
+
+// newIPCController creates a controller backed by the detected compositor
+func newIPCController() (*ipcController, error) {
+	comp, err := compositor.DetectCompositor()
+	if err != nil {
+		return nil, fmt.Errorf("no compositor available for power control: %w", err)
+	}
+	return &ipcController{comp: comp}, nil
+}
+
+// SetPower turns all outputs on or off via compositor IPC
+func (c *ipcController) SetPower(on bool) error {
+	return c.comp.SetPower(on)
+}
+
+// Name returns the backend name
+func (c *ipcController) Name() string {
+	return "ipc:" + c.comp.Name()
+}
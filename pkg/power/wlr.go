@@ -0,0 +1,238 @@
+// wlr.go - Output power control using zwlr_output_power_manager_v1
+package power
+
+import (
+	"fmt"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
+
+// Output power modes from wlr-output-power-management-unstable-v1
+const (
+	wlrOutputPowerModeOff uint32 = 0
+	wlrOutputPowerModeOn  uint32 = 1
+)
+
+// WlrController toggles outputs through the wlroots output power protocol.
+// Each call opens a short-lived Wayland connection, like wlopm does; the
+// compositor keeps the mode after the client disconnects.
+type WlrController struct{}
+
+// NewWlrController verifies the compositor advertises the protocol
+func NewWlrController() (*WlrController, error) {
+	conn, err := connectWlr()
+	if err != nil {
+		return nil, err
+	}
+	conn.close()
+	return &WlrController{}, nil
+}
+
+// Name returns the backend name
+func (w *WlrController) Name() string {
+	return "wlr-output-power"
+}
+
+// SetPower turns all outputs on or off
+func (w *WlrController) SetPower(on bool) error {
+	conn, err := connectWlr()
+	if err != nil {
+		return err
+	}
+	defer conn.close()
+
+	mode := wlrOutputPowerModeOff
+	if on {
+		mode = wlrOutputPowerModeOn
+	}
+
+	var failed []string
+	for i, output := range conn.outputs {
+		outputPower, err := conn.manager.GetOutputPower(output)
+		if err != nil {
+			return fmt.Errorf("failed to get output power: %w", err)
+		}
+		outputPower.failedHandler = func() {
+			failed = append(failed, fmt.Sprintf("output %d", i))
+		}
+		if err := outputPower.SetMode(mode); err != nil {
+			return fmt.Errorf("failed to set output power mode: %w", err)
+		}
+		defer outputPower.Destroy()
+	}
+
+	if err := conn.roundtrip(); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("compositor rejected power change for %v", failed)
+	}
+	return nil
+}
+
+// wlrConnection holds the globals needed for one power change
+type wlrConnection struct {
+	display  *client.Display
+	registry *client.Registry
+	manager  *wlrOutputPowerManager
+	outputs  []*client.Output
+}
+
+// connectWlr connects to Wayland and binds the power manager and all outputs
+func connectWlr() (*wlrConnection, error) {
+	display, err := client.Connect("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Wayland display: %w", err)
+	}
+
+	registry, err := display.GetRegistry()
+	if err != nil {
+		display.Context().Close()
+		return nil, fmt.Errorf("failed to get registry: %w", err)
+	}
+
+	conn := &wlrConnection{display: display, registry: registry}
+
+	var managerName uint32
+	var outputNames []uint32
+	registry.SetGlobalHandler(func(e client.RegistryGlobalEvent) {
+		switch e.Interface {
+		case "zwlr_output_power_manager_v1":
+			managerName = e.Name
+		case "wl_output":
+			outputNames = append(outputNames, e.Name)
+		}
+	})
+
+	if err := conn.roundtrip(); err != nil {
+		conn.close()
+		return nil, err
+	}
+
+	if managerName == 0 {
+		conn.close()
+		return nil, fmt.Errorf("compositor does not support zwlr_output_power_manager_v1 protocol")
+	}
+
+	conn.manager = newWlrOutputPowerManager(display.Context())
+	if err := registry.Bind(managerName, "zwlr_output_power_manager_v1", 1, conn.manager); err != nil {
+		conn.close()
+		return nil, fmt.Errorf("failed to bind output power manager: %w", err)
+	}
+
+	for _, name := range outputNames {
+		output := client.NewOutput(display.Context())
+		if err := registry.Bind(name, "wl_output", 1, output); err != nil {
+			conn.close()
+			return nil, fmt.Errorf("failed to bind output: %w", err)
+		}
+		conn.outputs = append(conn.outputs, output)
+	}
+
+	return conn, nil
+}
+
+// roundtrip blocks until the compositor has processed all previous requests
+func (c *wlrConnection) roundtrip() error {
+	callback, err := c.display.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync: %w", err)
+	}
+	defer callback.Destroy()
+
+	done := false
+	callback.SetDoneHandler(func(_ client.CallbackDoneEvent) {
+		done = true
+	})
+
+	for !done {
+		if err := c.display.Context().Dispatch(); err != nil {
+			return fmt.Errorf("dispatch error: %w", err)
+		}
+	}
+	return nil
+}
+
+// close releases the Wayland connection
+func (c *wlrConnection) close() {
+	if c.manager != nil {
+		c.manager.Destroy()
+	}
+	c.display.Context().Close()
+}
+
+// wlrOutputPowerManager is the zwlr_output_power_manager_v1 global
+type wlrOutputPowerManager struct {
+	client.BaseProxy
+}
+
+func newWlrOutputPowerManager(ctx *client.Context) *wlrOutputPowerManager {
+	m := &wlrOutputPowerManager{}
+	ctx.Register(m)
+	return m
+}
+
+// GetOutputPower creates a power control object for an output (opcode 0)
+func (m *wlrOutputPowerManager) GetOutputPower(output *client.Output) (*wlrOutputPower, error) {
+	id := newWlrOutputPower(m.Context())
+	const opcode = 0
+	const reqBufLen = 8 + 4 + 4
+	var reqBuf [reqBufLen]byte
+	client.PutUint32(reqBuf[0:4], m.ID())
+	client.PutUint32(reqBuf[4:8], uint32(reqBufLen<<16|opcode&0x0000ffff))
+	client.PutUint32(reqBuf[8:12], id.ID())
+	client.PutUint32(reqBuf[12:16], output.ID())
+	err := m.Context().WriteMsg(reqBuf[:], nil)
+	return id, err
+}
+
+// Destroy destroys the manager (opcode 1)
+func (m *wlrOutputPowerManager) Destroy() error {
+	defer m.Context().Unregister(m)
+	return writeEmptyRequest(&m.BaseProxy, 1)
+}
+
+// wlrOutputPower is a zwlr_output_power_v1 object for a single output
+type wlrOutputPower struct {
+	client.BaseProxy
+	failedHandler func()
+}
+
+func newWlrOutputPower(ctx *client.Context) *wlrOutputPower {
+	p := &wlrOutputPower{}
+	ctx.Register(p)
+	return p
+}
+
+// SetMode sets the output power mode (opcode 0)
+func (p *wlrOutputPower) SetMode(mode uint32) error {
+	const opcode = 0
+	const reqBufLen = 8 + 4
+	var reqBuf [reqBufLen]byte
+	client.PutUint32(reqBuf[0:4], p.ID())
+	client.PutUint32(reqBuf[4:8], uint32(reqBufLen<<16|opcode&0x0000ffff))
+	client.PutUint32(reqBuf[8:12], mode)
+	return p.Context().WriteMsg(reqBuf[:], nil)
+}
+
+// Destroy destroys the power control object (opcode 1)
+func (p *wlrOutputPower) Destroy() error {
+	defer p.Context().Unregister(p)
+	return writeEmptyRequest(&p.BaseProxy, 1)
+}
+
+// Dispatch handles mode (0) and failed (1) events
+func (p *wlrOutputPower) Dispatch(opcode uint32, fd int, data []byte) {
+	if opcode == 1 && p.failedHandler != nil {
+		p.failedHandler()
+	}
+}
+
+// writeEmptyRequest sends a request without arguments
+func writeEmptyRequest(proxy *client.BaseProxy, opcode uint32) error {
+	const reqBufLen = 8
+	var reqBuf [reqBufLen]byte
+	client.PutUint32(reqBuf[0:4], proxy.ID())
+	client.PutUint32(reqBuf[4:8], uint32(reqBufLen<<16|opcode&0x0000ffff))
+	return proxy.Context().WriteMsg(reqBuf[:], nil)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
@@ -139,3 +140,13 @@ func GetPID() (int, error) {
 func GetPPID() (int, error) {
 	return os.Getppid(), nil
 }
+
+// RuntimeDir returns the per-user directory for sysc-walls' runtime state,
+// in XDG_RUNTIME_DIR or a private directory under the system temp dir
+func RuntimeDir() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" || !filepath.IsAbs(dir) {
+		dir = filepath.Join(os.TempDir(), fmt.Sprintf("sysc-walls-%d", os.Getuid()))
+	}
+	return filepath.Join(dir, "sysc-walls")
+}
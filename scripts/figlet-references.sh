@@ -0,0 +1,42 @@
+#!/bin/bash
+# figlet-references.sh - Render reference text with the real figlet
+# The renders in internal/figlet/testdata/figlet are checked against the
+# bundled fonts and the smushing fixture in internal/figlet/testdata by
+# TestFigletRenders. Each file is the text on its first line followed by
+# the output of:
+#
+#   figlet -w 1000 -d <font dir> -f <font> <text>
+
+set -e
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+SYSC_WALLS_ROOT="$(dirname "$SCRIPT_DIR")"
+OUT="$SYSC_WALLS_ROOT/internal/figlet/testdata/figlet"
+
+if ! command -v figlet >/dev/null; then
+    echo "Error: figlet not found. Install it (e.g. apt install figlet) and run again."
+    exit 1
+fi
+
+# font dir|font|text
+REFERENCES=(
+    "internal/figlet/fonts|kompaktblk|12:30 PM"
+    "internal/figlet/fonts|block|HI"
+    "internal/figlet/fonts|dots|Ok"
+    "internal/figlet/testdata|smush|EFUVHIPQXYSTZWBC"
+)
+
+mkdir -p "$OUT"
+cd "$SYSC_WALLS_ROOT"
+for ref in "${REFERENCES[@]}"; do
+    dir="${ref%%|*}"
+    rest="${ref#*|}"
+    font="${rest%%|*}"
+    text="${rest#*|}"
+    {
+        printf '%s\n' "$text"
+        figlet -w 1000 -d "$dir" -f "$font" "$text"
+    } > "$OUT/$font.txt"
+    echo "Wrote $OUT/$font.txt"
+done
+echo "figlet: $(figlet -v | head -1)"